@@ -12,6 +12,30 @@ const (
 	ProviderDataPathDefault     = "./tmp/indexed-provider-data.json"
 	ProviderDataPathDescription = "path to indexed markets and providers"
 
+	// index
+	HTTPCassetteModeFlag        = "http-cassette-mode"
+	HTTPCassetteModeDefault     = ""
+	HTTPCassetteModeDescription = "record HTTP responses to, or replay them from, the cassette directory (one of: record, replay)"
+
+	HTTPCassetteDirFlag        = "http-cassette-dir"
+	HTTPCassetteDirDefault     = "./tmp/cassettes"
+	HTTPCassetteDirDescription = "directory of recorded HTTP cassettes used with --http-cassette-mode"
+
+	FromArchiveFlag        = "from-archive"
+	FromArchiveDefault     = ""
+	FromArchiveDescription = "replay the files archived in this directory by --archive-intermediate-steps instead of fetching markets and assets"
+
+	MergeProviderDataFlag        = "merge-provider-data"
+	MergeProviderDataDescription = "merge the markets of previous index runs from these provider data files, keeping the most recently observed values"
+
+	CMCCacheDirFlag        = "cmc-cache-dir"
+	CMCCacheDirDefault     = ""
+	CMCCacheDirDescription = "directory to cache CoinMarketCap responses in, overrides index.coinmarketcap.cache.dir"
+
+	CMCOfflineFlag        = "cmc-offline"
+	CMCOfflineDefault     = false
+	CMCOfflineDescription = "serve CoinMarketCap responses from the cache regardless of their age, failing on cache misses"
+
 	// override
 	MarketMapGeneratedFlag        = "market-map"
 	MarketMapGeneratedDefault     = "./tmp/generated-market-map.json"
@@ -80,6 +104,10 @@ const (
 	ProviderDataOutPathDefault     = ProviderDataPathDefault
 	ProviderDataOutPathDescription = "path to output indexed markets and providers"
 
+	ProviderDBOutPathFlag        = "provider-db-out"
+	ProviderDBOutPathDefault     = ""
+	ProviderDBOutPathDescription = "path to a SQLite database to record the index run in, created if it does not exist"
+
 	// generate
 	MarketMapOutPathGeneratedFlag         = "generated-market-map-out"
 	MarketMapOutPathGeneratedDefault      = MarketMapGeneratedDefault
@@ -17,6 +17,7 @@ import (
 	"github.com/skip-mev/connect-mmu/generator"
 	"github.com/skip-mev/connect-mmu/generator/types"
 	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
@@ -45,12 +46,22 @@ func GenerateCmd() *cobra.Command {
 
 			logger.Info("successfully read config", zap.String("path", flags.configPath))
 
-			mm, exclusionReasons, err := GenerateFromConfig(ctx, logger, *cfg.Generate, *cfg.Chain, flags.providerDataPath)
+			aliases := cfg.AliasRegistry()
+			mm, exclusionReasons, err := GenerateFromConfig(ctx, logger, *cfg.Generate, *cfg.Chain, aliases, flags.providerDataPath)
 			if err != nil {
 				logger.Error("failed to generate marketmap", zap.Error(err))
 				return err
 			}
 
+			for _, alias := range aliases.Report() {
+				logger.Info("applied alias",
+					zap.String("kind", alias.Kind),
+					zap.String("alias", alias.Alias),
+					zap.String("canonical", alias.Canonical),
+					zap.Int("count", alias.Count),
+				)
+			}
+
 			if flags.marketMapOutPath != "" {
 				logger.Info("writing markets", zap.String("file", flags.marketMapOutPath))
 				if err := file.WriteMarketMapToFile(flags.marketMapOutPath, mm); err != nil {
@@ -95,6 +106,7 @@ func GenerateFromConfig(
 	logger *zap.Logger,
 	cfg config.GenerateConfig,
 	chainConfig config.ChainConfig,
+	aliases *symbols.AliasRegistry,
 	providerPath string,
 ) (mmtypes.MarketMap, types.ExclusionReasons, error) {
 	providerStore, err := provider.NewMemoryStoreFromFile(providerPath)
@@ -116,7 +128,7 @@ func GenerateFromConfig(
 
 	logger.Info("successfully got on chain marketmap", zap.Int("num markets", len(onChainMarketMap.Markets)))
 
-	g := generator.New(logger, providerStore)
+	g := generator.New(logger, providerStore, aliases)
 
 	mm, exclusionReasons, err := g.GenerateMarketMap(ctx, cfg, onChainMarketMap)
 	if err != nil {
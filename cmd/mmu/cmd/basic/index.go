@@ -3,16 +3,20 @@ package basic
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
 	indexer "github.com/skip-mev/connect-mmu/market-indexer"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-func IndexCmd() *cobra.Command {
+func IndexCmd(ingesterRegistry *ingesters.Registry) *cobra.Command {
 	var flags indexCmdFlags
 
 	cmd := &cobra.Command{
@@ -35,17 +39,85 @@ func IndexCmd() *cobra.Command {
 				return errors.New("index configuration missing from mmu config")
 			}
 
-			providerStore := provider.NewMemoryStore()
+			if flags.cmcCacheDir != "" {
+				cfg.Index.CoinMarketCapConfig.Cache.Dir = flags.cmcCacheDir
+			}
+			if flags.cmcOffline {
+				cfg.Index.CoinMarketCapConfig.Cache.Offline = true
+			}
+			if err := cfg.Index.CoinMarketCapConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid coinmarketcap config: %w", err)
+			}
+			if cfg.Index.CoinMarketCapConfig.Cache.Enabled() {
+				logger.Info("caching coinmarketcap responses",
+					zap.String("dir", cfg.Index.CoinMarketCapConfig.Cache.Dir),
+					zap.Bool("offline", cfg.Index.CoinMarketCapConfig.Cache.Offline),
+				)
+			}
+
+			var httpOpts []http.ClientOption
+			if flags.httpCassetteMode != "" {
+				transport, err := http.NewCassetteTransport(http.CassetteMode(flags.httpCassetteMode), flags.httpCassetteDir, nil)
+				if err != nil {
+					return fmt.Errorf("failed to create cassette transport: %w", err)
+				}
+				logger.Info("using http cassettes", zap.String("mode", flags.httpCassetteMode), zap.String("dir", flags.httpCassetteDir))
+				httpOpts = append(httpOpts, http.WithTransport(transport))
+			}
 
-			idx, err := indexer.NewIndexer(*cfg.Index, logger, providerStore, flags.archiveIntermediateSteps)
+			// a replay stamps its records with the observation time of the archived run, so that it writes the
+			// same provider data.
+			observedAt := time.Now().UTC()
+			if flags.fromArchive != "" {
+				run, err := indexer.ReadArchivedRun(flags.fromArchive)
+				if err != nil {
+					return err
+				}
+				observedAt = run.ObservedAt
+			}
+			providerStore := provider.NewMemoryStoreObservedAt(observedAt)
+
+			var idx *indexer.Indexer
+			if flags.fromArchive != "" {
+				logger.Info("replaying archived index", zap.String("dir", flags.fromArchive), zap.Time("observed_at", observedAt))
+				idx, err = indexer.NewIndexerFromArchive(*cfg.Index, logger, providerStore, ingesterRegistry, cfg.AliasRegistry(), flags.fromArchive, httpOpts...)
+			} else {
+				idx, err = indexer.NewIndexer(*cfg.Index, logger, providerStore, ingesterRegistry, cfg.AliasRegistry(), flags.archiveIntermediateSteps, httpOpts...)
+			}
 			if err != nil {
 				return err
 			}
 
+			if flags.fromArchive == "" {
+				if err := idx.ArchiveRun(indexer.ArchivedRun{ObservedAt: observedAt}); err != nil {
+					return fmt.Errorf("failed to archive index run: %w", err)
+				}
+			}
+
 			if err := idx.Index(ctx); err != nil {
 				return err
 			}
 
+			// previous runs are merged after indexing, so that they only fill in markets that were not indexed.
+			for _, path := range flags.mergeProviderData {
+				logger.Info("merging provider data", zap.String("path", path))
+				document, err := provider.ReadDocument(path)
+				if err != nil {
+					return fmt.Errorf("failed to read provider data to merge: %w", err)
+				}
+				if err := providerStore.Merge(ctx, document); err != nil {
+					return fmt.Errorf("failed to merge provider data from %s: %w", path, err)
+				}
+			}
+			if len(flags.mergeProviderData) > 0 {
+				// merging previous runs records the asset info changes since them.
+				report := providerStore.GetIndexReport(ctx)
+				report.AssetInfoChanges = providerStore.GetAssetInfoChanges(ctx)
+				if err := providerStore.SetIndexReport(ctx, report); err != nil {
+					return err
+				}
+			}
+
 			if flags.providerDataOutPath != "" {
 				logger.Info(fmt.Sprintf("Writing indexed markets to path: %s", flags.providerDataOutPath))
 				if err := providerStore.WriteToPath(ctx, flags.providerDataOutPath); err != nil {
@@ -53,6 +125,19 @@ func IndexCmd() *cobra.Command {
 				}
 			}
 
+			if flags.providerDBOutPath != "" {
+				logger.Info("recording index run in provider database", zap.String("path", flags.providerDBOutPath))
+				providerDB, err := provider.NewSQLiteStore(flags.providerDBOutPath)
+				if err != nil {
+					return err
+				}
+				defer providerDB.Close()
+
+				if err := providerDB.Merge(ctx, providerStore.CreateOutputDocument()); err != nil {
+					return fmt.Errorf("failed to record index run in provider database: %w", err)
+				}
+			}
+
 			return nil
 		},
 	}
@@ -65,11 +150,26 @@ func IndexCmd() *cobra.Command {
 type indexCmdFlags struct {
 	configPath               string
 	providerDataOutPath      string
+	providerDBOutPath        string
 	archiveIntermediateSteps bool
+	httpCassetteMode         string
+	httpCassetteDir          string
+	cmcCacheDir              string
+	cmcOffline               bool
+	fromArchive              string
+	mergeProviderData        []string
 }
 
 func indexCmdConfigureFlags(cmd *cobra.Command, flags *indexCmdFlags) {
 	cmd.Flags().StringVar(&flags.configPath, ConfigPathFlag, ConfigPathDefault, ConfigPathDescription)
 	cmd.Flags().StringVar(&flags.providerDataOutPath, ProviderDataOutPathFlag, ProviderDataOutPathDefault, ProviderDataOutPathDescription)
+	cmd.Flags().StringVar(&flags.providerDBOutPath, ProviderDBOutPathFlag, ProviderDBOutPathDefault, ProviderDBOutPathDescription)
 	cmd.Flags().BoolVar(&flags.archiveIntermediateSteps, ArchiveIntermediateStepsFlag, ArchiveIntermediateStepsDefault, ArchiveIntermediateStepsDescription)
+	cmd.Flags().StringVar(&flags.httpCassetteMode, HTTPCassetteModeFlag, HTTPCassetteModeDefault, HTTPCassetteModeDescription)
+	cmd.Flags().StringVar(&flags.httpCassetteDir, HTTPCassetteDirFlag, HTTPCassetteDirDefault, HTTPCassetteDirDescription)
+	cmd.Flags().StringVar(&flags.cmcCacheDir, CMCCacheDirFlag, CMCCacheDirDefault, CMCCacheDirDescription)
+	cmd.Flags().BoolVar(&flags.cmcOffline, CMCOfflineFlag, CMCOfflineDefault, CMCOfflineDescription)
+	cmd.Flags().StringVar(&flags.fromArchive, FromArchiveFlag, FromArchiveDefault, FromArchiveDescription)
+	cmd.Flags().StringSliceVar(&flags.mergeProviderData, MergeProviderDataFlag, nil, MergeProviderDataDescription)
+	cmd.MarkFlagsMutuallyExclusive(FromArchiveFlag, ArchiveIntermediateStepsFlag)
 }
@@ -99,7 +99,7 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 		return errors.New("generate configuration missing from mmu config")
 	}
 
-	generated, exclusionReasons, err := basic.GenerateFromConfig(ctx, logger, *cfg.Generate, *cfg.Chain, flags.providerDataPath)
+	generated, exclusionReasons, err := basic.GenerateFromConfig(ctx, logger, *cfg.Generate, *cfg.Chain, cfg.AliasRegistry(), flags.providerDataPath)
 	if err != nil {
 		logger.Error("failed to generate marketmap", zap.Error(err))
 		return err
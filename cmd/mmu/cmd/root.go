@@ -7,10 +7,11 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/composite"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/utils"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/signing"
 )
 
-func RootCmd(registry *signing.Registry) *cobra.Command {
+func RootCmd(registry *signing.Registry, ingesterRegistry *ingesters.Registry) *cobra.Command {
 	var logLevel string
 	rootCmd := &cobra.Command{
 		Use:   "mmu",
@@ -26,7 +27,7 @@ func RootCmd(registry *signing.Registry) *cobra.Command {
 
 	// Basic Commands
 	rootCmd.AddCommand(
-		basic.IndexCmd(),
+		basic.IndexCmd(ingesterRegistry),
 		basic.GenerateCmd(),
 		basic.OverrideCmd(),
 		basic.UpsertsCmd(),
@@ -37,7 +38,10 @@ func RootCmd(registry *signing.Registry) *cobra.Command {
 	rootCmd.AddCommand(
 		utils.ConfigInitCmd(),
 		utils.DiffCmd(),
+		utils.IndexDiffCmd(),
+		utils.ProviderDBCmd(),
 		utils.ValidateCmd(),
+		utils.IngestersCmd(ingesterRegistry),
 	)
 
 	// Composite Commands
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skip-mev/connect-mmu/diffs"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+func IndexDiffCmd() *cobra.Command {
+	var flags indexDiffCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "index-diff",
+		Short: "report the changes between two indexed provider data files",
+		Long: "compares two indexed provider data files and reports the provider markets that were added or removed," +
+			" large swings in market volume and liquidity, CMC ID remaps, and asset rank and tag changes.",
+		Example: "index-diff --old old-provider-data.json --new indexed-provider-data.json --output index-diff",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if flags.format != formatMarkdown && flags.format != formatJSON {
+				return fmt.Errorf("unknown format %q: must be %s or %s", flags.format, formatMarkdown, formatJSON)
+			}
+
+			oldDoc, err := provider.ReadDocument(flags.oldPath)
+			if err != nil {
+				return fmt.Errorf("failed to read old provider data: %w", err)
+			}
+
+			newDoc, err := provider.ReadDocument(flags.newPath)
+			if err != nil {
+				return fmt.Errorf("failed to read new provider data: %w", err)
+			}
+
+			diff := diffs.DiffIndexes(oldDoc, newDoc, diffs.IndexDiffOptions{
+				VolumeThreshold:    flags.volumeThreshold,
+				LiquidityThreshold: flags.liquidityThreshold,
+			})
+
+			diffJSON, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return err
+			}
+			diffMarkdown := diff.Markdown()
+
+			if flags.format == formatJSON {
+				cmd.Println(string(diffJSON))
+			} else {
+				cmd.Print(diffMarkdown)
+			}
+
+			if flags.outputPath != "" {
+				if err := file.WriteBytesToFile(flags.outputPath+".json", diffJSON); err != nil {
+					return fmt.Errorf("failed to write diff to file: %w", err)
+				}
+				if err := file.WriteBytesToFile(flags.outputPath+".md", []byte(diffMarkdown)); err != nil {
+					return fmt.Errorf("failed to write diff to file: %w", err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	indexDiffCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type indexDiffCmdFlags struct {
+	oldPath            string
+	newPath            string
+	outputPath         string
+	format             string
+	volumeThreshold    float64
+	liquidityThreshold float64
+}
+
+func indexDiffCmdConfigureFlags(cmd *cobra.Command, flags *indexDiffCmdFlags) {
+	const (
+		flagOld                = "old"
+		flagNew                = "new"
+		flagFormat             = "format"
+		flagVolumeThreshold    = "volume-threshold"
+		flagLiquidityThreshold = "liquidity-threshold"
+	)
+
+	cmd.Flags().StringVar(&flags.oldPath, flagOld, "", "path to the old indexed provider data")
+	cmd.Flags().StringVar(&flags.newPath, flagNew, "", "path to the new indexed provider data")
+	cmd.Flags().StringVar(&flags.outputPath, flagOutput, "", "writes the diff to <output>.json and <output>.md")
+	cmd.Flags().StringVar(&flags.format, flagFormat, formatMarkdown, "format of the diff printed to stdout (markdown, json)")
+	cmd.Flags().Float64Var(&flags.volumeThreshold, flagVolumeThreshold, diffs.DefaultSwingThreshold,
+		"minimum relative change of a market's volume to report, e.g. 0.5 for 50%")
+	cmd.Flags().Float64Var(&flags.liquidityThreshold, flagLiquidityThreshold, diffs.DefaultSwingThreshold,
+		"minimum relative change of a market's liquidity to report")
+
+	cmd.MarkFlagRequired(flagOld)
+	cmd.MarkFlagRequired(flagNew)
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+)
+
+func IngestersCmd(registry *ingesters.Registry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ingesters",
+		Short:   "list the supported ingesters",
+		Example: "mmu ingesters",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg := config.DefaultMarketConfig()
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tPROVIDER NAMES\tCMC EXCHANGES")
+			for _, registration := range registry.Registrations() {
+				venues := registration.GetVenues(cfg)
+				providerNames := make([]string, 0, len(venues))
+				slugs := make([]string, 0, len(venues))
+				for _, venue := range venues {
+					providerNames = append(providerNames, venue.ProviderName)
+					slugs = append(slugs, venue.CMCExchangeSlug)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", registration.Name, strings.Join(providerNames, ","), strings.Join(slugs, ","))
+			}
+
+			return w.Flush()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	flagDB           = "db"
+	flagObservedAt   = "observed-at"
+	flagProviders    = "providers"
+	flagQuotes       = "quotes"
+	flagMinUsdVolume = "min-usd-volume"
+	flagMinLiquidity = "min-liquidity"
+	flagMinRank      = "min-rank"
+	flagMaxRank      = "max-rank"
+	flagCMCTags      = "cmc-tags"
+	flagBaseCMCIDs   = "base-cmc-ids"
+	flagMaxMarketAge = "max-market-age"
+)
+
+func ProviderDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-db",
+		Short: "convert indexed provider data to and from a SQLite database",
+		Long: "imports indexed provider data files into a SQLite database that keeps the history of every index run," +
+			" exports the most recently observed markets of a database to a provider data file, and lists the markets" +
+			" of a database that pass a set of filters.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		providerDBImportCmd(),
+		providerDBExportCmd(),
+		providerDBMarketsCmd(),
+	)
+
+	return cmd
+}
+
+func providerDBImportCmd() *cobra.Command {
+	var (
+		dbPath     string
+		observedAt map[string]string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import [provider data files...]",
+		Short: "import indexed provider data files into a SQLite database",
+		Example: "provider-db import --db provider-data.db old-provider-data.json indexed-provider-data.json\n" +
+			"provider-db import --db provider-data.db --observed-at legacy.json=2024-01-01T00:00:00Z legacy.json",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := provider.NewSQLiteStore(dbPath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			for _, path := range args {
+				document, err := provider.ReadDocument(path)
+				if err != nil {
+					return fmt.Errorf("failed to read provider data: %w", err)
+				}
+				if err := setMissingObservedAt(path, &document, observedAt[path]); err != nil {
+					return err
+				}
+				if err := store.Merge(cmd.Context(), document); err != nil {
+					return fmt.Errorf("failed to import provider data from %s: %w", path, err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, flagDB, "", "path to the SQLite database, created if it does not exist")
+	cmd.Flags().StringToStringVar(&observedAt, flagObservedAt, nil,
+		"RFC 3339 observation times of provider data files written before observation times were recorded, as path=time")
+	cmd.MarkFlagRequired(flagDB)
+
+	return cmd
+}
+
+// setMissingObservedAt sets the observation time of the records of the document at path that have none, so that
+// the runs of legacy documents are kept apart. The time is value, if set, or the start of the document's run.
+func setMissingObservedAt(path string, document *provider.Document, value string) error {
+	var observedAt time.Time
+	switch {
+	case value != "":
+		var err error
+		observedAt, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("invalid observation time of %s: %w", path, err)
+		}
+	case document.Run != nil:
+		observedAt = document.Run.StartedAt
+	}
+
+	if observedAt.IsZero() {
+		if document.MissingObservedAt() > 0 {
+			return fmt.Errorf("provider data %s has records without an observation time: set it with --%s %s=<time>", path, flagObservedAt, path)
+		}
+		return nil
+	}
+
+	document.SetMissingObservedAt(observedAt)
+	return nil
+}
+
+func providerDBExportCmd() *cobra.Command {
+	var (
+		dbPath     string
+		outputPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "export the most recently observed markets of a SQLite database to a provider data file",
+		Example: "provider-db export --db provider-data.db --output indexed-provider-data.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// opening a database that does not exist would create an empty one.
+			if _, err := os.Stat(dbPath); err != nil {
+				return fmt.Errorf("failed to open provider database: %w", err)
+			}
+
+			store, err := provider.NewSQLiteStore(dbPath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			return store.WriteToPath(cmd.Context(), outputPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, flagDB, "", "path to the SQLite database")
+	cmd.Flags().StringVar(&outputPath, flagOutput, "", "path to write the provider data to")
+	cmd.MarkFlagRequired(flagDB)
+	cmd.MarkFlagRequired(flagOutput)
+
+	return cmd
+}
+
+func providerDBMarketsCmd() *cobra.Command {
+	var (
+		dbPath     string
+		outputPath string
+		params     provider.GetFilteredProviderMarketsParams
+	)
+
+	cmd := &cobra.Command{
+		Use:   "markets",
+		Short: "list the most recently observed markets of a SQLite database that pass the given filters",
+		Example: "provider-db markets --db provider-data.db --providers coinbase_ws,binance_ws --quotes USD,USDT\n" +
+			"provider-db markets --db provider-data.db --providers uniswapv3_api-ethereum --min-liquidity 10000 --max-rank 500",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// opening a database that does not exist would create an empty one.
+			if _, err := os.Stat(dbPath); err != nil {
+				return fmt.Errorf("failed to open provider database: %w", err)
+			}
+
+			store, err := provider.NewSQLiteStore(dbPath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			rows, err := store.GetProviderMarkets(cmd.Context(), params)
+			if err != nil {
+				return fmt.Errorf("failed to get provider markets: %w", err)
+			}
+
+			markets := make([]providerMarketRow, len(rows))
+			for i, row := range rows {
+				markets[i] = newProviderMarketRow(row)
+			}
+
+			bz, err := json.MarshalIndent(markets, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" {
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return err
+			}
+			return os.WriteFile(outputPath, bz, 0o600)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, flagDB, "", "path to the SQLite database")
+	cmd.Flags().StringVar(&outputPath, flagOutput, "", "path to write the markets to, instead of stdout")
+	cmd.Flags().StringSliceVar(&params.ProviderNames, flagProviders, nil, "providers of the markets")
+	cmd.Flags().StringSliceVar(&params.TargetQuotes, flagQuotes, nil, "quotes of the markets")
+	cmd.Flags().Float64Var(&params.MinUsdVolume, flagMinUsdVolume, 0, "minimum 24 hour volume of the markets in USD")
+	cmd.Flags().Float64Var(&params.MinLiquidity, flagMinLiquidity, 0, "minimum ±2% depth in USD of both sides of the markets' order books")
+	cmd.Flags().Int64Var(&params.MinRank, flagMinRank, 0, "minimum CMC rank of the markets' base assets")
+	cmd.Flags().Int64Var(&params.MaxRank, flagMaxRank, 0, "maximum CMC rank of the markets' base assets")
+	cmd.Flags().StringSliceVar(&params.CMCTags, flagCMCTags, nil, "CMC tags, one of which the markets' base assets must have")
+	cmd.Flags().Int64SliceVar(&params.BaseCmcIDs, flagBaseCMCIDs, nil, "CMC IDs of the markets' base assets")
+	cmd.Flags().DurationVar(&params.MaxMarketAge, flagMaxMarketAge, 0,
+		"excludes markets observed longer than this before the most recently observed market")
+	cmd.MarkFlagRequired(flagDB)
+	cmd.MarkFlagRequired(flagProviders)
+
+	return cmd
+}
+
+// providerMarketRow is a market listed by provider-db markets, with its metadata as JSON rather than bytes.
+type providerMarketRow struct {
+	provider.GetFilteredProviderMarketsRow
+	MetadataJSON json.RawMessage `json:"MetadataJSON,omitempty"`
+}
+
+func newProviderMarketRow(row provider.GetFilteredProviderMarketsRow) providerMarketRow {
+	market := providerMarketRow{GetFilteredProviderMarketsRow: row}
+	if len(row.MetadataJSON) > 0 {
+		market.MetadataJSON = row.MetadataJSON
+	}
+	return market
+}
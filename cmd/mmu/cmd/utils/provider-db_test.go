@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// writeLegacyDocument writes a provider data file without a schema version or observation times, with a BTC/USD
+// market of the given volume.
+func writeLegacyDocument(t *testing.T, dir, name string, volume float64) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(`{
+	"asset_infos": [
+		{"id": 0, "symbol": "BTC", "cmc_id": 1, "cmc_id_valid": true, "rank": 1, "rank_valid": true},
+		{"id": 1, "symbol": "USD", "cmc_id": 2781, "cmc_id_valid": true}
+	],
+	"provider_markets": [
+		{
+			"id": 0, "target_base": "BTC", "target_quote": "USD", "off_chain_ticker": "BTC-USD",
+			"provider_name": "coinbase_ws", "quote_volume": %f, "base_asset_info_id": 0, "quote_asset_info_id": 1
+		}
+	]
+}`, volume)), 0o600))
+
+	return path
+}
+
+func TestProviderDBImportLegacyDocuments(t *testing.T) {
+	dir := t.TempDir()
+	older := writeLegacyDocument(t, dir, "older.json", 1000)
+	newer := writeLegacyDocument(t, dir, "newer.json", 2000)
+	dbPath := filepath.Join(dir, "provider.db")
+
+	// legacy documents cannot be imported without an observation time.
+	cmd := providerDBImportCmd()
+	cmd.SetArgs([]string{"--db", dbPath, older, newer})
+	require.ErrorContains(t, cmd.ExecuteContext(context.Background()), "has records without an observation time")
+
+	// the newer document is imported first, which must not replace its values with the older ones.
+	cmd = providerDBImportCmd()
+	cmd.SetArgs([]string{
+		"--db", dbPath,
+		"--observed-at", older + "=2024-01-01T00:00:00Z",
+		"--observed-at", newer + "=2024-01-02T00:00:00Z",
+		newer, older,
+	})
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+	store, err := provider.NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer store.Close()
+
+	// both runs are kept in the history.
+	var runs int
+	require.NoError(t, store.DB().QueryRow(`SELECT COUNT(DISTINCT observed_at) FROM provider_market_observations`).Scan(&runs))
+	require.Equal(t, 2, runs)
+
+	document, err := store.CreateOutputDocument(context.Background())
+	require.NoError(t, err)
+	require.Len(t, document.ProviderMarkets, 1)
+	require.Equal(t, 2000.0, document.ProviderMarkets[0].QuoteVolume)
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), document.ProviderMarkets[0].ObservedAt)
+}
+
+func TestProviderDBMarkets(t *testing.T) {
+	dir := t.TempDir()
+	path := writeLegacyDocument(t, dir, "provider-data.json", 1000)
+	dbPath := filepath.Join(dir, "provider.db")
+
+	cmd := providerDBImportCmd()
+	cmd.SetArgs([]string{"--db", dbPath, "--observed-at", path + "=2024-01-01T00:00:00Z", path})
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+	tcs := []struct {
+		name    string
+		args    []string
+		tickers []string
+	}{
+		{
+			name:    "no filters",
+			args:    []string{"--providers", "coinbase_ws"},
+			tickers: []string{"BTC-USD"},
+		},
+		{
+			name:    "other provider",
+			args:    []string{"--providers", "binance_ws"},
+			tickers: []string{},
+		},
+		{
+			name:    "matching quote and rank",
+			args:    []string{"--providers", "coinbase_ws", "--quotes", "USD,USDT", "--max-rank", "10", "--base-cmc-ids", "1"},
+			tickers: []string{"BTC-USD"},
+		},
+		{
+			name:    "other quote",
+			args:    []string{"--providers", "coinbase_ws", "--quotes", "USDT"},
+			tickers: []string{},
+		},
+		{
+			name:    "rank out of bounds",
+			args:    []string{"--providers", "coinbase_ws", "--min-rank", "2"},
+			tickers: []string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "markets.json")
+
+			cmd := providerDBMarketsCmd()
+			cmd.SetArgs(append([]string{"--db", dbPath, "--output", outputPath}, tc.args...))
+			require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+			bz, err := os.ReadFile(outputPath)
+			require.NoError(t, err)
+
+			var markets []provider.GetFilteredProviderMarketsRow
+			require.NoError(t, json.Unmarshal(bz, &markets))
+
+			tickers := make([]string, len(markets))
+			for i, market := range markets {
+				tickers[i] = market.OffChainTicker
+			}
+			require.Equal(t, tc.tickers, tickers)
+		})
+	}
+}
+
+func TestProviderDBMarketsMissingDatabase(t *testing.T) {
+	cmd := providerDBMarketsCmd()
+	cmd.SetArgs([]string{"--db", filepath.Join(t.TempDir(), "missing.db"), "--providers", "coinbase_ws"})
+	require.ErrorContains(t, cmd.ExecuteContext(context.Background()), "failed to open provider database")
+}
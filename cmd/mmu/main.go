@@ -14,6 +14,8 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/consts"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/lib/aws"
+	indexer "github.com/skip-mev/connect-mmu/market-indexer"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/signing"
 	"github.com/skip-mev/connect-mmu/signing/local"
 	"github.com/skip-mev/connect-mmu/signing/simulate"
@@ -56,6 +58,14 @@ func createSigningRegistry() *signing.Registry {
 	return r
 }
 
+func createIngesterRegistry() *ingesters.Registry {
+	r, err := indexer.NewDefaultIngesterRegistry()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
 func getSupportedCommands() map[string]Command {
 	return map[string]Command{
 		"index":    Index,
@@ -156,7 +166,7 @@ func lambdaHandler(ctx context.Context, event json.RawMessage) (resp LambdaRespo
 	}
 
 	r := createSigningRegistry()
-	rootCmd := cmd.RootCmd(r)
+	rootCmd := cmd.RootCmd(r, createIngesterRegistry())
 	rootCmd.SetArgs(args)
 	if err := rootCmd.Execute(); err != nil {
 		logger.Error("command returned errors", zap.Bool("mmu_datadog", true), zap.Strings("command", args), zap.Error(err))
@@ -175,7 +185,7 @@ func main() {
 	} else {
 		// Running locally
 		r := createSigningRegistry()
-		if err := cmd.RootCmd(r).Execute(); err != nil {
+		if err := cmd.RootCmd(r, createIngesterRegistry()).Execute(); err != nil {
 			os.Exit(1)
 		}
 	}
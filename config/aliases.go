@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+)
+
+// AliasConfig maps exchange specific symbols and wrapped assets to the canonical assets they represent.
+type AliasConfig struct {
+	// Symbols are the symbol aliases applied to the target base and quote of ingested markets.
+	Symbols []SymbolAlias `json:"symbols" mapstructure:"symbols"`
+	// WrappedAssets are the CMC IDs of wrapped assets that are treated as their native asset when
+	// generating feeds.
+	WrappedAssets []WrappedAssetAlias `json:"wrapped_assets" mapstructure:"wrapped_assets"`
+}
+
+// SymbolAlias maps a symbol to its canonical symbol.
+type SymbolAlias struct {
+	// Venue is the name of the ingester the alias applies to. If empty, the alias applies to every ingester.
+	Venue     string `json:"venue,omitempty" mapstructure:"venue"`
+	Symbol    string `json:"symbol" mapstructure:"symbol"`
+	Canonical string `json:"canonical" mapstructure:"canonical"`
+}
+
+// WrappedAssetAlias maps the CMC ID of a wrapped asset to the CMC ID of its native asset.
+type WrappedAssetAlias struct {
+	CMCID          int64 `json:"cmc_id" mapstructure:"cmc_id"`
+	CanonicalCMCID int64 `json:"canonical_cmc_id" mapstructure:"canonical_cmc_id"`
+}
+
+// DefaultAliasConfig returns the aliases of the symbols known to differ on exchanges, and the known wrapped assets.
+func DefaultAliasConfig() AliasConfig {
+	return AliasConfig{
+		Symbols: []SymbolAlias{
+			// Kraken prefixes legacy crypto assets with X and fiat assets with Z.
+			{Venue: "kraken", Symbol: "ZEUR", Canonical: "EUR"},
+			{Venue: "kraken", Symbol: "ZUSD", Canonical: "USD"},
+			{Venue: "kraken", Symbol: "XXBT", Canonical: "BTC"},
+			{Venue: "kraken", Symbol: "XETH", Canonical: "ETH"},
+			{Venue: "kraken", Symbol: "XXRP", Canonical: "XRP"},
+			{Venue: "kraken", Symbol: "ZGBP", Canonical: "GBP"},
+			{Venue: "kraken", Symbol: "ZJPY", Canonical: "JPY"},
+			{Venue: "kraken", Symbol: "XLTC", Canonical: "LTC"},
+			{Venue: "kraken", Symbol: "XXDG", Canonical: "DOGE"},
+			{Venue: "kraken", Symbol: "ZCAD", Canonical: "CAD"},
+			{Venue: "kraken", Symbol: "XXMR", Canonical: "XMR"},
+			{Venue: "kraken", Symbol: "ZAUD", Canonical: "AUD"},
+			{Venue: "kraken", Symbol: "XREP", Canonical: "REP"},
+			{Venue: "kraken", Symbol: "XZEC", Canonical: "ZEC"},
+			{Venue: "kraken", Symbol: "XETC", Canonical: "ETC"},
+			{Venue: "kraken", Symbol: "XMLN", Canonical: "MLN"},
+			{Venue: "kraken", Symbol: "XXLM", Canonical: "XLM"},
+			{Venue: "bitfinex", Symbol: "UST", Canonical: "USDT"},
+			{Venue: "bitfinex", Symbol: "MNA", Canonical: "MANA"},
+		},
+		WrappedAssets: []WrappedAssetAlias{
+			// Wrapped SOL -> SOL
+			// - SOL:  https://coinmarketcap.com/currencies/solana/
+			// - Wrapped SOL: https://coinmarketcap.com/currencies/wrapped-solana/
+			{CMCID: 16116, CanonicalCMCID: 5426},
+		},
+	}
+}
+
+// Registry returns an AliasRegistry of the aliases.
+func (c AliasConfig) Registry() *symbols.AliasRegistry {
+	symbolAliases := make([]symbols.SymbolAlias, 0, len(c.Symbols))
+	for _, alias := range c.Symbols {
+		symbolAliases = append(symbolAliases, symbols.SymbolAlias{
+			Venue:     alias.Venue,
+			Symbol:    alias.Symbol,
+			Canonical: alias.Canonical,
+		})
+	}
+
+	wrappedAssets := make(map[int64]int64, len(c.WrappedAssets))
+	for _, alias := range c.WrappedAssets {
+		wrappedAssets[alias.CMCID] = alias.CanonicalCMCID
+	}
+
+	return symbols.NewAliasRegistry(symbolAliases, wrappedAssets)
+}
+
+// Validate checks that aliases are non-empty, unique, and resolve in a single step.
+func (c *AliasConfig) Validate() error {
+	symbols := make(map[string]string, len(c.Symbols))
+	key := func(venue, symbol string) string {
+		return strings.ToLower(venue) + "/" + strings.ToUpper(symbol)
+	}
+
+	for _, alias := range c.Symbols {
+		if strings.TrimSpace(alias.Symbol) == "" || strings.TrimSpace(alias.Canonical) == "" {
+			return fmt.Errorf("symbol alias %+v must have a symbol and a canonical symbol", alias)
+		}
+		if strings.EqualFold(alias.Symbol, alias.Canonical) {
+			return fmt.Errorf("symbol alias %q maps to itself", alias.Symbol)
+		}
+
+		k := key(alias.Venue, alias.Symbol)
+		if _, found := symbols[k]; found {
+			return fmt.Errorf("duplicate symbol alias %q for venue %q", alias.Symbol, alias.Venue)
+		}
+		symbols[k] = alias.Canonical
+	}
+
+	for _, alias := range c.Symbols {
+		for _, venue := range []string{alias.Venue, ""} {
+			if _, found := symbols[key(venue, alias.Canonical)]; found {
+				return fmt.Errorf("symbol alias %q resolves to %q, which is itself an alias", alias.Symbol, alias.Canonical)
+			}
+		}
+	}
+
+	wrapped := make(map[int64]int64, len(c.WrappedAssets))
+	for _, alias := range c.WrappedAssets {
+		if alias.CMCID <= 0 || alias.CanonicalCMCID <= 0 {
+			return fmt.Errorf("wrapped asset alias %+v must have positive CMC IDs", alias)
+		}
+		if alias.CMCID == alias.CanonicalCMCID {
+			return fmt.Errorf("wrapped asset alias %d maps to itself", alias.CMCID)
+		}
+		if _, found := wrapped[alias.CMCID]; found {
+			return fmt.Errorf("duplicate wrapped asset alias %d", alias.CMCID)
+		}
+		wrapped[alias.CMCID] = alias.CanonicalCMCID
+	}
+
+	for _, alias := range c.WrappedAssets {
+		if _, found := wrapped[alias.CanonicalCMCID]; found {
+			return fmt.Errorf("wrapped asset alias %d resolves to %d, which is itself an alias", alias.CMCID, alias.CanonicalCMCID)
+		}
+	}
+
+	return nil
+}
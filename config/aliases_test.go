@@ -0,0 +1,106 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestAliasConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.AliasConfig
+		wantErr bool
+	}{
+		{
+			name:    "default config is valid",
+			cfg:     config.DefaultAliasConfig(),
+			wantErr: false,
+		},
+		{
+			name: "same symbol on different venues is valid",
+			cfg: config.AliasConfig{
+				Symbols: []config.SymbolAlias{
+					{Venue: "kraken", Symbol: "XXBT", Canonical: "BTC"},
+					{Venue: "bitfinex", Symbol: "XXBT", Canonical: "BTC"},
+					{Symbol: "XXBT", Canonical: "BTC"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty canonical symbol is invalid",
+			cfg: config.AliasConfig{
+				Symbols: []config.SymbolAlias{{Symbol: "XXBT"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "symbol aliased to itself is invalid",
+			cfg: config.AliasConfig{
+				Symbols: []config.SymbolAlias{{Symbol: "btc", Canonical: "BTC"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate symbol alias is invalid",
+			cfg: config.AliasConfig{
+				Symbols: []config.SymbolAlias{
+					{Venue: "kraken", Symbol: "XXBT", Canonical: "BTC"},
+					{Venue: "Kraken", Symbol: "xxbt", Canonical: "XBT"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "chained symbol alias is invalid",
+			cfg: config.AliasConfig{
+				Symbols: []config.SymbolAlias{
+					{Venue: "kraken", Symbol: "XXBT", Canonical: "XBT"},
+					{Symbol: "XBT", Canonical: "BTC"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-positive wrapped asset id is invalid",
+			cfg: config.AliasConfig{
+				WrappedAssets: []config.WrappedAssetAlias{{CMCID: 16116}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate wrapped asset alias is invalid",
+			cfg: config.AliasConfig{
+				WrappedAssets: []config.WrappedAssetAlias{
+					{CMCID: 16116, CanonicalCMCID: 5426},
+					{CMCID: 16116, CanonicalCMCID: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "chained wrapped asset alias is invalid",
+			cfg: config.AliasConfig{
+				WrappedAssets: []config.WrappedAssetAlias{
+					{CMCID: 16116, CanonicalCMCID: 5426},
+					{CMCID: 5426, CanonicalCMCID: 1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
 	"github.com/dydxprotocol/slinky/x/marketmap/types"
@@ -150,6 +151,11 @@ type GenerateConfig struct {
 	// This is used to avoid "thrashy" Market Map updates, where MMU repeatedly adds then removes a market that is hovering right around the min vol/liq thresholds
 	// Range: 0 <= RelaxedMinVolumeAndLiquidityFactor <= 1 (i.e. relaxed min vol / liq thresholds should always be less than or equal to the original thresholds)
 	RelaxedMinVolumeAndLiquidityFactor float64 `json:"relaxed_min_volume_and_liquidity_factor" mapstructure:"relaxed_min_volume_and_liquidity_factor"`
+
+	// MaxMarketAge excludes provider markets that were last observed longer than MaxMarketAge before the most recent
+	// index run in the provider data, e.g. markets kept from a merged index run that have since been delisted.
+	// Markets without an observation time have an unknown age and are kept. If 0, markets are not excluded by age.
+	MaxMarketAge time.Duration `json:"max_market_age,omitempty" mapstructure:"max_market_age"`
 }
 
 var defaultProviders = map[string]ProviderConfig{
@@ -186,6 +192,14 @@ var defaultQuotes = map[string]QuoteConfig{
 		MinProviderVolume: 0,
 		NormalizeByPair:   "SOL/USD",
 	},
+	// KRW markets from Korean venues are converted to USD markets. Since no indexed venue quotes
+	// KRW/USD directly, its reference price is derived from a common asset (e.g. USDT/USD and
+	// USDT/KRW), and a KRW/USD market must be present in the market map (e.g. via market_map_override).
+	"KRW": {
+		MinProviderVolume:    110000000,
+		MinProviderLiquidity: 1000,
+		NormalizeByPair:      "KRW/USD",
+	},
 }
 
 func DefaultGenerateConfig() GenerateConfig {
@@ -278,6 +292,10 @@ func (cfg *GenerateConfig) Validate() error {
 		return fmt.Errorf("invalid RelaxedMinVolumeAndLiquidityFactor: must be between 0 and 1, got %f", cfg.RelaxedMinVolumeAndLiquidityFactor)
 	}
 
+	if cfg.MaxMarketAge < 0 {
+		return fmt.Errorf("max_market_age must be non-negative, got %s", cfg.MaxMarketAge)
+	}
+
 	return nil
 }
 
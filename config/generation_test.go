@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -139,6 +140,18 @@ func TestValidateGenerateConfig(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"max market age is negative",
+			config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount: 1,
+				MinDexProviderCount: 1,
+				MaxMarketAge:        -time.Hour,
+			},
+			true,
+		},
 		{
 			"min provider count > min provider count override",
 			config.GenerateConfig{
@@ -1,17 +1,62 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dydxprotocol/slinky/providers/apis/defi/uniswapv3"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/mapstructure"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	DefaultMaxConcurrentIngesters = 4
+	DefaultIngesterTimeout        = time.Minute * 10
+
+	// Default TTLs of cached CoinMarketCap responses.
+	DefaultCMCIDMapTTL   = time.Hour * 24
+	DefaultCMCInfoTTL    = time.Hour * 24
+	DefaultCMCMarketsTTL = time.Hour
+	DefaultCMCQuotesTTL  = time.Minute * 5
+
+	// DefaultOrderBookRateLimit is the default number of order books an ingester fetches per second.
+	DefaultOrderBookRateLimit = 5
+
+	// DefaultCoinGeckoRankPages is the default number of pages of the CoinGecko market cap ranking that are fetched.
+	DefaultCoinGeckoRankPages = 4
 )
 
 type MarketConfig struct {
 	Ingesters           []IngesterConfig    `json:"ingesters" mapstructure:"ingesters"`
 	CoinMarketCapConfig CoinMarketCapConfig `json:"coinmarketcap" mapstructure:"coinmarketcap"`
-	RaydiumNodes        []RaydiumNodeConfig `json:"raydium" mapstructure:"raydium"`
+	// CoinGeckoConfig configures CoinGecko as a secondary asset aggregator to CoinMarketCap.
+	CoinGeckoConfig CoinGeckoConfig `json:"coingecko" mapstructure:"coingecko"`
+	// RaydiumNodes are the Solana RPC nodes used by the Solana ingesters (raydium, orca and meteora).
+	RaydiumNodes []RaydiumNodeConfig `json:"raydium" mapstructure:"raydium"`
 
 	// GeckoNetworkDexPairs is a configuration for the Gecko Terminal ingester. This configures the ingester to
-	// ingest data from the specified pairs. Note: not all pairs are valid. Please see ingesters/gecko/utils.go for valid pairs.
+	// ingest data from the specified pairs. Pairs in KnownGeckoNetworkDexPairs only need a network and dex, all
+	// other pairs must also specify the Connect provider name and ticker venue of their markets.
 	GeckoNetworkDexPairs []GeckoNetworkDexPair `json:"gecko_network_dex_pairs" mapstructure:"gecko_network_dex_pairs"`
+
+	// UniswapV3Networks is a configuration for the Uniswap v3 ingester. The ingester reads the pools of each
+	// configured factory from an EVM JSON-RPC node.
+	UniswapV3Networks []UniswapV3NetworkConfig `json:"uniswapv3_networks" mapstructure:"uniswapv3_networks"`
+
+	// MaxConcurrentIngesters is the maximum number of ingesters that fetch markets at the same time.
+	// If set to 0, DefaultMaxConcurrentIngesters is used.
+	MaxConcurrentIngesters int `json:"max_concurrent_ingesters" mapstructure:"max_concurrent_ingesters"`
+
+	// IngesterTimeout is the maximum duration a single ingester may spend fetching its markets.
+	// If set to 0, ingesters are not bounded by a timeout.
+	IngesterTimeout time.Duration `json:"ingester_timeout" mapstructure:"ingester_timeout"`
 }
 
 var defaultIngesters = []IngesterConfig{
@@ -25,20 +70,345 @@ var defaultGeckoNetworkDexPairs = []GeckoNetworkDexPair{
 
 func DefaultMarketConfig() MarketConfig {
 	return MarketConfig{
-		Ingesters:            defaultIngesters,
-		CoinMarketCapConfig:  CoinMarketCapConfig{APIKey: ""},
-		RaydiumNodes:         []RaydiumNodeConfig{},
-		GeckoNetworkDexPairs: defaultGeckoNetworkDexPairs,
+		Ingesters:              defaultIngesters,
+		CoinMarketCapConfig:    CoinMarketCapConfig{APIKey: ""},
+		RaydiumNodes:           []RaydiumNodeConfig{},
+		GeckoNetworkDexPairs:   defaultGeckoNetworkDexPairs,
+		MaxConcurrentIngesters: DefaultMaxConcurrentIngesters,
+		IngesterTimeout:        DefaultIngesterTimeout,
+	}
+}
+
+// IngesterOptions returns the decoded options of the configured ingester with the given name. An ingester
+// that is not configured has no options.
+func (c *MarketConfig) IngesterOptions(name string) (IngesterOptions, error) {
+	for _, ingester := range c.Ingesters {
+		if ingester.Name == name {
+			return ingester.DecodeOptions()
+		}
 	}
+
+	return IngesterOptions{}, nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of the JSON encoding of the config, which identifies the config an
+// index run used. API keys are left out, so runs with different keys but the same config have the same hash.
+func (c *MarketConfig) Hash() (string, error) {
+	cfg := *c
+	cfg.CoinMarketCapConfig.APIKey = ""
+	cfg.CoinGeckoConfig.APIKey = ""
+
+	bz, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(bz)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// KnownGeckoNetworkDexPairs are the network/dex pairs whose Connect mapping does not need to be configured.
+var KnownGeckoNetworkDexPairs = []GeckoNetworkDexPair{
+	{
+		Network:         "eth",
+		Dex:             "uniswap_v3",
+		ProviderName:    "uniswapv3_api-ethereum",
+		TickerVenue:     "UNISWAP_V3",
+		CMCExchangeSlug: "uniswap-v3",
+	},
+	{
+		Network:         "base",
+		Dex:             "uniswap-v3-base",
+		ProviderName:    "uniswapv3_api-base",
+		TickerVenue:     "UNISWAP_V3_BASE",
+		CMCExchangeSlug: "uniswap-v3-base",
+	},
+}
+
+// GeckoNetworkChains maps GeckoTerminal networks to the chains of the Connect uniswapv3 providers. Connect only
+// has uniswapv3 providers on ethereum and base, so pairs on other networks, e.g. arbitrum, bsc or polygon_pos,
+// cannot be indexed until it supports their chain.
+var GeckoNetworkChains = map[string]string{
+	"eth":  "ethereum",
+	"base": "base",
 }
 
 type GeckoNetworkDexPair struct {
 	Network string `json:"network" mapstructure:"network"`
 	Dex     string `json:"dex" mapstructure:"dex"`
+
+	// ProviderName is the Connect provider name of markets on the dex. It must be a Connect uniswapv3 API provider.
+	ProviderName string `json:"provider_name,omitempty" mapstructure:"provider_name"`
+	// TickerVenue is the venue used in the off-chain tickers of markets on the dex.
+	TickerVenue string `json:"ticker_venue,omitempty" mapstructure:"ticker_venue"`
+	// CMCExchangeSlug is the slug of the dex on CoinMarketCap. If empty, the dex is used.
+	CMCExchangeSlug string `json:"cmc_exchange_slug,omitempty" mapstructure:"cmc_exchange_slug"`
+}
+
+// String returns the pair as network/dex.
+func (p GeckoNetworkDexPair) String() string {
+	return p.Network + "/" + p.Dex
+}
+
+// WithDefaults returns the pair with any unset fields filled in from the known pair with the same network and dex.
+func (p GeckoNetworkDexPair) WithDefaults() GeckoNetworkDexPair {
+	for _, known := range KnownGeckoNetworkDexPairs {
+		if known.Network != p.Network || known.Dex != p.Dex {
+			continue
+		}
+
+		if p.ProviderName == "" {
+			p.ProviderName = known.ProviderName
+		}
+		if p.TickerVenue == "" {
+			p.TickerVenue = known.TickerVenue
+		}
+		if p.CMCExchangeSlug == "" {
+			p.CMCExchangeSlug = known.CMCExchangeSlug
+		}
+	}
+
+	if p.CMCExchangeSlug == "" {
+		p.CMCExchangeSlug = p.Dex
+	}
+
+	return p
+}
+
+// Validate checks that the pair, after applying WithDefaults, maps to a supported Connect provider on the chain of
+// its network.
+func (p GeckoNetworkDexPair) Validate() error {
+	if p.Network == "" || p.Dex == "" {
+		return fmt.Errorf("network and dex cannot be empty")
+	}
+
+	p = p.WithDefaults()
+	if p.ProviderName == "" || p.TickerVenue == "" {
+		known := make([]string, 0, len(KnownGeckoNetworkDexPairs))
+		for _, pair := range KnownGeckoNetworkDexPairs {
+			known = append(known, pair.String())
+		}
+		return fmt.Errorf("unsupported pair %s: provider_name and ticker_venue must be set for pairs other than %s",
+			p, strings.Join(known, ", "))
+	}
+
+	if !uniswapv3.IsValidProviderName(p.ProviderName) {
+		providerNames := maps.Values(uniswapv3.ProviderNames)
+		slices.Sort(providerNames)
+		return fmt.Errorf("unsupported pair %s: provider %q is not a Connect uniswapv3 provider: must be one of %v",
+			p, p.ProviderName, providerNames)
+	}
+
+	chain, ok := GeckoNetworkChains[p.Network]
+	if !ok {
+		networks := maps.Keys(GeckoNetworkChains)
+		slices.Sort(networks)
+		return fmt.Errorf("unsupported pair %s: Connect has no provider on network %q: must be one of %v",
+			p, p.Network, networks)
+	}
+	if uniswapv3.ProviderNames[chain] != p.ProviderName {
+		return fmt.Errorf("unsupported pair %s: network %q is not on the chain of provider %q", p, p.Network, p.ProviderName)
+	}
+
+	return nil
+}
+
+// DefaultUniswapV3BlockRange is the default number of blocks queried per eth_getLogs request.
+const DefaultUniswapV3BlockRange = 10000
+
+// DefaultUniswapV3BlocksPerDay is the number of blocks each supported chain produces in a day.
+var DefaultUniswapV3BlocksPerDay = map[string]uint64{
+	"ethereum": 7200,  // 12 second blocks.
+	"base":     43200, // 2 second blocks.
+}
+
+// UniswapV3NetworkConfig configures the Uniswap v3 deployment of a single EVM network.
+type UniswapV3NetworkConfig struct {
+	// Chain is the Connect chain name of the network, e.g. "ethereum" or "base". It determines the Connect
+	// provider name of the network's markets.
+	Chain string `json:"chain" mapstructure:"chain"`
+	// Endpoint is the EVM JSON-RPC endpoint of the network.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+	// FactoryAddress is the address of the Uniswap v3 factory whose PoolCreated logs are indexed.
+	FactoryAddress string `json:"factory_address" mapstructure:"factory_address"`
+	// FromBlock is the block to start reading PoolCreated logs from, e.g. the factory's deployment block.
+	FromBlock uint64 `json:"from_block" mapstructure:"from_block"`
+	// BlockRange is the number of blocks queried per eth_getLogs request. If 0, DefaultUniswapV3BlockRange is used.
+	BlockRange uint64 `json:"block_range" mapstructure:"block_range"`
+	// BlocksPerDay is the number of latest blocks whose Swap logs make up the 24 hour volume of the network's
+	// markets. If 0, the DefaultUniswapV3BlocksPerDay of the chain is used.
+	BlocksPerDay uint64 `json:"blocks_per_day,omitempty" mapstructure:"blocks_per_day"`
+	// TickerVenue is the venue used in the off-chain tickers of the network's markets.
+	TickerVenue string `json:"ticker_venue" mapstructure:"ticker_venue"`
+	// CMCExchangeSlug is the slug of the network's Uniswap v3 deployment on CoinMarketCap.
+	CMCExchangeSlug string `json:"cmc_exchange_slug" mapstructure:"cmc_exchange_slug"`
+	// QuoteTokens are the addresses of tokens that are preferred as the quote of a pool, e.g. WETH and USDC.
+	// If neither or both tokens of a pool are quote tokens, token1 is used as the quote.
+	QuoteTokens []string `json:"quote_tokens" mapstructure:"quote_tokens"`
+}
+
+// ProviderName returns the Connect provider name of markets on the network.
+func (c UniswapV3NetworkConfig) ProviderName() string {
+	return uniswapv3.ProviderNames[c.Chain]
+}
+
+// DayBlocks returns the number of latest blocks whose Swap logs make up the 24 hour volume of the network's markets.
+func (c UniswapV3NetworkConfig) DayBlocks() uint64 {
+	if c.BlocksPerDay != 0 {
+		return c.BlocksPerDay
+	}
+	return DefaultUniswapV3BlocksPerDay[c.Chain]
+}
+
+func (c UniswapV3NetworkConfig) Validate() error {
+	if _, ok := uniswapv3.ProviderNames[c.Chain]; !ok {
+		chains := maps.Keys(uniswapv3.ProviderNames)
+		slices.Sort(chains)
+		return fmt.Errorf("unsupported chain %q: must be one of %v", c.Chain, chains)
+	}
+
+	if c.Endpoint == "" {
+		return fmt.Errorf("endpoint cannot be empty")
+	}
+
+	if !common.IsHexAddress(c.FactoryAddress) {
+		return fmt.Errorf("invalid factory address %q", c.FactoryAddress)
+	}
+
+	for _, token := range c.QuoteTokens {
+		if !common.IsHexAddress(token) {
+			return fmt.Errorf("invalid quote token address %q", token)
+		}
+	}
+
+	if c.TickerVenue == "" {
+		return fmt.Errorf("ticker venue cannot be empty")
+	}
+
+	return nil
 }
 
+const (
+	// IngesterPolicyRequired causes the index run to fail if the ingester fails.
+	IngesterPolicyRequired = "required"
+	// IngesterPolicyOptional causes the ingester to be skipped and reported if it fails.
+	IngesterPolicyOptional = "optional"
+)
+
 type IngesterConfig struct {
 	Name string `json:"name"`
+
+	// Policy determines how a failure of this ingester is handled during an index run.
+	// Must be one of "required" or "optional". If empty, the ingester is required.
+	Policy string `json:"policy,omitempty" mapstructure:"policy"`
+
+	// Options are the options of this ingester. They are decoded into IngesterOptions with DecodeOptions.
+	Options map[string]any `json:"options,omitempty" mapstructure:"options"`
+}
+
+// IngesterOptions are the options that can be set for a single ingester.
+type IngesterOptions struct {
+	// BaseURL replaces the scheme and host of every API request of the ingester, e.g. to point it at a
+	// proxy or a local stand-in server. Its path, if any, is prepended to the requested paths.
+	BaseURL string `json:"base_url"`
+	// RequestTimeout bounds each API request of the ingester. If 0, requests are only bounded by the
+	// ingester timeout.
+	RequestTimeout time.Duration `json:"request_timeout"`
+	// RateLimit is the maximum number of API requests per second of the ingester to each host. If 0, requests
+	// are not limited.
+	RateLimit float64 `json:"rate_limit"`
+	// Burst is the number of API requests the ingester may send to a host at once before RateLimit applies.
+	// If 0, requests are spread evenly.
+	Burst int `json:"burst"`
+	// MaxInFlight is the maximum number of API requests of the ingester in flight at once. If 0, it is not limited.
+	MaxInFlight int `json:"max_in_flight"`
+	// QuoteAllowlist are the quote symbols of the markets that are indexed. If empty, markets of any quote
+	// are indexed.
+	QuoteAllowlist []string `json:"quote_allowlist"`
+	// MinVolume is the minimum daily volume of an indexed market in USD. Markets that only report a quote
+	// volume are converted to USD with the prices of the ingester's USD markets, and are dropped if their
+	// quote has no USD price.
+	MinVolume float64 `json:"min_volume"`
+	// OrderBookDepth fetches the order books of the indexed markets to set their ±2% depth, for ingesters
+	// that support it. Requires MinVolume, so that order books are only fetched for markets with volume.
+	OrderBookDepth bool `json:"order_book_depth"`
+	// OrderBookRateLimit is the maximum number of order books fetched per second. If 0,
+	// DefaultOrderBookRateLimit is used.
+	OrderBookRateLimit float64 `json:"order_book_rate_limit"`
+}
+
+// DecodeOptions decodes the ingester's Options. Durations may be given as strings, e.g. "30s", or in
+// nanoseconds. Unknown options are an error.
+func (pc *IngesterConfig) DecodeOptions() (IngesterOptions, error) {
+	var opts IngesterOptions
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:      &opts,
+		TagName:     "json",
+		ErrorUnused: true,
+		DecodeHook:  mapstructure.StringToTimeDurationHookFunc(),
+	})
+	if err != nil {
+		return IngesterOptions{}, fmt.Errorf("error creating options decoder: %w", err)
+	}
+
+	if err := decoder.Decode(pc.Options); err != nil {
+		return IngesterOptions{}, fmt.Errorf("error decoding options: %w", err)
+	}
+
+	return opts, nil
+}
+
+func (o *IngesterOptions) Validate() error {
+	if o.BaseURL != "" {
+		u, err := url.Parse(o.BaseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base_url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("base_url %q must have a scheme and host", o.BaseURL)
+		}
+	}
+
+	if o.RequestTimeout < 0 {
+		return fmt.Errorf("request_timeout must be non-negative")
+	}
+
+	if o.RateLimit < 0 {
+		return fmt.Errorf("rate_limit must be non-negative")
+	}
+
+	if o.Burst < 0 {
+		return fmt.Errorf("burst must be non-negative")
+	}
+
+	if o.MaxInFlight < 0 {
+		return fmt.Errorf("max_in_flight must be non-negative")
+	}
+
+	for _, quote := range o.QuoteAllowlist {
+		if strings.TrimSpace(quote) == "" {
+			return fmt.Errorf("quote_allowlist cannot contain empty symbols")
+		}
+	}
+
+	if o.MinVolume < 0 {
+		return fmt.Errorf("min_volume must be non-negative")
+	}
+
+	if o.OrderBookDepth && o.MinVolume == 0 {
+		return fmt.Errorf("min_volume must be set to fetch order book depth")
+	}
+
+	if o.OrderBookRateLimit < 0 {
+		return fmt.Errorf("order_book_rate_limit must be non-negative")
+	}
+
+	return nil
+}
+
+// IsOptional returns true if a failure of this ingester should not fail the index run.
+func (pc *IngesterConfig) IsOptional() bool {
+	return pc.Policy == IngesterPolicyOptional
 }
 
 func (pc *IngesterConfig) Validate() error {
@@ -46,14 +416,127 @@ func (pc *IngesterConfig) Validate() error {
 		return fmt.Errorf("name cannot be invalid")
 	}
 
+	switch pc.Policy {
+	case "", IngesterPolicyRequired, IngesterPolicyOptional:
+	default:
+		return fmt.Errorf("policy must be one of %q or %q, got %q", IngesterPolicyRequired, IngesterPolicyOptional, pc.Policy)
+	}
+
+	opts, err := pc.DecodeOptions()
+	if err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
 	return nil
 }
 
 type CoinMarketCapConfig struct {
 	APIKey string `json:"api_key" mapstructure:"api_key"`
+
+	// Cache configures an on-disk cache of CoinMarketCap responses.
+	Cache CoinMarketCapCacheConfig `json:"cache" mapstructure:"cache"`
+
+	// RateLimit is the maximum number of requests per second to CoinMarketCap. If 0, requests are not limited.
+	RateLimit float64 `json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+	// Burst is the number of requests that may be sent at once before RateLimit applies.
+	Burst int `json:"burst,omitempty" mapstructure:"burst"`
+	// MaxInFlight is the maximum number of requests to CoinMarketCap in flight at once. If 0, it is not limited.
+	MaxInFlight int `json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 }
 
 func (cc *CoinMarketCapConfig) Validate() error {
+	if cc.RateLimit < 0 {
+		return fmt.Errorf("rate_limit must be non-negative")
+	}
+
+	if cc.Burst < 0 {
+		return fmt.Errorf("burst must be non-negative")
+	}
+
+	if cc.MaxInFlight < 0 {
+		return fmt.Errorf("max_in_flight must be non-negative")
+	}
+
+	if err := cc.Cache.Validate(); err != nil {
+		return fmt.Errorf("cache config invalid: %w", err)
+	}
+
+	return nil
+}
+
+// CoinMarketCapCacheConfig is the configuration of the on-disk cache of CoinMarketCap responses. Each response
+// is reused until its TTL expires. A TTL of 0 uses the default TTL of the endpoint.
+type CoinMarketCapCacheConfig struct {
+	// Dir is the directory responses are cached in. Caching is disabled if empty.
+	Dir string `json:"dir" mapstructure:"dir"`
+	// Offline serves every response from the cache regardless of its age, and fails on cache misses
+	// instead of requesting CoinMarketCap.
+	Offline bool `json:"offline" mapstructure:"offline"`
+
+	// IDMapTTL is the TTL of the crypto, fiat, and exchange ID maps.
+	IDMapTTL time.Duration `json:"id_map_ttl" mapstructure:"id_map_ttl"`
+	// InfoTTL is the TTL of crypto info.
+	InfoTTL time.Duration `json:"info_ttl" mapstructure:"info_ttl"`
+	// MarketsTTL is the TTL of exchange assets, exchange markets, and DEX markets.
+	MarketsTTL time.Duration `json:"markets_ttl" mapstructure:"markets_ttl"`
+	// QuotesTTL is the TTL of quotes.
+	QuotesTTL time.Duration `json:"quotes_ttl" mapstructure:"quotes_ttl"`
+}
+
+// Enabled returns true if responses are cached.
+func (cc *CoinMarketCapCacheConfig) Enabled() bool {
+	return cc.Dir != ""
+}
+
+func (cc *CoinMarketCapCacheConfig) Validate() error {
+	if cc.Offline && cc.Dir == "" {
+		return fmt.Errorf("dir must be set to use the cache offline")
+	}
+
+	ttls := []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{"id_map_ttl", cc.IDMapTTL},
+		{"info_ttl", cc.InfoTTL},
+		{"markets_ttl", cc.MarketsTTL},
+		{"quotes_ttl", cc.QuotesTTL},
+	}
+	for _, ttl := range ttls {
+		if ttl.ttl < 0 {
+			return fmt.Errorf("%s must be non-negative", ttl.name)
+		}
+	}
+
+	return nil
+}
+
+// CoinGeckoConfig is the configuration of CoinGecko. When enabled, CoinGecko IDs are cross-checked against
+// the CoinMarketCap assets, and markets whose assets cannot be found on CoinMarketCap are associated with
+// CoinGecko assets instead.
+type CoinGeckoConfig struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// APIKey is a CoinGecko demo API key, or a pro API key if Pro is set.
+	APIKey string `json:"api_key" mapstructure:"api_key"`
+	Pro    bool   `json:"pro" mapstructure:"pro"`
+	// RankPages is the number of 250 coin pages of the market cap ranking that are fetched. Coins outside
+	// of these pages are unranked. If set to 0, DefaultCoinGeckoRankPages is used.
+	RankPages int `json:"rank_pages" mapstructure:"rank_pages"`
+}
+
+func (cc *CoinGeckoConfig) Validate() error {
+	if cc.Pro && cc.APIKey == "" {
+		return fmt.Errorf("api_key must be set to use the pro api")
+	}
+
+	if cc.RankPages < 0 {
+		return fmt.Errorf("rank_pages must be non-negative")
+	}
+
 	return nil
 }
 
@@ -75,6 +558,18 @@ func (c *MarketConfig) Validate() error {
 		return err
 	}
 
+	if err := c.CoinGeckoConfig.Validate(); err != nil {
+		return fmt.Errorf("coingecko config invalid: %w", err)
+	}
+
+	if c.MaxConcurrentIngesters < 0 {
+		return fmt.Errorf("max_concurrent_ingesters must be non-negative")
+	}
+
+	if c.IngesterTimeout < 0 {
+		return fmt.Errorf("ingester_timeout must be non-negative")
+	}
+
 	seen := make(map[string]struct{})
 
 	for _, ingester := range c.Ingesters {
@@ -90,12 +585,25 @@ func (c *MarketConfig) Validate() error {
 
 		// extra validation for specific ingesters
 		switch ingester.Name {
-		case "raydium":
+		case "gecko":
+			if err := c.validateGeckoNetworkDexPairs(); err != nil {
+				return fmt.Errorf("gecko config invalid: %w", err)
+			}
+		case "raydium", "orca", "meteora":
 			for _, rc := range c.RaydiumNodes {
 				if err := rc.Validate(); err != nil {
 					return fmt.Errorf("raydium config invalid: %w", err)
 				}
 			}
+		case "uniswapv3":
+			if len(c.UniswapV3Networks) == 0 {
+				return fmt.Errorf("uniswapv3 config invalid: no uniswapv3_networks specified")
+			}
+			for _, network := range c.UniswapV3Networks {
+				if err := network.Validate(); err != nil {
+					return fmt.Errorf("uniswapv3 config invalid: %w", err)
+				}
+			}
 		default:
 			// do nothing
 		}
@@ -103,3 +611,23 @@ func (c *MarketConfig) Validate() error {
 
 	return nil
 }
+
+func (c *MarketConfig) validateGeckoNetworkDexPairs() error {
+	if len(c.GeckoNetworkDexPairs) == 0 {
+		return fmt.Errorf("no gecko_network_dex_pairs specified")
+	}
+
+	seen := make(map[string]struct{}, len(c.GeckoNetworkDexPairs))
+	for _, pair := range c.GeckoNetworkDexPairs {
+		if err := pair.Validate(); err != nil {
+			return err
+		}
+
+		if _, found := seen[pair.String()]; found {
+			return fmt.Errorf("duplicate pair %s found", pair)
+		}
+		seen[pair.String()] = struct{}{}
+	}
+
+	return nil
+}
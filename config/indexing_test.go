@@ -0,0 +1,424 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestIngesterConfig_DecodeOptions(t *testing.T) {
+	ingester := config.IngesterConfig{
+		Name: "okx",
+		Options: map[string]any{
+			"base_url":        "http://localhost:8080",
+			"request_timeout": "30s",
+			"quote_allowlist": []any{"USDT"},
+		},
+	}
+
+	opts, err := ingester.DecodeOptions()
+	require.NoError(t, err)
+	require.Equal(t, config.IngesterOptions{
+		BaseURL:        "http://localhost:8080",
+		RequestTimeout: 30 * time.Second,
+		QuoteAllowlist: []string{"USDT"},
+	}, opts)
+
+	// durations may also be given in nanoseconds, as they are when a config is written with WriteConfig.
+	ingester.Options = map[string]any{"request_timeout": float64(time.Second)}
+	opts, err = ingester.DecodeOptions()
+	require.NoError(t, err)
+	require.Equal(t, time.Second, opts.RequestTimeout)
+}
+
+func TestMarketConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.MarketConfig
+		wantErr bool
+	}{
+		{
+			name:    "default config is valid",
+			cfg:     config.DefaultMarketConfig(),
+			wantErr: false,
+		},
+		{
+			name: "optional and required ingesters are valid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "coinbase", Policy: config.IngesterPolicyRequired},
+					{Name: "okx", Policy: config.IngesterPolicyOptional},
+					{Name: "kraken"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ingester options are valid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{
+						"base_url":        "http://localhost:8080",
+						"request_timeout": "30s",
+						"rate_limit":      10,
+						"burst":           5,
+						"max_in_flight":   2,
+						"quote_allowlist": []any{"USDT", "USDC"},
+						"min_volume":      1000.5,
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown ingester option is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"base_uri": "http://localhost:8080"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ingester base url without a host is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"base_url": "localhost"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative ingester rate limit is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"rate_limit": -1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "order book depth with a minimum volume is valid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"order_book_depth": true, "order_book_rate_limit": 2, "min_volume": 1000}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "order book depth without a minimum volume is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"order_book_depth": true}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative order book rate limit is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"order_book_rate_limit": -1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative ingester burst is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"rate_limit": 1, "burst": -1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative ingester max in flight is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Options: map[string]any{"max_in_flight": -1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown ingester policy is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx", Policy: "sometimes"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate ingesters are invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{
+					{Name: "okx"},
+					{Name: "okx"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max concurrent ingesters is invalid",
+			cfg: config.MarketConfig{
+				MaxConcurrentIngesters: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "coingecko with a demo key is valid",
+			cfg: config.MarketConfig{
+				CoinGeckoConfig: config.CoinGeckoConfig{Enabled: true, APIKey: "key", RankPages: 8},
+			},
+			wantErr: false,
+		},
+		{
+			name: "coingecko pro without a key is invalid",
+			cfg: config.MarketConfig{
+				CoinGeckoConfig: config.CoinGeckoConfig{Enabled: true, Pro: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative coingecko rank pages is invalid",
+			cfg: config.MarketConfig{
+				CoinGeckoConfig: config.CoinGeckoConfig{Enabled: true, RankPages: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cmc rate limit is valid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{RateLimit: 0.5, Burst: 10, MaxInFlight: 4},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative cmc rate limit is invalid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{RateLimit: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cmc max in flight is invalid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{MaxInFlight: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cmc cache with ttls is valid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{
+					Cache: config.CoinMarketCapCacheConfig{Dir: "cache", Offline: true, QuotesTTL: time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "offline cmc cache without a dir is invalid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{
+					Cache: config.CoinMarketCapCacheConfig{Offline: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cmc cache ttl is invalid",
+			cfg: config.MarketConfig{
+				CoinMarketCapConfig: config.CoinMarketCapConfig{
+					Cache: config.CoinMarketCapCacheConfig{Dir: "cache", InfoTTL: -1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative ingester timeout is invalid",
+			cfg: config.MarketConfig{
+				IngesterTimeout: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko without pairs is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with a configured pair is valid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "base", Dex: "uniswap-v3-base"},
+					{Network: "base", Dex: "sushiswap-v3-base", ProviderName: "uniswapv3_api-base", TickerVenue: "SUSHISWAP_V3_BASE"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "gecko with an unknown unconfigured pair is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "bsc", Dex: "pancakeswap_v3"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with an unsupported provider is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "bsc", Dex: "pancakeswap_v3", ProviderName: "pancakeswap_api-bsc", TickerVenue: "PANCAKESWAP_V3"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with a network on another chain than its provider is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "solana", Dex: "raydium", ProviderName: "uniswapv3_api-ethereum", TickerVenue: "RAYDIUM"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with a network without a Connect provider is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "arbitrum", Dex: "uniswap_v3_arbitrum", ProviderName: "uniswapv3_api-ethereum", TickerVenue: "UNISWAP_V3_ARBITRUM"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with a network on another evm chain than its provider is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "base", Dex: "sushiswap-v3-base", ProviderName: "uniswapv3_api-ethereum", TickerVenue: "SUSHISWAP_V3_BASE"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gecko with duplicate pairs is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "gecko"}},
+				GeckoNetworkDexPairs: []config.GeckoNetworkDexPair{
+					{Network: "eth", Dex: "uniswap_v3"},
+					{Network: "eth", Dex: "uniswap_v3"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "uniswapv3 with a valid network is valid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "uniswapv3"}},
+				UniswapV3Networks: []config.UniswapV3NetworkConfig{
+					{
+						Chain:          "ethereum",
+						Endpoint:       "http://localhost:8545",
+						FactoryAddress: "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+						TickerVenue:    "UNISWAP_V3",
+						QuoteTokens:    []string{"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "uniswapv3 without networks is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "uniswapv3"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "uniswapv3 with an unsupported chain is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "uniswapv3"}},
+				UniswapV3Networks: []config.UniswapV3NetworkConfig{
+					{
+						Chain:          "arbitrum",
+						Endpoint:       "http://localhost:8545",
+						FactoryAddress: "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+						TickerVenue:    "UNISWAP_V3_ARBITRUM",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "uniswapv3 with an invalid factory address is invalid",
+			cfg: config.MarketConfig{
+				Ingesters: []config.IngesterConfig{{Name: "uniswapv3"}},
+				UniswapV3Networks: []config.UniswapV3NetworkConfig{
+					{
+						Chain:          "ethereum",
+						Endpoint:       "http://localhost:8545",
+						FactoryAddress: "uniswap",
+						TickerVenue:    "UNISWAP_V3",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "orca with an invalid solana node is invalid",
+			cfg: config.MarketConfig{
+				Ingesters:    []config.IngesterConfig{{Name: "orca"}},
+				RaydiumNodes: []config.RaydiumNodeConfig{{Endpoint: ""}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMarketConfig_Hash(t *testing.T) {
+	cfg := config.DefaultMarketConfig()
+	hash, err := cfg.Hash()
+	require.NoError(t, err)
+	require.Len(t, hash, 64)
+
+	// API keys don't change the hash.
+	withKeys := config.DefaultMarketConfig()
+	withKeys.CoinMarketCapConfig.APIKey = "cmc-key"
+	withKeys.CoinGeckoConfig.APIKey = "coingecko-key"
+	withKeysHash, err := withKeys.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, withKeysHash)
+	require.Equal(t, "cmc-key", withKeys.CoinMarketCapConfig.APIKey)
+
+	changed := config.DefaultMarketConfig()
+	changed.MaxConcurrentIngesters++
+	changedHash, err := changed.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changedHash)
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"encoding/json"
 	"os"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 )
 
 type Config struct {
@@ -12,6 +14,7 @@ type Config struct {
 	Upsert   *UpsertConfig   `json:"upsert,omitempty"`
 	Dispatch *DispatchConfig `json:"dispatch,omitempty"`
 	Chain    *ChainConfig    `json:"chain,omitempty"`
+	Aliases  *AliasConfig    `json:"aliases,omitempty"`
 }
 
 func (c *Config) ValidateAllConfigs() error {
@@ -51,9 +54,24 @@ func (c *Config) ValidateAllConfigs() error {
 		}
 	}
 
+	if c.Aliases != nil {
+		if err := c.Aliases.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// AliasRegistry returns an AliasRegistry of the configured aliases, or of the default aliases if none are configured.
+func (c *Config) AliasRegistry() *symbols.AliasRegistry {
+	if c.Aliases == nil {
+		return DefaultAliasConfig().Registry()
+	}
+
+	return c.Aliases.Registry()
+}
+
 func DefaultConfig() Config {
 	return Config{
 		Index:    &[]MarketConfig{DefaultMarketConfig()}[0],
@@ -62,6 +80,7 @@ func DefaultConfig() Config {
 		Upsert:   &[]UpsertConfig{DefaultUpsertConfig()}[0],
 		Dispatch: &[]DispatchConfig{DefaultDispatchConfig()}[0],
 		Chain:    &[]ChainConfig{DefaultChainConfig()}[0],
+		Aliases:  &[]AliasConfig{DefaultAliasConfig()}[0],
 	}
 }
 
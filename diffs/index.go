@@ -0,0 +1,462 @@
+package diffs
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// DefaultSwingThreshold is the default relative change of a market's volume or liquidity that is reported.
+const DefaultSwingThreshold = 0.5
+
+// IndexDiffOptions configures which changes DiffIndexes reports.
+type IndexDiffOptions struct {
+	// VolumeThreshold is the minimum relative change of a market's volume that is reported, e.g. 0.5 for 50%.
+	VolumeThreshold float64
+	// LiquidityThreshold is the minimum relative change of a market's ±2% depth that is reported.
+	LiquidityThreshold float64
+}
+
+// IndexDiff is the difference between two indexed provider documents.
+type IndexDiff struct {
+	Providers       []ProviderDiff `json:"providers"`
+	VolumeSwings    []MarketSwing  `json:"volume_swings"`
+	LiquiditySwings []MarketSwing  `json:"liquidity_swings"`
+	AssetRemaps     []AssetRemap   `json:"asset_remaps"`
+	AssetChanges    []AssetChange  `json:"asset_changes"`
+	FailedIngesters []string       `json:"failed_ingesters,omitempty"`
+}
+
+// ProviderDiff is the change in the markets of a single provider.
+type ProviderDiff struct {
+	ProviderName string `json:"provider_name"`
+	OldMarkets   int    `json:"old_markets"`
+	NewMarkets   int    `json:"new_markets"`
+	// Added and Removed are the off-chain tickers of the markets that were added and removed.
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	// IngesterFailed is true if the ingester of the provider failed in the new index, in which case its
+	// markets were removed because of the failure rather than delisted.
+	IngesterFailed bool `json:"ingester_failed,omitempty"`
+}
+
+// MarketSwing is a change in the volume or liquidity of a market.
+type MarketSwing struct {
+	ProviderName   string `json:"provider_name"`
+	OffChainTicker string `json:"off_chain_ticker"`
+	// Old and New are nil if the value is unknown, i.e. a market without a USD volume.
+	Old *float64 `json:"old"`
+	New *float64 `json:"new"`
+	// Change is the relative change from Old to New, e.g. -0.9 for a 90% drop, or nil if either is unknown.
+	Change *float64 `json:"change"`
+}
+
+// AssetRemap is a market whose base or quote asset is identified by a different CMC ID in the new index.
+type AssetRemap struct {
+	ProviderName   string `json:"provider_name"`
+	OffChainTicker string `json:"off_chain_ticker"`
+	// Side is either "base" or "quote".
+	Side     string `json:"side"`
+	Symbol   string `json:"symbol"`
+	OldCMCID int64  `json:"old_cmc_id"`
+	NewCMCID int64  `json:"new_cmc_id"`
+}
+
+// AssetChange is a change in the rank or CMC tags of an asset.
+type AssetChange struct {
+	// Asset identifies the asset by its CMC ID, its CoinGecko ID, or its symbol.
+	Asset       string   `json:"asset"`
+	Symbol      string   `json:"symbol"`
+	OldRank     int64    `json:"old_rank"`
+	NewRank     int64    `json:"new_rank"`
+	AddedTags   []string `json:"added_tags,omitempty"`
+	RemovedTags []string `json:"removed_tags,omitempty"`
+}
+
+// IsEmpty returns true if the diff reports no changes.
+func (d IndexDiff) IsEmpty() bool {
+	return len(d.Providers) == 0 && len(d.VolumeSwings) == 0 && len(d.LiquiditySwings) == 0 &&
+		len(d.AssetRemaps) == 0 && len(d.AssetChanges) == 0
+}
+
+// marketKey identifies a provider market across documents.
+type marketKey struct {
+	providerName   string
+	offChainTicker string
+}
+
+// DiffIndexes returns the changes from the old to the new provider document. Markets are matched by provider
+// name and off-chain ticker, and assets by CMC ID, CoinGecko ID, or symbol, in that order. Swings are only
+// reported for markets whose old value is positive.
+func DiffIndexes(oldDoc, newDoc provider.Document, opts IndexDiffOptions) IndexDiff {
+	diff := IndexDiff{
+		Providers:       make([]ProviderDiff, 0),
+		VolumeSwings:    make([]MarketSwing, 0),
+		LiquiditySwings: make([]MarketSwing, 0),
+		AssetRemaps:     make([]AssetRemap, 0),
+		AssetChanges:    make([]AssetChange, 0),
+	}
+
+	failedProviders := make(map[string]provider.IngesterFailure)
+	if newDoc.IndexReport != nil {
+		failedProviders = newDoc.IndexReport.FailedProviders()
+		for _, failure := range newDoc.IndexReport.FailedIngesters {
+			diff.FailedIngesters = append(diff.FailedIngesters, failure.Ingester)
+		}
+	}
+
+	oldMarkets, oldKeys := indexMarkets(oldDoc.ProviderMarkets)
+	newMarkets, newKeys := indexMarkets(newDoc.ProviderMarkets)
+	oldAssets := indexAssetsByID(oldDoc.AssetInfos)
+	newAssets := indexAssetsByID(newDoc.AssetInfos)
+
+	providers := make(map[string]*ProviderDiff)
+	providerDiff := func(name string) *ProviderDiff {
+		pd, found := providers[name]
+		if !found {
+			pd = &ProviderDiff{ProviderName: name, Added: make([]string, 0), Removed: make([]string, 0)}
+			providers[name] = pd
+		}
+		return pd
+	}
+
+	for _, key := range oldKeys {
+		providerDiff(key.providerName).OldMarkets++
+
+		oldMarket := oldMarkets[key]
+		newMarket, found := newMarkets[key]
+		if !found {
+			pd := providerDiff(key.providerName)
+			pd.Removed = append(pd.Removed, key.offChainTicker)
+			continue
+		}
+
+		if swing, ok := marketSwing(key, marketVolume(oldMarket), marketVolume(newMarket), opts.VolumeThreshold); ok {
+			diff.VolumeSwings = append(diff.VolumeSwings, swing)
+		}
+		oldLiquidity, newLiquidity := marketLiquidity(oldMarket), marketLiquidity(newMarket)
+		if swing, ok := marketSwing(key, &oldLiquidity, &newLiquidity, opts.LiquidityThreshold); ok {
+			diff.LiquiditySwings = append(diff.LiquiditySwings, swing)
+		}
+
+		oldBase, newBase := oldAssets[oldMarket.BaseAssetInfoID], newAssets[newMarket.BaseAssetInfoID]
+		if remap, ok := assetRemap(key, "base", oldBase, newBase); ok {
+			diff.AssetRemaps = append(diff.AssetRemaps, remap)
+		}
+		oldQuote, newQuote := oldAssets[oldMarket.QuoteAssetInfoID], newAssets[newMarket.QuoteAssetInfoID]
+		if remap, ok := assetRemap(key, "quote", oldQuote, newQuote); ok {
+			diff.AssetRemaps = append(diff.AssetRemaps, remap)
+		}
+	}
+
+	for _, key := range newKeys {
+		pd := providerDiff(key.providerName)
+		pd.NewMarkets++
+		if _, found := oldMarkets[key]; !found {
+			pd.Added = append(pd.Added, key.offChainTicker)
+		}
+	}
+
+	for _, name := range sortedKeys(providers) {
+		pd := providers[name]
+		_, pd.IngesterFailed = failedProviders[name]
+		if len(pd.Added) == 0 && len(pd.Removed) == 0 && !pd.IngesterFailed {
+			continue
+		}
+		slices.Sort(pd.Added)
+		slices.Sort(pd.Removed)
+		diff.Providers = append(diff.Providers, *pd)
+	}
+
+	sortSwings(diff.VolumeSwings)
+	sortSwings(diff.LiquiditySwings)
+	diff.AssetChanges = diffAssets(oldDoc.AssetInfos, newDoc.AssetInfos)
+
+	return diff
+}
+
+// indexMarkets returns the markets of a document by key, along with the keys in document order. If a key
+// appears more than once, its first market is used.
+func indexMarkets(markets []provider.ProviderMarket) (map[marketKey]provider.ProviderMarket, []marketKey) {
+	indexed := make(map[marketKey]provider.ProviderMarket, len(markets))
+	keys := make([]marketKey, 0, len(markets))
+	for _, market := range markets {
+		key := marketKey{providerName: market.ProviderName, offChainTicker: market.OffChainTicker}
+		if _, found := indexed[key]; found {
+			continue
+		}
+		indexed[key] = market
+		keys = append(keys, key)
+	}
+	return indexed, keys
+}
+
+func indexAssetsByID(assets []provider.AssetInfo) map[int32]provider.AssetInfo {
+	indexed := make(map[int32]provider.AssetInfo, len(assets))
+	for _, asset := range assets {
+		indexed[asset.ID] = asset
+	}
+	return indexed
+}
+
+// marketVolume returns the USD volume of a market, or nil if it has none. Quote volumes are not used instead,
+// since they cannot be compared with USD volumes.
+func marketVolume(market provider.ProviderMarket) *float64 {
+	if market.UsdVolume <= 0 {
+		return nil
+	}
+	return &market.UsdVolume
+}
+
+// marketLiquidity returns the total ±2% depth of a market.
+func marketLiquidity(market provider.ProviderMarket) float64 {
+	return market.NegativeDepthTwo + market.PositiveDepthTwo
+}
+
+// marketSwing returns the swing from oldValue to newValue, if it is at least threshold. A value that became
+// known or unknown is always a swing, while a value that is unknown in both is not.
+func marketSwing(key marketKey, oldValue, newValue *float64, threshold float64) (MarketSwing, bool) {
+	swing := MarketSwing{
+		ProviderName:   key.providerName,
+		OffChainTicker: key.offChainTicker,
+		Old:            oldValue,
+		New:            newValue,
+	}
+
+	switch {
+	case oldValue == nil && newValue == nil:
+		return MarketSwing{}, false
+	case oldValue == nil || newValue == nil:
+		return swing, true
+	case *oldValue <= 0:
+		return MarketSwing{}, false
+	}
+
+	change := (*newValue - *oldValue) / *oldValue
+	if math.Abs(change) < threshold || change == 0 {
+		return MarketSwing{}, false
+	}
+
+	swing.Change = &change
+	return swing, true
+}
+
+// sortSwings orders swings from the largest drop to the largest rise, followed by the swings from or to an
+// unknown value.
+func sortSwings(swings []MarketSwing) {
+	slices.SortFunc(swings, func(a, b MarketSwing) int {
+		if (a.Change == nil) != (b.Change == nil) {
+			if a.Change == nil {
+				return 1
+			}
+			return -1
+		}
+		if a.Change != nil && *a.Change != *b.Change {
+			if *a.Change < *b.Change {
+				return -1
+			}
+			return 1
+		}
+		if a.ProviderName != b.ProviderName {
+			return strings.Compare(a.ProviderName, b.ProviderName)
+		}
+		return strings.Compare(a.OffChainTicker, b.OffChainTicker)
+	})
+}
+
+func assetRemap(key marketKey, side string, oldAsset, newAsset provider.AssetInfo) (AssetRemap, bool) {
+	if oldAsset.CMCID == newAsset.CMCID {
+		return AssetRemap{}, false
+	}
+
+	return AssetRemap{
+		ProviderName:   key.providerName,
+		OffChainTicker: key.offChainTicker,
+		Side:           side,
+		Symbol:         newAsset.Symbol,
+		OldCMCID:       oldAsset.CMCID,
+		NewCMCID:       newAsset.CMCID,
+	}, true
+}
+
+// assetKey identifies an asset across documents.
+func assetKey(asset provider.AssetInfo) string {
+	switch {
+	case asset.CMCID != 0:
+		return "cmc:" + strconv.FormatInt(asset.CMCID, 10)
+	case asset.CoinGeckoID != "":
+		return "coingecko:" + asset.CoinGeckoID
+	default:
+		return "symbol:" + asset.Symbol
+	}
+}
+
+// diffAssets returns the changes in the rank and tags of the assets in both documents.
+func diffAssets(oldAssets, newAssets []provider.AssetInfo) []AssetChange {
+	oldByKey := make(map[string]provider.AssetInfo, len(oldAssets))
+	for _, asset := range oldAssets {
+		if _, found := oldByKey[assetKey(asset)]; !found {
+			oldByKey[assetKey(asset)] = asset
+		}
+	}
+
+	changes := make([]AssetChange, 0)
+	seen := make(map[string]struct{}, len(newAssets))
+	for _, newAsset := range newAssets {
+		key := assetKey(newAsset)
+		if _, found := seen[key]; found {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		oldAsset, found := oldByKey[key]
+		if !found {
+			continue
+		}
+
+		added, removed := diffTags(oldAsset.CMCTags, newAsset.CMCTags)
+		if oldAsset.Rank == newAsset.Rank && len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		changes = append(changes, AssetChange{
+			Asset:       key,
+			Symbol:      newAsset.Symbol,
+			OldRank:     oldAsset.Rank,
+			NewRank:     newAsset.Rank,
+			AddedTags:   added,
+			RemovedTags: removed,
+		})
+	}
+
+	slices.SortFunc(changes, func(a, b AssetChange) int {
+		return strings.Compare(a.Asset, b.Asset)
+	})
+
+	return changes
+}
+
+func diffTags(oldTags, newTags []string) (added, removed []string) {
+	for _, tag := range newTags {
+		if !slices.Contains(oldTags, tag) {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range oldTags {
+		if !slices.Contains(newTags, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Markdown renders the diff as a Markdown report.
+func (d IndexDiff) Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# Index Diff\n\n")
+	if d.IsEmpty() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	if len(d.FailedIngesters) > 0 {
+		fmt.Fprintf(&b, "Failed ingesters in the new index: %s\n\n", strings.Join(d.FailedIngesters, ", "))
+	}
+
+	if len(d.Providers) > 0 {
+		b.WriteString("## Provider Markets\n\n")
+		b.WriteString("| Provider | Old | New | Added | Removed |\n")
+		b.WriteString("| --- | ---: | ---: | ---: | ---: |\n")
+		for _, pd := range d.Providers {
+			name := pd.ProviderName
+			if pd.IngesterFailed {
+				name += " (ingester failed)"
+			}
+			fmt.Fprintf(&b, "| %s | %d | %d | %d | %d |\n", name, pd.OldMarkets, pd.NewMarkets, len(pd.Added), len(pd.Removed))
+		}
+		b.WriteString("\n")
+
+		for _, pd := range d.Providers {
+			if len(pd.Added) == 0 && len(pd.Removed) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "### %s\n\n", pd.ProviderName)
+			for _, ticker := range pd.Added {
+				fmt.Fprintf(&b, "- added `%s`\n", ticker)
+			}
+			for _, ticker := range pd.Removed {
+				fmt.Fprintf(&b, "- removed `%s`\n", ticker)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	writeSwings(&b, "Volume Swings", d.VolumeSwings)
+	writeSwings(&b, "Liquidity Swings", d.LiquiditySwings)
+
+	if len(d.AssetRemaps) > 0 {
+		b.WriteString("## CMC ID Remaps\n\n")
+		b.WriteString("| Provider | Market | Side | Symbol | Old CMC ID | New CMC ID |\n")
+		b.WriteString("| --- | --- | --- | --- | ---: | ---: |\n")
+		for _, remap := range d.AssetRemaps {
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %d | %d |\n",
+				remap.ProviderName, remap.OffChainTicker, remap.Side, remap.Symbol, remap.OldCMCID, remap.NewCMCID)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(d.AssetChanges) > 0 {
+		b.WriteString("## Asset Changes\n\n")
+		b.WriteString("| Asset | Symbol | Old Rank | New Rank | Added Tags | Removed Tags |\n")
+		b.WriteString("| --- | --- | ---: | ---: | --- | --- |\n")
+		for _, change := range d.AssetChanges {
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %s | %s |\n", change.Asset, change.Symbol, change.OldRank, change.NewRank,
+				strings.Join(change.AddedTags, ", "), strings.Join(change.RemovedTags, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func writeSwings(b *strings.Builder, title string, swings []MarketSwing) {
+	if len(swings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## %s\n\n", title)
+	b.WriteString("| Provider | Market | Old | New | Change |\n")
+	b.WriteString("| --- | --- | ---: | ---: | ---: |\n")
+	for _, swing := range swings {
+		change := "unknown"
+		if swing.Change != nil {
+			change = fmt.Sprintf("%+.1f%%", *swing.Change*100)
+		}
+		fmt.Fprintf(b, "| %s | `%s` | %s | %s | %s |\n",
+			swing.ProviderName, swing.OffChainTicker, formatSwingValue(swing.Old), formatSwingValue(swing.New), change)
+	}
+	b.WriteString("\n")
+}
+
+func formatSwingValue(value *float64) string {
+	if value == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%.2f", *value)
+}
@@ -0,0 +1,102 @@
+package diffs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/diffs"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestDiffIndexes(t *testing.T) {
+	oldDoc := provider.Document{
+		AssetInfos: []provider.AssetInfo{
+			{ID: 0, Symbol: "BTC", CMCID: 1, Rank: 1, CMCTags: []string{"pow"}},
+			{ID: 1, Symbol: "USDT", CMCID: 825, Rank: 3},
+			{ID: 2, Symbol: "FOO", CMCID: 100, Rank: 50},
+			{ID: 3, Symbol: "BAR", CoinGeckoID: "bar", Rank: 70},
+		},
+		ProviderMarkets: []provider.ProviderMarket{
+			{ProviderName: "binance_ws", OffChainTicker: "BTCUSDT", BaseAssetInfoID: 0, QuoteAssetInfoID: 1, UsdVolume: 1000, NegativeDepthTwo: 50, PositiveDepthTwo: 50},
+			{ProviderName: "binance_ws", OffChainTicker: "FOOUSDT", BaseAssetInfoID: 2, QuoteAssetInfoID: 1, QuoteVolume: 100},
+			{ProviderName: "binance_ws", OffChainTicker: "OLDUSDT", BaseAssetInfoID: 2, QuoteAssetInfoID: 1},
+			{ProviderName: "binance_ws", OffChainTicker: "BARUSDT", BaseAssetInfoID: 3, QuoteAssetInfoID: 1, UsdVolume: 500, QuoteVolume: 500},
+			{ProviderName: "okx_ws", OffChainTicker: "BTC-USDT", BaseAssetInfoID: 0, QuoteAssetInfoID: 1, UsdVolume: 1000},
+			{ProviderName: "kraken_api", OffChainTicker: "XXBTZUSD", BaseAssetInfoID: 0, QuoteAssetInfoID: 1, UsdVolume: 1000},
+		},
+	}
+
+	newDoc := provider.Document{
+		AssetInfos: []provider.AssetInfo{
+			{ID: 10, Symbol: "BTC", CMCID: 1, Rank: 1, CMCTags: []string{"pow", "store-of-value"}},
+			{ID: 11, Symbol: "USDT", CMCID: 825, Rank: 3},
+			{ID: 12, Symbol: "FOO", CMCID: 200, Rank: 40},
+			{ID: 13, Symbol: "BAR", CoinGeckoID: "bar", Rank: 60},
+		},
+		ProviderMarkets: []provider.ProviderMarket{
+			{ProviderName: "binance_ws", OffChainTicker: "BTCUSDT", BaseAssetInfoID: 10, QuoteAssetInfoID: 11, UsdVolume: 100, NegativeDepthTwo: 60, PositiveDepthTwo: 60},
+			{ProviderName: "binance_ws", OffChainTicker: "FOOUSDT", BaseAssetInfoID: 12, QuoteAssetInfoID: 11, QuoteVolume: 120},
+			{ProviderName: "binance_ws", OffChainTicker: "NEWUSDT", BaseAssetInfoID: 12, QuoteAssetInfoID: 11},
+			{ProviderName: "binance_ws", OffChainTicker: "BARUSDT", BaseAssetInfoID: 13, QuoteAssetInfoID: 11, QuoteVolume: 500},
+			{ProviderName: "okx_ws", OffChainTicker: "BTC-USDT", BaseAssetInfoID: 10, QuoteAssetInfoID: 11, UsdVolume: 2500},
+		},
+		IndexReport: &provider.IndexReport{
+			FailedIngesters: []provider.IngesterFailure{
+				{Ingester: "kraken", ProviderNames: []string{"kraken_api"}, Error: "timeout"},
+			},
+		},
+	}
+
+	diff := diffs.DiffIndexes(oldDoc, newDoc, diffs.IndexDiffOptions{VolumeThreshold: 0.5, LiquidityThreshold: 0.5})
+
+	require.Equal(t, []diffs.ProviderDiff{
+		{ProviderName: "binance_ws", OldMarkets: 4, NewMarkets: 4, Added: []string{"NEWUSDT"}, Removed: []string{"OLDUSDT"}},
+		{ProviderName: "kraken_api", OldMarkets: 1, NewMarkets: 0, Added: []string{}, Removed: []string{"XXBTZUSD"}, IngesterFailed: true},
+	}, diff.Providers)
+	require.Equal(t, []string{"kraken"}, diff.FailedIngesters)
+
+	// FOOUSDT only has quote volumes, which are not compared, while BARUSDT lost its USD volume.
+	require.Equal(t, []diffs.MarketSwing{
+		{ProviderName: "binance_ws", OffChainTicker: "BTCUSDT", Old: ptr(1000), New: ptr(100), Change: ptr(-0.9)},
+		{ProviderName: "okx_ws", OffChainTicker: "BTC-USDT", Old: ptr(1000), New: ptr(2500), Change: ptr(1.5)},
+		{ProviderName: "binance_ws", OffChainTicker: "BARUSDT", Old: ptr(500)},
+	}, diff.VolumeSwings)
+	// a 20% change in liquidity is below the threshold.
+	require.Empty(t, diff.LiquiditySwings)
+
+	require.Equal(t, []diffs.AssetRemap{
+		{ProviderName: "binance_ws", OffChainTicker: "FOOUSDT", Side: "base", Symbol: "FOO", OldCMCID: 100, NewCMCID: 200},
+	}, diff.AssetRemaps)
+
+	require.Equal(t, []diffs.AssetChange{
+		{Asset: "cmc:1", Symbol: "BTC", OldRank: 1, NewRank: 1, AddedTags: []string{"store-of-value"}},
+		{Asset: "coingecko:bar", Symbol: "BAR", OldRank: 70, NewRank: 60},
+	}, diff.AssetChanges)
+
+	markdown := diff.Markdown()
+	require.Contains(t, markdown, "| kraken_api (ingester failed) | 1 | 0 | 0 | 1 |")
+	require.Contains(t, markdown, "- added `NEWUSDT`")
+	require.Contains(t, markdown, "| binance_ws | `BTCUSDT` | 1000.00 | 100.00 | -90.0% |")
+	require.Contains(t, markdown, "| binance_ws | `BARUSDT` | 500.00 | unknown | unknown |")
+	require.Contains(t, markdown, "| binance_ws | `FOOUSDT` | base | FOO | 100 | 200 |")
+	require.False(t, strings.Contains(markdown, "Liquidity Swings"))
+}
+
+func TestDiffIndexesNoChanges(t *testing.T) {
+	doc := provider.Document{
+		AssetInfos: []provider.AssetInfo{{ID: 0, Symbol: "BTC", CMCID: 1, Rank: 1}},
+		ProviderMarkets: []provider.ProviderMarket{
+			{ProviderName: "binance_ws", OffChainTicker: "BTCUSDT", UsdVolume: 1000},
+		},
+	}
+
+	diff := diffs.DiffIndexes(doc, doc, diffs.IndexDiffOptions{VolumeThreshold: diffs.DefaultSwingThreshold})
+	require.True(t, diff.IsEmpty())
+	require.Equal(t, "# Index Diff\n\nNo changes.\n", diff.Markdown())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/skip-mev/connect-mmu/generator/querier"
 	"github.com/skip-mev/connect-mmu/generator/transformer"
 	"github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
@@ -20,10 +21,10 @@ type Generator struct {
 	t transformer.Transformer
 }
 
-func New(logger *zap.Logger, providerStore provider.Store) Generator {
+func New(logger *zap.Logger, providerStore provider.Store, aliases *symbols.AliasRegistry) Generator {
 	return Generator{
 		logger: logger.With(zap.String("mmu-service", "generator")),
-		q:      querier.New(logger, providerStore),
+		q:      querier.New(logger, providerStore, aliases),
 		t:      transformer.New(logger),
 	}
 }
@@ -41,6 +42,15 @@ func (g *Generator) GenerateMarketMap(
 
 	g.logger.Info("queried", zap.Int("feeds", len(feeds)))
 
+	for providerName, failure := range g.q.UnavailableProviders(ctx, cfg) {
+		g.logger.Warn("provider markets missing due to ingester failure during indexing",
+			zap.Bool("mmu_datadog", true),
+			zap.String("provider", providerName),
+			zap.String("ingester", failure.Ingester),
+			zap.String("error", failure.Error),
+		)
+	}
+
 	// Transform Feeds
 	transformed, dropped, err := g.t.TransformFeeds(ctx, cfg, feeds, onChainMarketMap)
 	if err != nil {
@@ -31,7 +31,7 @@ func TestGenerationDeterminisimFromIndexedFile(t *testing.T) {
 	providerStore, err := provider.NewMemoryStoreFromFile(localIndexedMarketsFile)
 	require.NoError(t, err)
 
-	gen := generator.New(logger, providerStore)
+	gen := generator.New(logger, providerStore, config.DefaultAliasConfig().Registry())
 
 	bz, err := os.ReadFile(dydxTestnetGenerationConfig)
 	require.NoError(t, err)
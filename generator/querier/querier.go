@@ -11,6 +11,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/store/provider"
 	mmutypes "github.com/skip-mev/connect-mmu/types"
 )
@@ -18,13 +19,16 @@ import (
 type Querier struct {
 	logger        *zap.Logger
 	providerStore provider.Store
+	// aliases resolve wrapped assets to their native assets.
+	aliases *symbols.AliasRegistry
 }
 
 // New creates a new Querier to read in indexed data to a MemoryStore
-func New(logger *zap.Logger, providerStore provider.Store) Querier {
+func New(logger *zap.Logger, providerStore provider.Store, aliases *symbols.AliasRegistry) Querier {
 	return Querier{
 		logger:        logger.With(zap.String("mmu-service", "querier")),
 		providerStore: providerStore,
+		aliases:       aliases,
 	}
 }
 
@@ -40,6 +44,7 @@ func (q *Querier) logConfig(cfg config.GenerateConfig) {
 func (q *Querier) Feeds(ctx context.Context, cfg config.GenerateConfig) (types.Feeds, error) {
 	args := provider.GetFilteredProviderMarketsParams{
 		ProviderNames: maps.Keys(cfg.Providers),
+		MaxMarketAge:  cfg.MaxMarketAge,
 	}
 	q.logger.Info("query", zap.Any("args", args))
 	rows, err := q.providerStore.GetProviderMarkets(ctx, args)
@@ -49,7 +54,7 @@ func (q *Querier) Feeds(ctx context.Context, cfg config.GenerateConfig) (types.F
 
 	feeds := make(types.Feeds, 0, len(rows))
 	for _, row := range rows {
-		feed, err := toFeed(row, cfg)
+		feed, err := q.toFeed(row, cfg)
 		if err != nil {
 			q.logger.Error("failed to convert row to feed", zap.Error(err), zap.Any("row", row))
 			return nil, fmt.Errorf("failed to convert row to feed: %w", err)
@@ -61,7 +66,21 @@ func (q *Querier) Feeds(ctx context.Context, cfg config.GenerateConfig) (types.F
 	return feeds, nil
 }
 
-func toFeed(pm provider.GetFilteredProviderMarketsRow, cfg config.GenerateConfig) (types.Feed, error) {
+// UnavailableProviders returns the configured providers whose ingesters failed during the index run,
+// keyed by provider name. Markets for these providers are missing from the store because of the failure,
+// not because they were delisted.
+func (q *Querier) UnavailableProviders(ctx context.Context, cfg config.GenerateConfig) map[string]provider.IngesterFailure {
+	unavailable := make(map[string]provider.IngesterFailure)
+	for providerName, failure := range q.providerStore.GetIndexReport(ctx).FailedProviders() {
+		if _, ok := cfg.Providers[providerName]; ok {
+			unavailable[providerName] = failure
+		}
+	}
+
+	return unavailable
+}
+
+func (q *Querier) toFeed(pm provider.GetFilteredProviderMarketsRow, cfg config.GenerateConfig) (types.Feed, error) {
 	// use provider name -> isCex or isDex -> minProviderCount
 	var minProviderCount uint64
 	if cfg.IsProviderDefi(pm.ProviderName) {
@@ -86,14 +105,15 @@ func toFeed(pm provider.GetFilteredProviderMarketsRow, cfg config.GenerateConfig
 		Metadata_JSON:   string(pm.MetadataJSON),
 	}
 
-	cmcInfo := mmutypes.NewCoinMarketCapInfo(pm.BaseCmcID, pm.QuoteCmcID, pm.BaseRank, pm.QuoteRank)
+	// wrapped assets are resolved to their native assets to assert that these are _essentially_ the same asset.
+	cmcInfo := mmutypes.NewCoinMarketCapInfo(q.aliases.CMCID(pm.BaseCmcID), q.aliases.CMCID(pm.QuoteCmcID), pm.BaseRank, pm.QuoteRank)
 
 	liquidityInfo := mmutypes.LiquidityInfo{
 		NegativeDepthTwo: pm.NegativeDepthTwo,
 		PositiveDepthTwo: pm.PositiveDepthTwo,
 	}
 
-	return types.NewFeed(
+	feed := types.NewFeed(
 		ticker,
 		providerConfig,
 		pm.QuoteVolume,
@@ -101,7 +121,10 @@ func toFeed(pm provider.GetFilteredProviderMarketsRow, cfg config.GenerateConfig
 		pm.ReferencePrice,
 		liquidityInfo,
 		cmcInfo,
-	), nil
+	)
+	feed.CoinGeckoInfo = mmutypes.NewCoinGeckoInfo(pm.BaseCoinGeckoID, pm.QuoteCoinGeckoID, pm.BaseCoinGeckoRank, pm.QuoteCoinGeckoRank)
+
+	return feed, nil
 }
 
 func (q *Querier) CMCIDToAssetInfo(ctx context.Context, cfg config.GenerateConfig) (map[int64]provider.AssetInfo, error) {
@@ -109,7 +109,7 @@ func TestFeeds(t *testing.T) {
 	require.NoError(t, err)
 	log, err := zap.NewDevelopment()
 	require.NoError(t, err)
-	qr := querier.New(log, store)
+	qr := querier.New(log, store, nil)
 
 	t.Run("get no feeds for empty query", func(t *testing.T) {
 		feeds, err := qr.Feeds(ctx, config.GenerateConfig{})
@@ -133,6 +133,32 @@ func TestFeeds(t *testing.T) {
 	})
 }
 
+func TestUnavailableProviders(t *testing.T) {
+	store := provider.NewMemoryStore()
+	ctx := context.Background()
+
+	failure := provider.IngesterFailure{
+		Ingester:      "okx",
+		ProviderNames: []string{"okx_ws"},
+		Error:         "503 service unavailable",
+	}
+	require.NoError(t, store.SetIndexReport(ctx, provider.IndexReport{
+		FailedIngesters: []provider.IngesterFailure{failure},
+	}))
+
+	qr := querier.New(zap.NewNop(), store, nil)
+
+	t.Run("no unavailable providers when failed provider is not configured", func(t *testing.T) {
+		unavailable := qr.UnavailableProviders(ctx, config.GenerateConfig{Providers: map[string]config.ProviderConfig{"coinbase_ws": {}}})
+		require.Empty(t, unavailable)
+	})
+
+	t.Run("configured failed provider is unavailable", func(t *testing.T) {
+		unavailable := qr.UnavailableProviders(ctx, config.GenerateConfig{Providers: map[string]config.ProviderConfig{"okx_ws": {}, "coinbase_ws": {}}})
+		require.Equal(t, map[string]provider.IngesterFailure{"okx_ws": failure}, unavailable)
+	})
+}
+
 func createAssets(ctx context.Context, t *testing.T, store provider.Store, assets []string) []int32 {
 	t.Helper()
 	ids := make([]int32, 0, len(assets))
@@ -3,7 +3,6 @@ package transformer
 import (
 	"context"
 	"fmt"
-	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -16,7 +15,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/generator/types"
-	"github.com/skip-mev/connect-mmu/lib/file"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
 )
 
 const NON_EXISTENT_CMC_ID = int64(-1)
@@ -77,6 +76,11 @@ func NormalizeBy() TransformFeed {
 				feed.Ticker.CurrencyPair.Quote = newQuote
 
 				adjustPrice, ok := avgRefPrices[normPair.String()]
+				if !ok {
+					// there may be no feeds for the pair itself (e.g. KRW/USD), so try to derive its
+					// price from a common asset instead (e.g. USDT/USD and USDT/KRW).
+					adjustPrice, ok = types.CrossReferencePrice(avgRefPrices, normPair)
+				}
 				if !ok {
 					return nil, nil, fmt.Errorf("adjust price for %s not found", normPair.String())
 				}
@@ -105,8 +109,9 @@ func NormalizeBy() TransformFeed {
 // - GOAT/USD from binance and kraken referring to Goatseus Maximus (CMC ID 33440)
 // - GOAT/USD from uniswap base referring to GOAT on Base (CMC ID 34935)
 //
-// For each market, we sort the feeds and select the base asset's CMC ID of the first sorted feed. This
-// will have the best CMC rank. We then filter out all feeds for this market that do not match this CMC ID.
+// For each market, we sort the feeds and select the base asset of the first sorted feed. This will have the
+// best CMC rank, or the best CoinGecko rank if no feed has a CMC rank. We then filter out all feeds for this
+// market that do not refer to this base asset. Base assets without a CMC ID are identified by their CoinGecko ID.
 func ResolveCMCConflictsForMarket() TransformFeed {
 	return WithoutMarketMap(func(_ context.Context, logger *zap.Logger, _ config.GenerateConfig, feeds types.Feeds) (types.Feeds,
 		types.ExclusionReasons, error,
@@ -124,20 +129,19 @@ func ResolveCMCConflictsForMarket() TransformFeed {
 
 		for ticker, feeds := range tickerToFeeds {
 			feeds.Sort()
-			bestCMCId := feeds[0].CMCInfo.BaseID
+			bestID := feeds[0].UniqueBaseID()
 			bestCMCRank := feeds[0].CMCInfo.BaseRank
 			for _, feed := range feeds {
-				if feed.CMCInfo.BaseRank < bestCMCRank {
-					panic(fmt.Sprintf("found feed for %s with lower CMC rank than the best one for ticker %s. best CMC rank %d, feed CMC rank %d", feed.ProviderConfig.Name, ticker, bestCMCRank, feed.CMCInfo.BaseRank))
+				if types.BetterRank(feed.CMCInfo.BaseRank, bestCMCRank) {
+					panic(fmt.Sprintf("found feed for %s with better CMC rank than the best one for ticker %s. best CMC rank %d, feed CMC rank %d", feed.ProviderConfig.Name, ticker, bestCMCRank, feed.CMCInfo.BaseRank))
 				}
-				if feed.CMCInfo.BaseID == bestCMCId {
+				if feed.UniqueBaseID() == bestID {
 					out = append(out, feed)
 				} else {
 					exclusions.AddExclusionReasonFromFeed(feed, feed.ProviderConfig.Name,
-						fmt.Sprintf("Transform ResolveCMCConflictsForMarket: BestCMCID: %d, FeedCMCID: %d, BestCMCRank: %d, FeedCMCRank: %d", bestCMCId,
-							feed.CMCInfo.BaseID, bestCMCRank, feed.CMCInfo.BaseRank))
-					logger.Debug("dropping feed with worse CMC ID", zap.Any("ticker", feed.Ticker.String()), zap.Any("provider", feed.ProviderConfig.Name))
-
+						fmt.Sprintf("Transform ResolveCMCConflictsForMarket: BestBaseID: %s, FeedBaseID: %s, BestCMCRank: %d, FeedCMCRank: %d, BestCoinGeckoRank: %d, FeedCoinGeckoRank: %d",
+							bestID, feed.UniqueBaseID(), bestCMCRank, feed.CMCInfo.BaseRank, feeds[0].CoinGeckoInfo.BaseRank, feed.CoinGeckoInfo.BaseRank))
+					logger.Debug("dropping feed with worse base asset", zap.Any("ticker", feed.Ticker.String()), zap.Any("provider", feed.ProviderConfig.Name))
 				}
 			}
 		}
@@ -205,13 +209,13 @@ func DropFeedsWithoutAggregatorIDs() TransformFeed {
 		exclusions := types.NewExclusionReasons()
 		for _, feed := range feeds {
 			providerConfig := cfg.Providers[feed.ProviderConfig.Name]
-			if (feed.CMCInfo.BaseID != 0 && providerConfig.RequireAggregateIDs) || !providerConfig.
-				RequireAggregateIDs {
+			hasAggregateID := feed.CMCInfo.BaseID != 0 || feed.CoinGeckoInfo.BaseID != ""
+			if (hasAggregateID && providerConfig.RequireAggregateIDs) || !providerConfig.RequireAggregateIDs {
 				out = append(out, feed)
 			} else {
 				exclusions.AddExclusionReasonFromFeed(feed, feed.ProviderConfig.Name,
-					fmt.Sprintf("Transform DropFeedsWithoutAggregatorIDs: BaseCMCID: %d, RequireAggregateIDs: %v", feed.CMCInfo.BaseID,
-						providerConfig.RequireAggregateIDs))
+					fmt.Sprintf("Transform DropFeedsWithoutAggregatorIDs: BaseCMCID: %d, BaseCoinGeckoID: %q, RequireAggregateIDs: %v",
+						feed.CMCInfo.BaseID, feed.CoinGeckoInfo.BaseID, providerConfig.RequireAggregateIDs))
 				logger.Info("dropping feed", zap.Any("ticker", feed.Ticker.String()), zap.Any("provider", feed.ProviderConfig.Name))
 			}
 		}
@@ -221,6 +225,36 @@ func DropFeedsWithoutAggregatorIDs() TransformFeed {
 	})
 }
 
+// DropScaledFeeds drops feeds from markets that trade a fixed multiple of their base asset, such as
+// 1000SATSUSDT on binance. These markets are indexed on the underlying asset with the multiplier in
+// their provider metadata, but a provider config cannot scale the price reported by the provider, so
+// they would report a price that is off by the multiplier.
+func DropScaledFeeds() TransformFeed {
+	return WithoutMarketMap(func(_ context.Context, logger *zap.Logger, _ config.GenerateConfig, feeds types.Feeds) (types.Feeds,
+		types.ExclusionReasons, error,
+	) {
+		logger.Info("dropping scaled feeds", zap.Int("num feeds", len(feeds)))
+
+		out := make([]types.Feed, 0, len(feeds))
+		exclusions := types.NewExclusionReasons()
+		for _, feed := range feeds {
+			metadata, scaled := mmutypes.ScaledMarketMetadataFromJSON(feed.ProviderConfig.Metadata_JSON)
+			if !scaled {
+				out = append(out, feed)
+				continue
+			}
+
+			exclusions.AddExclusionReasonFromFeed(feed, feed.ProviderConfig.Name,
+				fmt.Sprintf("Transform DropScaledFeeds: OffChainTicker: %s, ScaledBase: %s, Multiplier: %d, provider prices cannot be scaled",
+					feed.ProviderConfig.OffChainTicker, metadata.ScaledBase, metadata.Multiplier))
+			logger.Debug("dropping scaled feed", zap.Any("ticker", feed.Ticker.String()), zap.Any("provider", feed.ProviderConfig.Name))
+		}
+
+		logger.Info("dropped scaled feeds", zap.Int("remaining feeds", len(out)))
+		return out, exclusions, nil
+	})
+}
+
 // InvertOrDrop attempts to invert any potential feeds that could be inverted to a desired quote config to be valid.
 //
 // For example:
@@ -263,8 +297,9 @@ func InvertOrDrop() TransformFeed {
 					feed.ReferencePrice = new(big.Float).Quo(big.NewFloat(1), feed.ReferencePrice)
 				}
 
-				// invert the CMC IDs
+				// invert the aggregator IDs
 				feed.CMCInfo.Invert()
+				feed.CoinGeckoInfo.Invert()
 
 				logger.Debug("inverted feed", zap.Any("feed", feed))
 				out = append(out, feed)
@@ -439,7 +474,6 @@ func ResolveNamingAliases() TransformFeed {
 		out.Sort()
 		logger.Info("resolved ticker string naming aliases", zap.Int("feeds", len(out)))
 
-		file.CreateAndWriteJSONToFile("tmp_feeds.json", out)
 		return out, exclusions, nil
 	}
 }
@@ -461,9 +495,13 @@ func getOnChainBaseAssetID(tickerString string, onChainMarketMap mmtypes.MarketM
 					return onChainBaseAssetID, err
 				}
 
-				onChainBaseAssetID, err = strconv.ParseInt(existingMetadata.AggregateIDs[0].ID, 10, 64)
-				if err != nil {
-					return onChainBaseAssetID, fmt.Errorf("failed to parse CMC ID: %w", err)
+				// markets that are only known to CoinGecko have no CMC ID.
+				cmcID, found := types.AggregateID(existingMetadata, types.VenueCoinMarketcap)
+				if found {
+					onChainBaseAssetID, err = strconv.ParseInt(cmcID, 10, 64)
+					if err != nil {
+						return onChainBaseAssetID, fmt.Errorf("failed to parse CMC ID: %w", err)
+					}
 				}
 			}
 		}
@@ -479,15 +517,15 @@ func getOnChainBaseAssetID(tickerString string, onChainMarketMap mmtypes.MarketM
 // refer to the same market.
 //
 // The input data to this function should be a map[CMCIds] -> feeds with the _same_ CMC Info.
-// The set of feeds with the _lowest_ BaseAssetRank will be chosen.
+// The set of feeds with the _lowest_ BaseAssetRank will be chosen. Groups without a CMC rank are only chosen
+// by their CoinGecko rank if no group has a CMC rank.
 func getHighestRankFeedGroup(feedGroups map[string]types.Feeds, onChainBaseAssetID int64) (string, error) {
 	if len(feedGroups) == 0 {
 		return "", fmt.Errorf("no feed groups found")
 	}
 
 	bestGroup := ""
-	bestRankBase := int64(math.MaxInt64)
-	bestRankQuote := int64(math.MaxInt64)
+	bestRank := groupRank{}
 	for groupID, group := range feedGroups {
 		if len(group) == 0 {
 			return "", fmt.Errorf("no feeds found in group %s", groupID)
@@ -495,11 +533,6 @@ func getHighestRankFeedGroup(feedGroups map[string]types.Feeds, onChainBaseAsset
 
 		feed := group[0]
 
-		// if we don't have ranking info, don't consider
-		if !feed.CMCInfo.HasRank() {
-			continue
-		}
-
 		// If the base asset is enabled on chain, only consider feeds that match its CMC ID
 		if onChainBaseAssetID != NON_EXISTENT_CMC_ID {
 			if feed.CMCInfo.BaseID != onChainBaseAssetID {
@@ -507,17 +540,16 @@ func getHighestRankFeedGroup(feedGroups map[string]types.Feeds, onChainBaseAsset
 			}
 		}
 
-		// all items in this group have the same CMC Rank, so we just use item 0
-		if feed.CMCInfo.BaseRank < bestRankBase {
+		// all items in this group have the same ranks, so we just use item 0
+		rank, ok := newGroupRank(feed)
+		// if we don't have ranking info, don't consider
+		if !ok {
+			continue
+		}
+
+		if bestGroup == "" || rank.better(bestRank) {
 			bestGroup = groupID
-			bestRankBase = feed.CMCInfo.BaseRank
-			bestRankQuote = feed.CMCInfo.QuoteRank
-		} else if feed.CMCInfo.BaseRank == bestRankBase {
-			// compare quoteRank
-			if feed.CMCInfo.QuoteRank < bestRankQuote {
-				bestGroup = groupID
-				bestRankQuote = feed.CMCInfo.QuoteRank
-			}
+			bestRank = rank
 		}
 	}
 
@@ -528,6 +560,35 @@ func getHighestRankFeedGroup(feedGroups map[string]types.Feeds, onChainBaseAsset
 	return bestGroup, nil
 }
 
+// groupRank is the rank of a feed group. CMC ranks and CoinGecko ranks are on different scales, so groups with a
+// CMC rank are always better than groups with only a CoinGecko rank.
+type groupRank struct {
+	coinGecko bool
+	base      int64
+	quote     int64
+}
+
+func newGroupRank(feed types.Feed) (groupRank, bool) {
+	switch {
+	case feed.CMCInfo.HasRank():
+		return groupRank{base: feed.CMCInfo.BaseRank, quote: feed.CMCInfo.QuoteRank}, true
+	case feed.CoinGeckoInfo.HasRank():
+		return groupRank{coinGecko: true, base: feed.CoinGeckoInfo.BaseRank, quote: feed.CoinGeckoInfo.QuoteRank}, true
+	default:
+		return groupRank{}, false
+	}
+}
+
+func (r groupRank) better(other groupRank) bool {
+	if r.coinGecko != other.coinGecko {
+		return !r.coinGecko
+	}
+	if r.base != other.base {
+		return r.base < other.base
+	}
+	return r.quote < other.quote
+}
+
 // TopFeedsForProvider chooses only the top N feeds for a provider if it has a filter set.
 // The feeds are sorted by the base asset's CMC rank and then the top N are chosen.
 // If no filter is set, the feeds are sorted, but no feeds will be excluded.
@@ -233,6 +233,81 @@ func TestNormalizeBy(t *testing.T) {
 				},
 			}, expectErr: false,
 		},
+		{
+			name: "valid market adjusted by a pair derived from a common asset",
+			cfg: config.GenerateConfig{
+				Quotes: map[string]config.QuoteConfig{
+					"KRW": {NormalizeByPair: "KRW/USD"},
+					"USD": {},
+				},
+			},
+			feeds: []types.Feed{
+				{
+					Ticker:         mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "KRW"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfig: mmtypes.ProviderConfig{Name: "upbit_ws", OffChainTicker: "KRW-BTC"},
+					ReferencePrice: big.NewFloat(65536000),
+					CMCInfo:        cmcInfoA,
+				},
+				{
+					Ticker:         mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("USDT", "KRW"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfig: mmtypes.ProviderConfig{Name: "upbit_ws", OffChainTicker: "KRW-USDT"},
+					ReferencePrice: big.NewFloat(1024),
+					CMCInfo:        usdtusdFeed.CMCInfo,
+				},
+				{
+					Ticker:         marketUsdtUsd.Ticker,
+					ProviderConfig: marketUsdtUsd.ProviderConfigs[0],
+					ReferencePrice: big.NewFloat(1),
+					CMCInfo:        usdtusdFeed.CMCInfo,
+				},
+			},
+			transformed: []types.Feed{
+				{
+					Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name:            "upbit_ws",
+						OffChainTicker:  "KRW-BTC",
+						NormalizeByPair: &connecttypes.CurrencyPair{Base: "KRW", Quote: "USD"},
+					},
+					ReferencePrice: big.NewFloat(64000),
+					CMCInfo:        cmcInfoA,
+				},
+				{
+					Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("USDT", "USD"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name:            "upbit_ws",
+						OffChainTicker:  "KRW-USDT",
+						NormalizeByPair: &connecttypes.CurrencyPair{Base: "KRW", Quote: "USD"},
+					},
+					ReferencePrice: big.NewFloat(1),
+					CMCInfo:        usdtusdFeed.CMCInfo,
+				},
+				{
+					Ticker:         marketUsdtUsd.Ticker,
+					ProviderConfig: marketUsdtUsd.ProviderConfigs[0],
+					ReferencePrice: big.NewFloat(1),
+					CMCInfo:        usdtusdFeed.CMCInfo,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid normalize by pair without a price",
+			cfg: config.GenerateConfig{
+				Quotes: map[string]config.QuoteConfig{
+					"KRW": {NormalizeByPair: "KRW/USD"},
+				},
+			},
+			feeds: []types.Feed{
+				{
+					Ticker:         mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "KRW"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfig: mmtypes.ProviderConfig{Name: "upbit_ws", OffChainTicker: "KRW-BTC"},
+					ReferencePrice: big.NewFloat(65536000),
+					CMCInfo:        cmcInfoA,
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "invalid quotes",
 			cfg:  config.GenerateConfig{},
@@ -476,6 +551,34 @@ func TestDropFeeds(t *testing.T) {
 	}
 }
 
+func TestDropScaledFeeds(t *testing.T) {
+	scaledProviderConfig := mmtypes.ProviderConfig{
+		Name:           binanceProvider,
+		OffChainTicker: "1000SATSUSDT",
+		Metadata_JSON:  `{"multiplier":1000,"scaled_base":"1000SATS"}`,
+	}
+	satsusdt := mmtypes.Ticker{
+		CurrencyPair:     connecttypes.NewCurrencyPair("SATS", "USDT"),
+		Decimals:         8,
+		MinProviderCount: 1,
+	}
+
+	feeds := types.Feeds{
+		types.NewFeed(marketBtcUsdt.Ticker, marketBtcUsdt.ProviderConfigs[0], 20000.0, 20000.0, 20000.0, liquidityInfo2000, cmcInfoA),
+		types.NewFeed(satsusdt, scaledProviderConfig, 20000.0, 20000.0, 0.0000003, liquidityInfo2000, cmcInfoB),
+	}
+
+	transformed, dropped, err := transformer.DropScaledFeeds()(context.Background(), zap.NewNop(), config.GenerateConfig{}, feeds, mmtypes.MarketMap{})
+	require.NoError(t, err)
+	require.True(t, feeds[:1].Equal(transformed))
+
+	require.Len(t, dropped, 1)
+	require.Len(t, dropped[satsusdt.String()], 1)
+	require.Equal(t, binanceProvider, dropped[satsusdt.String()][0].Provider)
+	require.Contains(t, dropped[satsusdt.String()][0].Reason, "Transform DropScaledFeeds")
+	require.Contains(t, dropped[satsusdt.String()][0].Reason, "Multiplier: 1000")
+}
+
 func TestInvert(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1746,6 +1849,136 @@ func TestResolveCMCConflictsForMarket(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "resolve conflicts between assets only known to coingecko - keep best coingecko rank",
+			feeds: types.Feeds{
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: krakenProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 300,
+					},
+					DailyUsdVolume: big.NewFloat(0),
+				},
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: binanceProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 300,
+					},
+					DailyUsdVolume: big.NewFloat(10),
+				},
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: bybitProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goat-on-base",
+						BaseRank: 2000,
+					},
+					DailyUsdVolume: big.NewFloat(20),
+				},
+			},
+			expectedFeeds: types.Feeds{
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: krakenProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 300,
+					},
+					DailyUsdVolume: big.NewFloat(0),
+				},
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: binanceProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 300,
+					},
+					DailyUsdVolume: big.NewFloat(10),
+				},
+			},
+		},
+		{
+			name: "resolve conflicts - keep cmc ranked asset over better coingecko rank",
+			feeds: types.Feeds{
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: krakenProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						BaseID:   33440,
+						QuoteID:  2781, // USD
+						BaseRank: 500,
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 0,
+					},
+					DailyUsdVolume: big.NewFloat(0),
+				},
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: bybitProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						QuoteID: 2781, // USD
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goat-on-base",
+						BaseRank: 10,
+					},
+					DailyUsdVolume: big.NewFloat(20),
+				},
+			},
+			expectedFeeds: types.Feeds{
+				{
+					Ticker: btcusd,
+					ProviderConfig: mmtypes.ProviderConfig{
+						Name: krakenProvider,
+					},
+					CMCInfo: mmutypes.CoinMarketCapInfo{
+						BaseID:   33440,
+						QuoteID:  2781, // USD
+						BaseRank: 500,
+					},
+					CoinGeckoInfo: mmutypes.CoinGeckoInfo{
+						BaseID:   "goatseus-maximus",
+						BaseRank: 0,
+					},
+					DailyUsdVolume: big.NewFloat(0),
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
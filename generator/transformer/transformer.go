@@ -28,6 +28,7 @@ func New(logger *zap.Logger) Transformer {
 	return Transformer{
 		logger: logger.With(zap.String("mmu-service", "transformer")),
 		feedTransforms: []TransformFeed{
+			DropScaledFeeds(),
 			InvertOrDrop(), // must invert before normalize
 			PruneByLiquidity(),
 			PruneByQuoteVolume(),
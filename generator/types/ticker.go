@@ -11,6 +11,7 @@ import (
 
 const (
 	VenueCoinMarketcap = "coinmarketcap"
+	VenueCoinGecko     = "coingecko"
 )
 
 // ToTickerMetadataJSON creates a JSON string from the given database row based on the chain
@@ -24,11 +25,20 @@ func ToTickerMetadataJSON(feed Feed, referencePrice *big.Float, totalLiquidity f
 		CrossLaunch:    false,
 	}
 
-	// Base Asset
-	md.AggregateIDs = append(md.AggregateIDs, tickermetadata.AggregatorID{
-		Venue: VenueCoinMarketcap,
-		ID:    strconv.FormatInt(feed.CMCInfo.BaseID, 10),
-	})
+	// Base Asset. The CoinMarketCap ID is always listed first, unless the asset is only known to CoinGecko.
+	if feed.CMCInfo.BaseID != 0 || feed.CoinGeckoInfo.BaseID == "" {
+		md.AggregateIDs = append(md.AggregateIDs, tickermetadata.AggregatorID{
+			Venue: VenueCoinMarketcap,
+			ID:    strconv.FormatInt(feed.CMCInfo.BaseID, 10),
+		})
+	}
+
+	if feed.CoinGeckoInfo.BaseID != "" {
+		md.AggregateIDs = append(md.AggregateIDs, tickermetadata.AggregatorID{
+			Venue: VenueCoinGecko,
+			ID:    feed.CoinGeckoInfo.BaseID,
+		})
+	}
 
 	bz, err := tickermetadata.MarshalDyDx(md)
 	if err != nil {
@@ -36,3 +46,14 @@ func ToTickerMetadataJSON(feed Feed, referencePrice *big.Float, totalLiquidity f
 	}
 	return string(bz), nil
 }
+
+// AggregateID returns the ID of the given aggregator venue in the ticker metadata.
+func AggregateID(md tickermetadata.DyDx, venue string) (string, bool) {
+	for _, id := range md.AggregateIDs {
+		if id.Venue == venue {
+			return id.ID, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dydxprotocol/slinky/x/marketmap/types/tickermetadata"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/generator/types"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
+)
+
+func TestToTickerMetadataJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmcInfo  mmutypes.CoinMarketCapInfo
+		cgInfo   mmutypes.CoinGeckoInfo
+		expected []tickermetadata.AggregatorID
+		uniqueID string
+	}{
+		{
+			name:     "coinmarketcap only",
+			cmcInfo:  mmutypes.NewCoinMarketCapInfo(1, 2781, 1, 0),
+			expected: []tickermetadata.AggregatorID{{Venue: types.VenueCoinMarketcap, ID: "1"}},
+			uniqueID: "1-2781",
+		},
+		{
+			name:    "coinmarketcap and coingecko",
+			cmcInfo: mmutypes.NewCoinMarketCapInfo(1, 2781, 1, 0),
+			cgInfo:  mmutypes.NewCoinGeckoInfo("bitcoin", "", 0, 0),
+			expected: []tickermetadata.AggregatorID{
+				{Venue: types.VenueCoinMarketcap, ID: "1"},
+				{Venue: types.VenueCoinGecko, ID: "bitcoin"},
+			},
+			uniqueID: "1-2781",
+		},
+		{
+			name:     "coingecko only",
+			cmcInfo:  mmutypes.NewCoinMarketCapInfo(0, 2781, 0, 0),
+			cgInfo:   mmutypes.NewCoinGeckoInfo("pepe", "", 0, 0),
+			expected: []tickermetadata.AggregatorID{{Venue: types.VenueCoinGecko, ID: "pepe"}},
+			uniqueID: "coingecko:pepe-2781",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := types.Feed{CMCInfo: tt.cmcInfo, CoinGeckoInfo: tt.cgInfo}
+			require.Equal(t, tt.uniqueID, feed.UniqueID())
+
+			bz, err := types.ToTickerMetadataJSON(feed, big.NewFloat(1), 100)
+			require.NoError(t, err)
+
+			md, err := tickermetadata.DyDxFromJSONString(bz)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, md.AggregateIDs)
+
+			id, found := types.AggregateID(md, types.VenueCoinGecko)
+			require.Equal(t, tt.cgInfo.BaseID != "", found)
+			require.Equal(t, tt.cgInfo.BaseID, id)
+		})
+	}
+}
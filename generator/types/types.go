@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
 	mmtypes "github.com/dydxprotocol/slinky/x/marketmap/types"
 	"golang.org/x/exp/slices"
 
@@ -75,6 +76,8 @@ type Feed struct {
 	ReferencePrice *big.Float
 	// CMCInfo contains coinmarketcap specific information
 	CMCInfo types.CoinMarketCapInfo
+	// CoinGeckoInfo contains coingecko specific information
+	CoinGeckoInfo types.CoinGeckoInfo
 	// LiquidityInfo contains buy and sell side liquidity denominated in USD.
 	LiquidityInfo types.LiquidityInfo
 }
@@ -86,9 +89,6 @@ func NewFeed(
 	liquidityInfo types.LiquidityInfo,
 	cmcInfo types.CoinMarketCapInfo,
 ) Feed {
-	cmcInfo.BaseID = resolveWrappedAssetAliases(cmcInfo.BaseID)
-	cmcInfo.QuoteID = resolveWrappedAssetAliases(cmcInfo.QuoteID)
-
 	return Feed{
 		Ticker:           t,
 		ProviderConfig:   pc,
@@ -103,31 +103,25 @@ func NewFeed(
 // TickerString returns the string representation of the Feed's Market's Ticker.
 func (f *Feed) TickerString() string { return f.Ticker.String() }
 
-// knownWrappedAssetAliases maps the CMC ids of wrapped assets to their native asset CMC ID.
-// This is used to resolve wrapped assets to their native assets to assert that these are
-// _essentially_ the same asset.
-var knownWrappedAssetAliases = map[int64]int64{
-	// Wrapped SOL -> SOL
-	// - SOL:  https://coinmarketcap.com/currencies/solana/
-	// - Wrapped SOL: https://coinmarketcap.com/currencies/wrapped-solana/
-	16116: 5426,
+// UniqueID returns an ID that uniquely identifies the asset pair that is being represented using CoinMarketCap IDs
+// ID is of form: "BaseAssetID-QuoteAssetID". Assets that are only known to CoinGecko are identified as "coingecko:ID".
+// Wrapped assets are already resolved to their native assets by the querier.
+func (f *Feed) UniqueID() string {
+	return f.uniqueAssetID(f.CMCInfo.BaseID, f.CoinGeckoInfo.BaseID) + "-" +
+		f.uniqueAssetID(f.CMCInfo.QuoteID, f.CoinGeckoInfo.QuoteID)
 }
 
-func resolveWrappedAssetAliases(id int64) int64 {
-	if nativeAssetID, found := knownWrappedAssetAliases[id]; found {
-		return nativeAssetID
-	}
-
-	return id
+// UniqueBaseID returns the ID that uniquely identifies the base asset of the Feed, as in UniqueID.
+func (f *Feed) UniqueBaseID() string {
+	return f.uniqueAssetID(f.CMCInfo.BaseID, f.CoinGeckoInfo.BaseID)
 }
 
-// UniqueID returns an ID that uniquely identifies the asset pair that is being represented using CoinMarketCap IDs
-// ID is of form: "BaseAssetID-QuoteAssetID".
-func (f *Feed) UniqueID() string {
-	resolvedBaseID := resolveWrappedAssetAliases(f.CMCInfo.BaseID)
-	resolvedQuoteID := resolveWrappedAssetAliases(f.CMCInfo.QuoteID)
+func (f *Feed) uniqueAssetID(cmcID int64, coinGeckoID string) string {
+	if cmcID == 0 && coinGeckoID != "" {
+		return VenueCoinGecko + ":" + coinGeckoID
+	}
 
-	return strconv.FormatInt(resolvedBaseID, 10) + "-" + strconv.FormatInt(resolvedQuoteID, 10)
+	return strconv.FormatInt(cmcID, 10)
 }
 
 // Compare compares two Feeds
@@ -138,11 +132,16 @@ func (f *Feed) UniqueID() string {
 //
 // Comparison is done as follows:
 // 1. attempt to compare CMC ranks of the feeds
-// 2. else, attempt to compare liquidity of the feeds
-// 3. fall back to quote volume of the assets.
+// 2. else, attempt to compare CoinGecko ranks of the feeds
+// 3. else, attempt to compare liquidity of the feeds
+// 4. fall back to quote volume of the assets.
 func Compare(a, b Feed) bool {
 	if a.CMCInfo.BaseRank != b.CMCInfo.BaseRank {
-		return a.CMCInfo.BaseRank > b.CMCInfo.BaseRank
+		return BetterRank(b.CMCInfo.BaseRank, a.CMCInfo.BaseRank)
+	}
+
+	if a.CoinGeckoInfo.BaseRank != b.CoinGeckoInfo.BaseRank {
+		return BetterRank(b.CoinGeckoInfo.BaseRank, a.CoinGeckoInfo.BaseRank)
 	}
 
 	if a.LiquidityInfo.TotalLiquidity() != b.LiquidityInfo.TotalLiquidity() {
@@ -153,12 +152,25 @@ func Compare(a, b Feed) bool {
 	return cmp < 0
 }
 
+// BetterRank returns true if rank a is better than rank b. Ranked assets are better than unranked ones, which have
+// a rank of 0.
+func BetterRank(a, b int64) bool {
+	if a == 0 || b == 0 {
+		return a != 0
+	}
+
+	return a < b
+}
+
 // Sort follows the following rules:
 //
 // # CMC rank
 // if feed A has a CMC rank and feed B does not, feedA is higher than feedB
 // if feed A has a lower CMC rank than feed b, feedA is higher than feed B
 //
+// # CoinGecko rank
+// if the CMC ranks are equal, CoinGecko ranks are compared the same way
+//
 // # Liquidity
 // if feed A and feed B have non-zero, non-equal liquidity, if feed A has more liquidity than feed B, feed A is higher than feed B
 //
@@ -287,6 +299,10 @@ func (f *Feed) Equal(feedB Feed) bool {
 		return false
 	}
 
+	if f.CoinGeckoInfo != feedB.CoinGeckoInfo {
+		return false
+	}
+
 	if f.LiquidityInfo != feedB.LiquidityInfo {
 		return false
 	}
@@ -313,6 +329,35 @@ func (f Feeds) Equal(feedsB Feeds) bool {
 	return true
 }
 
+// CrossReferencePrice derives the reference price of pair from average reference prices keyed by
+// ticker string when there is no price for the pair itself. It looks for an asset X that is quoted in
+// both the base and the quote of the pair, so that BASE/QUOTE = (X/QUOTE) / (X/BASE). For example,
+// KRW/USD can be derived from USDT/USD and USDT/KRW. Candidates are tried in sorted order so that the
+// result is deterministic.
+func CrossReferencePrice(avgRefPrices map[string]*big.Float, pair connecttypes.CurrencyPair) (*big.Float, bool) {
+	candidates := make([]string, 0)
+	for ticker := range avgRefPrices {
+		cp, err := connecttypes.CurrencyPairFromString(ticker)
+		if err != nil || cp.Quote != pair.Quote || cp.Base == pair.Base {
+			continue
+		}
+		candidates = append(candidates, cp.Base)
+	}
+	slices.Sort(candidates)
+
+	for _, cross := range candidates {
+		crossInQuote := avgRefPrices[connecttypes.NewCurrencyPair(cross, pair.Quote).String()]
+		crossInBase, found := avgRefPrices[connecttypes.NewCurrencyPair(cross, pair.Base).String()]
+		if !found || crossInBase.Sign() == 0 {
+			continue
+		}
+
+		return new(big.Float).Quo(crossInQuote, crossInBase), true
+	}
+
+	return nil, false
+}
+
 func CalculateAverageReferencePrices(feeds Feeds) (map[string]*big.Float, error) {
 	// ticker -> sum of all reference prices
 	feedReferencePriceSum := make(map[string]*big.Float)
@@ -213,6 +213,39 @@ func TestCompare(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "feed with a rank takes precedence over unranked feed",
+			a: types.Feed{
+				CMCInfo: mmutypes.NewCoinMarketCapInfo(0, 0, 0, 0),
+			},
+			b: types.Feed{
+				CMCInfo: mmutypes.NewCoinMarketCapInfo(0, 0, 100, 1),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "coingecko rank is compared when cmc ranks are equal",
+			a: types.Feed{
+				CoinGeckoInfo: mmutypes.NewCoinGeckoInfo("a", "", 10, 0),
+			},
+			b: types.Feed{
+				CoinGeckoInfo: mmutypes.NewCoinGeckoInfo("b", "", 1, 0),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "cmc rank takes precedence over coingecko rank",
+			a: types.Feed{
+				CMCInfo: mmutypes.NewCoinMarketCapInfo(0, 0, 100, 0),
+			},
+			b: types.Feed{
+				CoinGeckoInfo: mmutypes.NewCoinGeckoInfo("b", "", 1, 0),
+			},
+			want:    false,
+			wantErr: false,
+		},
 		{
 			name: "mismatched currency still returns",
 			a: types.Feed{
@@ -423,3 +456,55 @@ func TestExclusionReasons_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestCrossReferencePrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices map[string]*big.Float
+		pair   connecttypes.CurrencyPair
+		want   *big.Float
+		found  bool
+	}{
+		{
+			name: "derived from a common asset",
+			prices: map[string]*big.Float{
+				"USDT/USD": big.NewFloat(1),
+				"USDT/KRW": big.NewFloat(1024),
+				"BTC/KRW":  big.NewFloat(65536000),
+			},
+			pair:  connecttypes.NewCurrencyPair("KRW", "USD"),
+			want:  big.NewFloat(1.0 / 1024),
+			found: true,
+		},
+		{
+			name: "first common asset in sorted order is used",
+			prices: map[string]*big.Float{
+				"USDT/USD": big.NewFloat(1),
+				"USDT/KRW": big.NewFloat(1024),
+				"BTC/USD":  big.NewFloat(64000),
+				"BTC/KRW":  big.NewFloat(32000000),
+			},
+			pair:  connecttypes.NewCurrencyPair("KRW", "USD"),
+			want:  big.NewFloat(0.002),
+			found: true,
+		},
+		{
+			name: "no common asset",
+			prices: map[string]*big.Float{
+				"USDT/USD": big.NewFloat(1),
+				"BTC/KRW":  big.NewFloat(65536000),
+			},
+			pair:  connecttypes.NewCurrencyPair("KRW", "USD"),
+			found: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := types.CrossReferencePrice(tt.prices, tt.pair)
+			require.Equal(t, tt.found, found)
+			if tt.found {
+				require.Equal(t, 0, tt.want.Cmp(got), "got %s", got.String())
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -41,6 +42,24 @@ func GetSecret(ctx context.Context, name string) (secretString string, err error
 	return secretString, nil
 }
 
+// OpenFromS3 opens the S3 object of path for reading. The caller must close the returned reader.
+func OpenFromS3(path string, shouldPrefixWithTimestamp bool) (io.ReadCloser, error) {
+	bucket, key, err := getS3Path(path, shouldPrefixWithTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s3Client.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s:%s from S3: %w", bucket, key, err)
+	}
+
+	return result.Body, nil
+}
+
 func ReadFromS3(path string, shouldPrefixWithTimestamp bool) ([]byte, error) {
 	bucket, key, err := getS3Path(path, shouldPrefixWithTimestamp)
 	if err != nil {
@@ -62,6 +81,12 @@ func ReadFromS3(path string, shouldPrefixWithTimestamp bool) ([]byte, error) {
 }
 
 func WriteToS3(path string, bz []byte, shouldPrefixWithTimestamp bool) error {
+	return WriteReaderToS3(path, bytes.NewReader(bz), shouldPrefixWithTimestamp)
+}
+
+// WriteReaderToS3 writes the contents of body to the S3 object of path. body is seekable so that its length is
+// known without reading it into memory, e.g. a file.
+func WriteReaderToS3(path string, body io.ReadSeeker, shouldPrefixWithTimestamp bool) error {
 	bucket, key, err := getS3Path(path, shouldPrefixWithTimestamp)
 	if err != nil {
 		return err
@@ -71,7 +96,7 @@ func WriteToS3(path string, bz []byte, shouldPrefixWithTimestamp bool) error {
 	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
-		Body:   bytes.NewReader(bz),
+		Body:   body,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to write object %s:%s to S3: %w", bucket, key, err)
@@ -3,6 +3,7 @@ package file
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/skip-mev/connect-mmu/lib/aws"
@@ -34,6 +35,48 @@ func WriteBytesToFile(path string, bz []byte) error {
 	return os.WriteFile(path, bz, 0o600)
 }
 
+// OpenFile opens the file at path for reading without reading it into memory. The caller must close the file.
+func OpenFile(path string) (io.ReadCloser, error) {
+	if aws.IsLambda() {
+		// Stream from S3
+		return aws.OpenFromS3(path, true)
+	}
+	// Open local file
+	return os.Open(path)
+}
+
+// CreateFile creates the file at path for writing. In AWS, the file is written to local disk and uploaded to S3
+// when it is closed, so that it is never held in memory. The caller must close the file, and check the error of
+// Close, since writes may only fail then.
+func CreateFile(path string) (io.WriteCloser, error) {
+	if aws.IsLambda() {
+		// Buffer on disk, then upload to S3
+		tmp, err := os.CreateTemp("", "mmu-*")
+		if err != nil {
+			return nil, err
+		}
+		return &s3File{File: tmp, path: path}, nil
+	}
+	// Create local file
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+}
+
+// s3File is a temporary file that is uploaded to S3 when it is closed.
+type s3File struct {
+	*os.File
+	path string
+}
+
+func (f *s3File) Close() error {
+	defer os.Remove(f.Name())
+	defer f.File.Close()
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return aws.WriteReaderToS3(f.path, f.File, true)
+}
+
 func ReadJSONFromFile[T any](path string) (t T, err error) {
 	bz, err := ReadBytesFromFile(path)
 	if err != nil {
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CassetteMode determines whether a CassetteTransport records or replays HTTP interactions.
+type CassetteMode string
+
+const (
+	// CassetteModeRecord performs real requests and saves every response to a cassette file.
+	CassetteModeRecord CassetteMode = "record"
+	// CassetteModeReplay serves responses from cassette files without performing any network requests.
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+// ValidateCassetteMode returns an error if the mode is not a known CassetteMode.
+func ValidateCassetteMode(mode CassetteMode) error {
+	switch mode {
+	case CassetteModeRecord, CassetteModeReplay:
+		return nil
+	default:
+		return fmt.Errorf("invalid cassette mode %q: must be one of %q or %q", mode, CassetteModeRecord, CassetteModeReplay)
+	}
+}
+
+// Cassette is a single recorded HTTP interaction.
+//
+// Request headers are intentionally not recorded so that API keys never end up in cassette files.
+type Cassette struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest identifies the request a Cassette was recorded for.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded response of a Cassette.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+var _ http.RoundTripper = &CassetteTransport{}
+
+// CassetteTransport is an http.RoundTripper that records HTTP interactions to, or replays them from,
+// a directory of cassette files. Each interaction is stored in <dir>/<host>/<key>.json, where the key
+// is derived from the request method, URL, and body.
+type CassetteTransport struct {
+	mode CassetteMode
+	dir  string
+	next http.RoundTripper
+
+	// mu serializes cassette file writes.
+	mu sync.Mutex
+}
+
+// NewCassetteTransport creates a CassetteTransport for the given mode and directory. In record mode,
+// requests are performed with next, or http.DefaultTransport if next is nil.
+func NewCassetteTransport(mode CassetteMode, dir string, next http.RoundTripper) (*CassetteTransport, error) {
+	if err := ValidateCassetteMode(mode); err != nil {
+		return nil, err
+	}
+
+	if dir == "" {
+		return nil, errors.New("cassette directory cannot be empty")
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &CassetteTransport{
+		mode: mode,
+		dir:  dir,
+		next: next,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cassetteReq, err := newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := t.cassettePath(req, cassetteReq)
+
+	if t.mode == CassetteModeReplay {
+		return t.replay(req, cassetteReq, path)
+	}
+
+	return t.record(req, cassetteReq, path)
+}
+
+func (t *CassetteTransport) replay(req *http.Request, cassetteReq CassetteRequest, path string) (*http.Response, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no cassette recorded for %s %s", cassetteReq.Method, cassetteReq.URL)
+		}
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(bz, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassette.Response.StatusCode, http.StatusText(cassette.Response.StatusCode)),
+		StatusCode:    cassette.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cassette.Response.Header,
+		Body:          io.NopCloser(bytes.NewBufferString(cassette.Response.Body)),
+		ContentLength: int64(len(cassette.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *CassetteTransport) record(req *http.Request, cassetteReq CassetteRequest, path string) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cassette := Cassette{
+		Request: cassetteReq,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     recordedHeader(resp.Header),
+			Body:       string(body),
+		},
+	}
+
+	bz, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, bz, 0o600); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *CassetteTransport) cassettePath(req *http.Request, cassetteReq CassetteRequest) string {
+	return filepath.Join(t.dir, req.URL.Hostname(), cassetteKey(cassetteReq)+".json")
+}
+
+// newCassetteRequest reads the request body, restoring it on the request so that it can still be sent.
+func newCassetteRequest(req *http.Request) (CassetteRequest, error) {
+	cassetteReq := CassetteRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return cassetteReq, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return CassetteRequest{}, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	cassetteReq.Body = string(body)
+
+	return cassetteReq, nil
+}
+
+// cassetteKey derives the cassette file name for a request. JSON-RPC request IDs are ignored, since
+// they are generated per request and would otherwise prevent a recorded call from ever matching.
+func cassetteKey(req CassetteRequest) string {
+	body := req.Body
+
+	var rpcRequest map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &rpcRequest); err == nil {
+		if _, ok := rpcRequest["jsonrpc"]; ok {
+			delete(rpcRequest, "id")
+			// map keys are marshalled in sorted order, so this is deterministic.
+			if bz, err := json.Marshal(rpcRequest); err == nil {
+				body = string(bz)
+			}
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL))
+	h.Write([]byte{0})
+	h.Write([]byte(body))
+
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// recordedHeader returns the subset of response headers worth keeping in a cassette.
+func recordedHeader(header http.Header) http.Header {
+	recorded := make(http.Header)
+	for _, key := range []string{"Content-Type", "Retry-After"} {
+		if value := header.Get(key); value != "" {
+			recorded.Set(key, value)
+		}
+	}
+	return recorded
+}
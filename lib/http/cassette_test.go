@@ -0,0 +1,116 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+func TestCassetteTransportRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	calls := 0
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	recorder, err := http.NewCassetteTransport(http.CassetteModeRecord, dir, nil)
+	require.NoError(t, err)
+
+	client := &nethttp.Client{Transport: recorder}
+	resp, err := client.Get(server.URL + "/markets?limit=10")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.JSONEq(t, `{"path":"/markets"}`, string(body))
+	require.Equal(t, 1, calls)
+
+	server.Close()
+
+	replayer, err := http.NewCassetteTransport(http.CassetteModeReplay, dir, nil)
+	require.NoError(t, err)
+
+	client = &nethttp.Client{Transport: replayer}
+	resp, err = client.Get(server.URL + "/markets?limit=10")
+	require.NoError(t, err)
+	replayed, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, nethttp.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.Equal(t, string(body), string(replayed))
+	require.Equal(t, 1, calls)
+
+	_, err = client.Get(server.URL + "/markets?limit=20") //nolint:bodyclose
+	require.ErrorContains(t, err, "no cassette recorded")
+}
+
+func TestCassetteTransportDoesNotRecordRequestHeaders(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder, err := http.NewCassetteTransport(http.CassetteModeRecord, dir, nil)
+	require.NoError(t, err)
+
+	resp, err := http.NewClient(http.WithTransport(recorder)).GetWithContext(context.Background(), server.URL, http.WithHeader("X-CMC_PRO_API_KEY", "secret"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	bz, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(bz), "secret")
+}
+
+func TestCassetteTransportIgnoresJSONRPCIDs(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":42}`))
+	}))
+	defer server.Close()
+
+	recorder, err := http.NewCassetteTransport(http.CassetteModeRecord, dir, nil)
+	require.NoError(t, err)
+
+	resp, err := (&nethttp.Client{Transport: recorder}).Post(server.URL, "application/json",
+		bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"getSlot"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	replayer, err := http.NewCassetteTransport(http.CassetteModeReplay, dir, nil)
+	require.NoError(t, err)
+
+	resp, err = (&nethttp.Client{Transport: replayer}).Post(server.URL, "application/json",
+		bytes.NewBufferString(`{"jsonrpc":"2.0","id":7,"method":"getSlot"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestNewCassetteTransportValidation(t *testing.T) {
+	_, err := http.NewCassetteTransport("rewind", t.TempDir(), nil)
+	require.ErrorContains(t, err, "invalid cassette mode")
+
+	_, err = http.NewCassetteTransport(http.CassetteModeReplay, "", nil)
+	require.ErrorContains(t, err, "cassette directory cannot be empty")
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,16 +10,30 @@ import (
 	"github.com/avast/retry-go/v4"
 )
 
+// MaxRetryAfter is the longest Retry-After that a rate limited request waits for before it is retried.
+const MaxRetryAfter = 5 * time.Minute
+
 // Client is a wrapper around the Go stdlib http client.
 type Client struct {
 	internal *http.Client
 }
 
+// StandardClient returns a stdlib http client configured with the given options. It may be passed to
+// libraries that accept an *http.Client.
+func StandardClient(opts ...ClientOption) *http.Client {
+	o := newClientOptions(opts...)
+
+	return &http.Client{
+		Transport: newOptionsTransport(o.transport, o),
+		Timeout:   o.timeout,
+	}
+}
+
 // NewClient returns a new Client with its internal http client
-// set to the default client.
-func NewClient() *Client {
+// set to the default client, configured with the given options.
+func NewClient(opts ...ClientOption) *Client {
 	return &Client{
-		internal: http.DefaultClient,
+		internal: StandardClient(opts...),
 	}
 }
 
@@ -44,8 +59,19 @@ func WithQueryParam(key, value string) GetOptions {
 	}
 }
 
-// GetWithContext performs a Get request with the context provided.
+// GetWithContext performs a Get request with the context provided. Requests that are rate limited
+// with 429 Too Many Requests are retried.
 func (c *Client) GetWithContext(ctx context.Context, url string, opts ...GetOptions) (*http.Response, error) {
+	return c.get(ctx, url, 20, opts...)
+}
+
+func (c *Client) GetWithContextRetryOnce(ctx context.Context, url string, opts ...GetOptions) (*http.Response, error) {
+	return c.get(ctx, url, 1, opts...)
+}
+
+// get performs a Get request, making up to attempts attempts while it is rate limited. Attempts wait for
+// the Retry-After of the rate limited response, or back off exponentially if it has none.
+func (c *Client) get(ctx context.Context, url string, attempts uint, opts ...GetOptions) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -62,45 +88,46 @@ func (c *Client) GetWithContext(ctx context.Context, url string, opts ...GetOpti
 		}
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			return nil, checkResponseCode(resp)
+			// the body of a discarded response must be closed to free its connection.
+			defer resp.Body.Close()
+			return nil, &tooManyRequestsError{err: checkResponseCode(resp), retryAfter: RetryAfter(resp)}
 		}
 
 		return resp, nil
-	}, retry.Attempts(20), retry.Delay(1*time.Second))
+	}, retry.Attempts(attempts), retry.Delay(1*time.Second), retry.DelayType(retryDelay), retry.Context(ctx))
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 
-	return resp, checkResponseCode(resp)
-}
-
-func (c *Client) GetWithContextRetryOnce(ctx context.Context, url string, opts ...GetOptions) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
+	if err := checkResponseCode(resp); err != nil {
+		// callers don't read the response of a failed request, so its body is closed here to free its
+		// connection and in-flight slot.
+		resp.Body.Close()
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	return resp, nil
+}
 
-	resp, err := retry.DoWithData(func() (*http.Response, error) {
-		resp, err := c.internal.Do(req)
-		if err != nil {
-			return nil, retry.Unrecoverable(err)
-		}
+// tooManyRequestsError is the error of a 429 Too Many Requests response.
+type tooManyRequestsError struct {
+	err        error
+	retryAfter time.Duration
+}
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			return nil, checkResponseCode(resp)
-		}
+func (e *tooManyRequestsError) Error() string {
+	return e.err.Error()
+}
 
-		return resp, nil
-	}, retry.Attempts(1), retry.Delay(1*time.Second))
-	if err != nil {
-		return resp, err
+// retryDelay waits for the Retry-After of a rate limited response, up to MaxRetryAfter. Other errors use
+// the default exponential backoff of retry-go.
+func retryDelay(n uint, err error, config *retry.Config) time.Duration {
+	var tooManyRequests *tooManyRequestsError
+	if errors.As(err, &tooManyRequests) && tooManyRequests.retryAfter > 0 {
+		return min(tooManyRequests.retryAfter, MaxRetryAfter)
 	}
 
-	return resp, checkResponseCode(resp)
+	return retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)(n, err, config)
 }
 
 // checkResponseCode parses the http.Response status code and returns
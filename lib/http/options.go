@@ -0,0 +1,268 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ClientOption configures the http clients created by NewClient and StandardClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	transport   http.RoundTripper
+	baseURL     *url.URL
+	timeout     time.Duration
+	rateLimit   float64
+	burst       int
+	maxInFlight int
+}
+
+func newClientOptions(opts ...ClientOption) clientOptions {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTransport sends requests with the given transport, e.g. a CassetteTransport, instead of the standard
+// library's default transport.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// TransportOf returns the transport set by opts, or nil if none is set. Clients of other hosts use it to share
+// the transport of a client without its base URL or rate limit.
+func TransportOf(opts ...ClientOption) http.RoundTripper {
+	return newClientOptions(opts...).transport
+}
+
+// WithBaseURL sends every request to the scheme and host of baseURL instead of the requested ones. The path
+// of baseURL, if any, is prepended to the requested path. This points a client at a proxy or a local
+// stand-in server without changing the endpoints it requests.
+func WithBaseURL(baseURL *url.URL) ClientOption {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithTimeout bounds every request, including reading its response body, by the given timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRateLimit limits the client to the given number of requests per second to each host, using a token
+// bucket per host. Requests wait for the limit instead of failing.
+func WithRateLimit(requestsPerSecond float64) ClientOption {
+	return func(o *clientOptions) {
+		o.rateLimit = requestsPerSecond
+	}
+}
+
+// WithBurst sets the size of the token buckets of WithRateLimit, i.e. the number of requests a host may
+// receive at once before the rate limit applies. Defaults to 1, which spreads requests evenly.
+func WithBurst(burst int) ClientOption {
+	return func(o *clientOptions) {
+		o.burst = burst
+	}
+}
+
+// WithMaxInFlight limits the number of requests of the client that are in flight at once. A request is in
+// flight until its response body is closed.
+func WithMaxInFlight(maxInFlight int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxInFlight = maxInFlight
+	}
+}
+
+// optionsTransport is a RoundTripper that redirects, rate limits, and bounds the concurrency of requests before
+// passing them to next. Hosts that respond with 429 Too Many Requests and a Retry-After header are not sent
+// further requests until the Retry-After has passed.
+type optionsTransport struct {
+	next    http.RoundTripper
+	baseURL *url.URL
+
+	rateLimit float64
+	burst     int
+	inFlight  chan struct{}
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimit
+}
+
+// hostLimit is the rate limit of a single host.
+type hostLimit struct {
+	// limiter is nil if the client is not rate limited.
+	limiter *rate.Limiter
+	// blockedUntil is the time a Retry-After of the host expires.
+	blockedUntil time.Time
+}
+
+func newOptionsTransport(next http.RoundTripper, o clientOptions) *optionsTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &optionsTransport{
+		next:      next,
+		baseURL:   o.baseURL,
+		rateLimit: o.rateLimit,
+		burst:     max(o.burst, 1),
+		hosts:     make(map[string]*hostLimit),
+	}
+	if o.maxInFlight > 0 {
+		t.inFlight = make(chan struct{}, o.maxInFlight)
+	}
+
+	return t
+}
+
+func (t *optionsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.baseURL != nil {
+		// RoundTrippers must not modify the request, so the redirect is applied to a clone.
+		req = req.Clone(req.Context())
+		req.URL.Scheme = t.baseURL.Scheme
+		req.URL.Host = t.baseURL.Host
+		req.URL.Path = strings.TrimSuffix(t.baseURL.Path, "/") + req.URL.Path
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = strings.TrimSuffix(t.baseURL.EscapedPath(), "/") + req.URL.RawPath
+		}
+		req.Host = ""
+	}
+
+	host := t.host(req.URL.Host)
+	if err := t.wait(req.Context(), host); err != nil {
+		return nil, err
+	}
+
+	release, err := t.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := RetryAfter(resp); retryAfter > 0 {
+			t.block(host, time.Now().Add(retryAfter))
+		}
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// host returns the rate limit of the given host, creating it on first use.
+func (t *optionsTransport) host(name string) *hostLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	host, found := t.hosts[name]
+	if !found {
+		host = &hostLimit{}
+		if t.rateLimit > 0 {
+			host.limiter = rate.NewLimiter(rate.Limit(t.rateLimit), t.burst)
+		}
+		t.hosts[name] = host
+	}
+
+	return host
+}
+
+// wait blocks until the Retry-After of the host has passed and its rate limit allows another request.
+func (t *optionsTransport) wait(ctx context.Context, host *hostLimit) error {
+	t.mu.Lock()
+	blockedUntil := host.blockedUntil
+	t.mu.Unlock()
+
+	if delay := time.Until(blockedUntil); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if host.limiter != nil {
+		return host.limiter.Wait(ctx)
+	}
+
+	return nil
+}
+
+// block stops requests to the host until the given time.
+func (t *optionsTransport) block(host *hostLimit, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until.After(host.blockedUntil) {
+		host.blockedUntil = until
+	}
+}
+
+// acquire waits for an in-flight slot and returns the function that releases it.
+func (t *optionsTransport) acquire(ctx context.Context) (func(), error) {
+	if t.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case t.inFlight <- struct{}{}:
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-t.inFlight })
+	}, nil
+}
+
+// releasingBody is a response body that releases the in-flight slot of its request when it is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// RetryAfter returns the delay requested by the Retry-After header of the response, given either in seconds
+// or as an HTTP date. It returns 0 if the header is missing or invalid.
+func RetryAfter(resp *http.Response) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
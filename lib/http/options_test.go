@@ -0,0 +1,251 @@
+package http_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+func TestClientWithBaseURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	baseURL, err := url.Parse(server.URL + "/proxy/")
+	require.NoError(t, err)
+
+	client := http.NewClient(http.WithBaseURL(baseURL))
+	resp, err := client.GetWithContext(context.Background(), "https://api.example.com/v1/tickers?limit=10")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, []string{"/proxy/v1/tickers?limit=10"}, paths)
+}
+
+// roundTripperFunc is an http.RoundTripper that calls itself.
+type roundTripperFunc func(*nethttp.Request) (*nethttp.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	return f(req)
+}
+
+func TestClientWithTransport(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	var requests []string
+	transport := roundTripperFunc(func(req *nethttp.Request) (*nethttp.Response, error) {
+		requests = append(requests, req.URL.String())
+		return nethttp.DefaultTransport.RoundTrip(req)
+	})
+
+	opts := []http.ClientOption{http.WithTransport(transport), http.WithTimeout(time.Second)}
+	resp, err := http.NewClient(opts...).GetWithContext(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, []string{server.URL}, requests)
+
+	require.NotNil(t, http.TransportOf(opts...))
+	require.Nil(t, http.TransportOf(http.WithTimeout(time.Second)))
+}
+
+func TestClientWithTimeout(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.NewClient(http.WithTimeout(10 * time.Millisecond))
+	_, err := client.GetWithContext(context.Background(), server.URL) //nolint:bodyclose
+	require.Error(t, err)
+}
+
+func TestClientWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.NewClient(http.WithRateLimit(20))
+
+	start := time.Now()
+	for range 3 {
+		resp, err := client.GetWithContext(context.Background(), server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	// the first request is sent immediately, the others 50ms apart.
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestClientRateLimitIsPerHost(t *testing.T) {
+	handler := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusOK)
+	})
+	first := httptest.NewServer(handler)
+	defer first.Close()
+	second := httptest.NewServer(handler)
+	defer second.Close()
+
+	client := http.NewClient(http.WithRateLimit(1))
+
+	// each host has its own token bucket, so neither request waits.
+	start := time.Now()
+	for _, server := range []*httptest.Server{first, second} {
+		resp, err := client.GetWithContext(context.Background(), server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestClientWithBurst(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.NewClient(http.WithRateLimit(1), http.WithBurst(3))
+
+	start := time.Now()
+	for range 3 {
+		resp, err := client.GetWithContext(context.Background(), server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestClientWithMaxInFlight(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			prev := maxRunning.Load()
+			if n <= prev || maxRunning.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.NewClient(http.WithMaxInFlight(2))
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.GetWithContext(context.Background(), server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestClientWithMaxInFlightReleasesFailedRequests(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := http.NewClient(http.WithMaxInFlight(1))
+	for range 3 {
+		resp, err := client.GetWithContext(ctx, server.URL) //nolint:bodyclose
+		require.ErrorContains(t, err, "internal server error")
+		require.Nil(t, resp)
+	}
+}
+
+func TestClientRetriesAfterTooManyRequests(t *testing.T) {
+	var requests []time.Time
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(nethttp.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.NewClient()
+	resp, err := client.GetWithContext(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Len(t, requests, 2)
+	require.GreaterOrEqual(t, requests[1].Sub(requests[0]), time.Second)
+}
+
+func TestClientTooManyRequestsStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(nethttp.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	client := http.NewClient()
+	_, err := client.GetWithContext(ctx, server.URL) //nolint:bodyclose
+	require.Error(t, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	tcs := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "missing", header: "", want: 0},
+		{name: "seconds", header: "120", want: 2 * time.Minute},
+		{name: "negative seconds", header: "-1", want: 0},
+		{name: "past date", header: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+		{name: "invalid", header: "soon", want: 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &nethttp.Response{Header: nethttp.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			require.Equal(t, tc.want, http.RetryAfter(resp))
+		})
+	}
+
+	resp := &nethttp.Response{Header: nethttp.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(nethttp.TimeFormat))
+	require.InDelta(t, time.Hour, http.RetryAfter(resp), float64(2*time.Second))
+}
@@ -0,0 +1,163 @@
+package symbols
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// AliasKindSymbol is the kind of an alias from an exchange symbol to a canonical symbol.
+	AliasKindSymbol = "symbol"
+	// AliasKindWrappedAsset is the kind of an alias from a wrapped asset CMC ID to its native asset CMC ID.
+	AliasKindWrappedAsset = "wrapped_asset"
+)
+
+// AliasUsage describes how many times an alias was applied.
+type AliasUsage struct {
+	Kind      string `json:"kind"`
+	Venue     string `json:"venue,omitempty"`
+	Alias     string `json:"alias"`
+	Canonical string `json:"canonical"`
+	Count     int    `json:"count"`
+}
+
+// SymbolAlias maps a symbol on a venue to its canonical symbol. An alias without a venue applies to every venue.
+type SymbolAlias struct {
+	Venue     string
+	Symbol    string
+	Canonical string
+}
+
+type symbolKey struct {
+	venue  string
+	symbol string
+}
+
+// AliasRegistry resolves exchange symbols and wrapped asset CMC IDs to their canonical assets,
+// and records which aliases were applied. It is safe for concurrent use. A nil AliasRegistry has no aliases.
+type AliasRegistry struct {
+	symbols map[symbolKey]string
+	cmcIDs  map[int64]int64
+
+	mu    sync.Mutex
+	fired map[AliasUsage]int
+}
+
+// NewAliasRegistry creates an AliasRegistry of the given symbol aliases, and wrapped asset aliases from the CMC ID
+// of a wrapped asset to the CMC ID of its native asset.
+func NewAliasRegistry(symbolAliases []SymbolAlias, wrappedAssets map[int64]int64) *AliasRegistry {
+	r := &AliasRegistry{
+		symbols: make(map[symbolKey]string, len(symbolAliases)),
+		cmcIDs:  make(map[int64]int64, len(wrappedAssets)),
+		fired:   make(map[AliasUsage]int),
+	}
+
+	for _, alias := range symbolAliases {
+		key := symbolKey{venue: strings.ToLower(alias.Venue), symbol: strings.ToUpper(alias.Symbol)}
+		r.symbols[key] = strings.ToUpper(alias.Canonical)
+	}
+
+	for id, canonical := range wrappedAssets {
+		r.cmcIDs[id] = canonical
+	}
+
+	return r
+}
+
+// Symbol returns the canonical symbol of the given ticker symbol on a venue. Aliases configured for
+// the venue take precedence over aliases that apply to every venue.
+func (r *AliasRegistry) Symbol(venue, symbol string) string {
+	if r == nil {
+		return symbol
+	}
+
+	venue = strings.ToLower(venue)
+	for _, key := range []symbolKey{{venue: venue, symbol: symbol}, {symbol: symbol}} {
+		if canonical, found := r.symbols[key]; found {
+			r.record(AliasUsage{Kind: AliasKindSymbol, Venue: key.venue, Alias: symbol, Canonical: canonical})
+			return canonical
+		}
+	}
+
+	return symbol
+}
+
+// CMCID returns the CMC ID of the native asset if id is the CMC ID of a wrapped asset, and id otherwise.
+func (r *AliasRegistry) CMCID(id int64) int64 {
+	if r == nil {
+		return id
+	}
+
+	canonical, found := r.cmcIDs[id]
+	if !found {
+		return id
+	}
+
+	r.record(AliasUsage{
+		Kind:      AliasKindWrappedAsset,
+		Alias:     strconv.FormatInt(id, 10),
+		Canonical: strconv.FormatInt(canonical, 10),
+	})
+	return canonical
+}
+
+func (r *AliasRegistry) record(usage AliasUsage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fired[usage]++
+}
+
+// Report returns the aliases that were applied since the registry was created or last reset,
+// sorted by kind, venue and alias.
+func (r *AliasRegistry) Report() []AliasUsage {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := make([]AliasUsage, 0, len(r.fired))
+	for usage, count := range r.fired {
+		usage.Count = count
+		report = append(report, usage)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Kind != report[j].Kind {
+			return report[i].Kind < report[j].Kind
+		}
+		if report[i].Venue != report[j].Venue {
+			return report[i].Venue < report[j].Venue
+		}
+		return report[i].Alias < report[j].Alias
+	})
+
+	return report
+}
+
+// ResetReport clears the record of applied aliases.
+func (r *AliasRegistry) ResetReport() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fired = make(map[AliasUsage]int)
+}
+
+// ToCanonicalTickerString cleans a symbol of the given venue with ToTickerString and resolves it to its
+// canonical symbol with aliases.
+func ToCanonicalTickerString(aliases *AliasRegistry, venue, s string) (string, error) {
+	s, err := ToTickerString(s)
+	if err != nil {
+		return "", err
+	}
+
+	return aliases.Symbol(venue, s), nil
+}
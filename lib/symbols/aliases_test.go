@@ -0,0 +1,99 @@
+package symbols_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+)
+
+func TestAliasRegistry(t *testing.T) {
+	registry := symbols.NewAliasRegistry(
+		[]symbols.SymbolAlias{
+			{Symbol: "WBTC", Canonical: "BTC"},
+			{Venue: "Kraken", Symbol: "xxbt", Canonical: "btc"},
+			{Venue: "kraken", Symbol: "WBTC", Canonical: "WBTC.K"},
+		},
+		map[int64]int64{16116: 5426},
+	)
+
+	tests := []struct {
+		name     string
+		venue    string
+		symbol   string
+		expected string
+	}{
+		{
+			name:     "venue alias",
+			venue:    "kraken",
+			symbol:   "XXBT",
+			expected: "BTC",
+		},
+		{
+			name:     "venue alias for another venue",
+			venue:    "binance",
+			symbol:   "XXBT",
+			expected: "XXBT",
+		},
+		{
+			name:     "global alias",
+			venue:    "binance",
+			symbol:   "WBTC",
+			expected: "BTC",
+		},
+		{
+			name:     "venue alias takes precedence",
+			venue:    "kraken",
+			symbol:   "WBTC",
+			expected: "WBTC.K",
+		},
+		{
+			name:     "no alias",
+			venue:    "kraken",
+			symbol:   "ETH",
+			expected: "ETH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, registry.Symbol(tt.venue, tt.symbol))
+		})
+	}
+
+	require.Equal(t, int64(5426), registry.CMCID(16116))
+	require.Equal(t, int64(1), registry.CMCID(1))
+	require.Equal(t, int64(5426), registry.CMCID(16116))
+
+	require.Equal(t, []symbols.AliasUsage{
+		{Kind: symbols.AliasKindSymbol, Alias: "WBTC", Canonical: "BTC", Count: 1},
+		{Kind: symbols.AliasKindSymbol, Venue: "kraken", Alias: "WBTC", Canonical: "WBTC.K", Count: 1},
+		{Kind: symbols.AliasKindSymbol, Venue: "kraken", Alias: "XXBT", Canonical: "BTC", Count: 1},
+		{Kind: symbols.AliasKindWrappedAsset, Alias: "16116", Canonical: "5426", Count: 2},
+	}, registry.Report())
+
+	registry.ResetReport()
+	require.Empty(t, registry.Report())
+}
+
+func TestToCanonicalTickerString(t *testing.T) {
+	registry := symbols.NewAliasRegistry([]symbols.SymbolAlias{{Venue: "bitfinex", Symbol: "UST", Canonical: "USDT"}}, nil)
+
+	got, err := symbols.ToCanonicalTickerString(registry, "bitfinex", " ust ")
+	require.NoError(t, err)
+	require.Equal(t, "USDT", got)
+
+	got, err = symbols.ToCanonicalTickerString(registry, "kraken", "ust")
+	require.NoError(t, err)
+	require.Equal(t, "UST", got)
+
+	_, err = symbols.ToCanonicalTickerString(registry, "bitfinex", "")
+	require.Error(t, err)
+
+	// a nil registry has no aliases.
+	got, err = symbols.ToCanonicalTickerString(nil, "bitfinex", "UST")
+	require.NoError(t, err)
+	require.Equal(t, "UST", got)
+	require.Empty(t, (*symbols.AliasRegistry)(nil).Report())
+}
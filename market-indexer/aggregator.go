@@ -0,0 +1,243 @@
+package indexer
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/coingecko"
+	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/utils"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// Aggregator is a market data aggregator that identifies the assets of indexed provider markets.
+type Aggregator interface {
+	// Name returns the name of the aggregator.
+	Name() string
+
+	// Associate sets the base and quote asset info IDs of the provider market. It returns false if the
+	// aggregator cannot identify the assets of the market.
+	Associate(ctx context.Context, input provider.CreateProviderMarket) (provider.CreateProviderMarket, bool, error)
+}
+
+var (
+	_ Aggregator = &coinMarketCapAggregator{}
+	_ Aggregator = &coinGeckoAggregator{}
+)
+
+// coinMarketCapAggregator identifies assets with the CoinMarketCap market pairs and assets of the Indexer.
+type coinMarketCapAggregator struct {
+	idx   *Indexer
+	pairs coinmarketcap.ProviderMarketPairs
+}
+
+func (a *coinMarketCapAggregator) Name() string {
+	return VenueCoinMarketCap
+}
+
+func (a *coinMarketCapAggregator) Associate(
+	ctx context.Context,
+	input provider.CreateProviderMarket,
+) (provider.CreateProviderMarket, bool, error) {
+	return a.idx.associateCoinMarketCap(ctx, input, a.pairs)
+}
+
+// coinGeckoAggregator identifies assets with the coins listed on CoinGecko. Assets that are also listed on
+// CoinMarketCap are identified by their existing asset info.
+type coinGeckoAggregator struct {
+	idx   *Indexer
+	coins coingecko.Coins
+
+	// assets maps CoinGecko IDs to their asset info.
+	assets map[string]provider.AssetInfo
+}
+
+func (a *coinGeckoAggregator) Name() string {
+	return VenueCoinGecko
+}
+
+func (a *coinGeckoAggregator) Associate(
+	ctx context.Context,
+	input provider.CreateProviderMarket,
+) (provider.CreateProviderMarket, bool, error) {
+	base, found := a.lookup(input.Create.TargetBase, input.BaseAddress)
+	if !found {
+		a.idx.logger.Debug("failed to find base asset on coingecko", zap.Any("input", input))
+		return input, false, nil
+	}
+
+	quote, found := a.lookup(input.Create.TargetQuote, input.QuoteAddress)
+	if !found {
+		a.idx.logger.Debug("failed to find quote asset on coingecko", zap.Any("input", input))
+		return input, false, nil
+	}
+
+	baseInfo, err := a.findOrCreateAssetInfo(ctx, base)
+	if err != nil {
+		return input, false, err
+	}
+
+	quoteInfo, err := a.findOrCreateAssetInfo(ctx, quote)
+	if err != nil {
+		return input, false, err
+	}
+
+	input.Create.BaseAssetInfoID = baseInfo.ID
+	input.Create.QuoteAssetInfoID = quoteInfo.ID
+
+	return input, true, nil
+}
+
+// lookup finds the coin of an asset by its contract address, or by its symbol if it has no address.
+func (a *coinGeckoAggregator) lookup(symbol, address string) (coingecko.Coin, bool) {
+	if address != "" {
+		return a.coins.LookupByAddress(address)
+	}
+
+	return a.coins.LookupBySymbol(symbol)
+}
+
+// findOrCreateAssetInfo returns the asset info of the coin, creating it if the coin is not known.
+func (a *coinGeckoAggregator) findOrCreateAssetInfo(ctx context.Context, coin coingecko.Coin) (provider.AssetInfo, error) {
+	if info, found := a.assets[coin.ID]; found {
+		return info, nil
+	}
+
+	platforms := make([]string, 0, len(coin.Platforms))
+	for platform, address := range coin.Platforms {
+		if address != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	sort.Strings(platforms)
+
+	multiAddresses := make([][]string, 0, len(platforms))
+	for _, platform := range platforms {
+		assetAddress := utils.AssetAddress{
+			Venue:   platform,
+			Address: coingecko.NormalizeAddress(coin.Platforms[platform]),
+		}
+		multiAddresses = append(multiAddresses, assetAddress.ToArray())
+	}
+
+	info, err := a.idx.providerStore.AddAssetInfo(ctx, provider.CreateAssetInfoParams{
+		Symbol:         coin.Symbol,
+		CoinGeckoRank:  coin.Rank,
+		MultiAddresses: multiAddresses,
+		CoinGeckoID:    coin.ID,
+	})
+	if err != nil {
+		return provider.AssetInfo{}, err
+	}
+
+	a.assets[coin.ID] = info
+	return info, nil
+}
+
+// crossCheck matches the known CoinMarketCap assets to CoinGecko coins by contract address, or by symbol
+// for assets without an address, and records the CoinGecko ID of each matched asset. Addresses whose
+// CoinGecko coin has a different symbol and coins that match several CoinMarketCap IDs are logged.
+func (a *coinGeckoAggregator) crossCheck(ctx context.Context, knownAssets utils.AssetMap) error {
+	// sort the known assets by ID so that cross-checks are identical across runs.
+	infos := make(map[int32]provider.AssetInfo)
+	for _, subMap := range knownAssets {
+		for _, info := range subMap {
+			infos[info.ID] = info
+		}
+	}
+	ids := make([]int32, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	mismatches := 0
+	for _, id := range ids {
+		info := infos[id]
+		coin, found := a.match(info)
+		if !found {
+			continue
+		}
+
+		if other, found := a.assets[coin.ID]; found {
+			mismatches++
+			a.idx.logger.Warn("coingecko id matches several cmc ids",
+				zap.String("coingecko_id", coin.ID),
+				zap.Int64("cmc_id", other.CMCID),
+				zap.Int64("other_cmc_id", info.CMCID),
+			)
+			continue
+		}
+
+		updated, err := a.idx.providerStore.AddAssetInfo(ctx, provider.CreateAssetInfoParams{
+			Symbol:         info.Symbol,
+			CmcID:          info.CMCID,
+			Rank:           info.Rank,
+			MultiAddresses: info.MultiAddresses,
+			CMCTags:        info.CMCTags,
+			CoinGeckoID:    coin.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		a.assets[coin.ID] = updated
+	}
+
+	a.idx.logger.Info("cross-checked coinmarketcap assets with coingecko",
+		zap.Int("assets", len(ids)),
+		zap.Int("matched", len(a.assets)),
+		zap.Int("mismatched", mismatches),
+	)
+
+	return nil
+}
+
+// match returns the CoinGecko coin of a CoinMarketCap asset.
+func (a *coinGeckoAggregator) match(info provider.AssetInfo) (coingecko.Coin, bool) {
+	hasAddress := false
+	for _, array := range info.MultiAddresses {
+		assetAddress := utils.MustAssetAddressFromArray(array)
+		// fiat assets are not listed on CoinGecko.
+		if assetAddress.Venue == VenueFiat {
+			return coingecko.Coin{}, false
+		}
+
+		if assetAddress.Address == "" {
+			continue
+		}
+		hasAddress = true
+
+		coin, found := a.coins.LookupByAddress(assetAddress.Address)
+		if !found {
+			continue
+		}
+
+		if coin.Symbol != info.Symbol {
+			a.idx.logger.Warn("coingecko coin has a different symbol than the cmc asset with the same address",
+				zap.String("address", assetAddress.Address),
+				zap.String("coingecko_id", coin.ID),
+				zap.String("coingecko_symbol", coin.Symbol),
+				zap.Int64("cmc_id", info.CMCID),
+				zap.String("cmc_symbol", info.Symbol),
+			)
+			continue
+		}
+
+		return coin, true
+	}
+
+	// assets with an address are not matched by symbol, as symbols of tokens are not unique.
+	if hasAddress {
+		return coingecko.Coin{}, false
+	}
+
+	coin, found := a.coins.LookupBySymbol(info.Symbol)
+	if !found || coin.Rank == 0 {
+		return coingecko.Coin{}, false
+	}
+
+	return coin, true
+}
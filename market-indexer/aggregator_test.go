@@ -0,0 +1,131 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	cg "github.com/skip-mev/connect-mmu/market-indexer/api/coingecko"
+	cgmocks "github.com/skip-mev/connect-mmu/market-indexer/api/coingecko/mocks"
+	"github.com/skip-mev/connect-mmu/market-indexer/coingecko"
+	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/utils"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	usdcAddress = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	pepeAddress = "0x6982508145454ce325ddbe47a25d4ec3d2311933"
+	fakeAddress = "0x1111111111111111111111111111111111111111"
+)
+
+// newCoinGeckoTestIndexer creates an Indexer with known CoinMarketCap assets and indexed CoinGecko coins.
+func newCoinGeckoTestIndexer(t *testing.T) *Indexer {
+	t.Helper()
+
+	idx := newTestIndexer(t, config.MarketConfig{})
+	idx.providerStore = provider.NewMemoryStore()
+	idx.knownAssets = make(utils.AssetMap)
+
+	for _, create := range []provider.CreateAssetInfoParams{
+		{Symbol: "BTC", CmcID: 1, Rank: 1},
+		{Symbol: "USD", CmcID: 2781, MultiAddresses: [][]string{{VenueFiat, ""}}},
+		{Symbol: "USDC", CmcID: 3408, Rank: 6, MultiAddresses: [][]string{{"Ethereum", usdcAddress}}},
+		// the coin with this address has a different symbol on coingecko.
+		{Symbol: "FAKE", CmcID: 999, Rank: 500, MultiAddresses: [][]string{{"Ethereum", fakeAddress}}},
+	} {
+		info, err := idx.providerStore.AddAssetInfo(context.Background(), create)
+		require.NoError(t, err)
+		idx.knownAssets.AddAssetFromInfo(info)
+	}
+
+	client := cgmocks.NewClient(t)
+	client.On("CoinsList", mock.Anything).Return(cg.CoinsListResponse{
+		{ID: "bitcoin", Symbol: "btc"},
+		{ID: "usd-coin", Symbol: "usdc", Platforms: map[string]string{"ethereum": "0xA0b86991c6218b36c1D19D4a2e9Eb0cE3606eB48"}},
+		{ID: "real-token", Symbol: "real", Platforms: map[string]string{"ethereum": fakeAddress}},
+		{ID: "pepe", Symbol: "pepe", Platforms: map[string]string{"ethereum": pepeAddress}},
+	}, nil)
+	client.On("CoinsMarkets", mock.Anything, 1).Return(cg.CoinsMarketsResponse{
+		{ID: "bitcoin", MarketCapRank: 1},
+		{ID: "usd-coin", MarketCapRank: 6},
+		{ID: "pepe", MarketCapRank: 30},
+	}, nil)
+	idx.cgIndexer = coingecko.NewWithClient(zap.NewNop(), client, 0)
+
+	require.NoError(t, idx.IndexCoinGeckoAssetInfo(context.Background()))
+
+	return idx
+}
+
+func TestIndexCoinGeckoAssetInfo(t *testing.T) {
+	idx := newCoinGeckoTestIndexer(t)
+
+	infos := idx.providerStore.GetCMCIDToAssetInfo(context.Background())
+	require.Equal(t, "bitcoin", infos[1].CoinGeckoID)
+	require.Equal(t, "usd-coin", infos[3408].CoinGeckoID)
+	require.Empty(t, infos[2781].CoinGeckoID)
+	require.Empty(t, infos[999].CoinGeckoID)
+}
+
+func TestAssociateAggregator(t *testing.T) {
+	market := func(base, quote, baseAddress, quoteAddress string) provider.CreateProviderMarket {
+		return provider.CreateProviderMarket{
+			Create: provider.CreateProviderMarketParams{
+				TargetBase:   base,
+				TargetQuote:  quote,
+				ProviderName: "test_api",
+			},
+			BaseAddress:  baseAddress,
+			QuoteAddress: quoteAddress,
+		}
+	}
+
+	inputs := []provider.CreateProviderMarket{
+		// identified by coinmarketcap.
+		market("BTC", "USD", "", ""),
+		// USDC is only known to coinmarketcap by address, so it is identified by coingecko.
+		market("BTC", "USDC", "", ""),
+		// PEPE is only known to coingecko.
+		market("PEPE", "USDC", pepeAddress, usdcAddress),
+		market("DOGE", "USD", "", ""),
+	}
+
+	t.Run("coinmarketcap and coingecko", func(t *testing.T) {
+		idx := newCoinGeckoTestIndexer(t)
+		infos := idx.providerStore.GetCMCIDToAssetInfo(context.Background())
+
+		associated, err := idx.AssociateAggregator(context.Background(), inputs, coinmarketcap.ProviderMarketPairs{})
+		require.NoError(t, err)
+		require.Len(t, associated, 3)
+
+		require.Equal(t, infos[1].ID, associated[0].Create.BaseAssetInfoID)
+		require.Equal(t, infos[2781].ID, associated[0].Create.QuoteAssetInfoID)
+
+		require.Equal(t, infos[1].ID, associated[1].Create.BaseAssetInfoID)
+		require.Equal(t, infos[3408].ID, associated[1].Create.QuoteAssetInfoID)
+
+		pepe := idx.coinGecko.assets["pepe"]
+		require.Zero(t, pepe.CMCID)
+		require.Zero(t, pepe.Rank)
+		require.Equal(t, int64(30), pepe.CoinGeckoRank)
+		require.Equal(t, [][]string{{"ethereum", pepeAddress}}, pepe.MultiAddresses)
+		require.Equal(t, pepe.ID, associated[2].Create.BaseAssetInfoID)
+		require.Equal(t, infos[3408].ID, associated[2].Create.QuoteAssetInfoID)
+	})
+
+	t.Run("coinmarketcap unavailable", func(t *testing.T) {
+		idx := newCoinGeckoTestIndexer(t)
+		idx.cmcUnavailable = true
+
+		associated, err := idx.AssociateAggregator(context.Background(), inputs, coinmarketcap.ProviderMarketPairs{})
+		require.NoError(t, err)
+		require.Len(t, associated, 2)
+		require.Equal(t, "USDC", associated[0].Create.TargetQuote)
+		require.Equal(t, "PEPE", associated[1].Create.TargetBase)
+	})
+}
@@ -0,0 +1,108 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+const (
+	EndpointBase    = "https://api.coingecko.com/api/v3"
+	EndpointBasePro = "https://pro-api.coingecko.com/api/v3"
+
+	PathCoinsList    = "/coins/list"
+	PathCoinsMarkets = "/coins/markets"
+
+	// MarketsPageSize is the maximum number of coins returned in a page of the markets endpoint.
+	MarketsPageSize = 250
+)
+
+var _ Client = &httpClient{}
+
+// Client is an interface for getting data from CoinGecko.
+//
+//go:generate mockery --name Client --filename mock_coingecko_client.go
+type Client interface {
+	// CoinsList gets all coins listed on CoinGecko along with their contract addresses.
+	CoinsList(ctx context.Context) (CoinsListResponse, error)
+
+	// CoinsMarkets gets the given page of coins ordered by market cap, starting at page 1.
+	CoinsMarkets(ctx context.Context, page int) (CoinsMarketsResponse, error)
+}
+
+type httpClient struct {
+	client    *http.Client
+	baseURL   string
+	apiHeader string
+	apiKey    string
+}
+
+// NewHTTPClient creates a Client for the CoinGecko API. If pro is set, the apiKey is used as a
+// pro API key, otherwise it is used as a demo API key if it is not empty.
+func NewHTTPClient(apiKey string, pro bool, opts ...http.ClientOption) Client {
+	if pro {
+		return &httpClient{
+			client:    http.NewClient(opts...),
+			baseURL:   EndpointBasePro,
+			apiHeader: "x-cg-pro-api-key",
+			apiKey:    apiKey,
+		}
+	}
+
+	return &httpClient{
+		client:    http.NewClient(opts...),
+		baseURL:   EndpointBase,
+		apiHeader: "x-cg-demo-api-key",
+		apiKey:    apiKey,
+	}
+}
+
+func (h *httpClient) options(opts ...http.GetOptions) []http.GetOptions {
+	opts = append(opts, http.WithJSONAccept())
+	if h.apiKey != "" {
+		opts = append(opts, http.WithHeader(h.apiHeader, h.apiKey))
+	}
+
+	return opts
+}
+
+// CoinsList gets all coins listed on CoinGecko using the HTTP client.
+func (h *httpClient) CoinsList(ctx context.Context) (CoinsListResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, h.baseURL+PathCoinsList, h.options(
+		http.WithQueryParam("include_platform", "true"),
+	)...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var coins CoinsListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&coins); err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
+
+// CoinsMarkets gets a page of coins ordered by market cap using the HTTP client.
+func (h *httpClient) CoinsMarkets(ctx context.Context, page int) (CoinsMarketsResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, h.baseURL+PathCoinsMarkets, h.options(
+		http.WithQueryParam("vs_currency", "usd"),
+		http.WithQueryParam("order", "market_cap_desc"),
+		http.WithQueryParam("per_page", strconv.Itoa(MarketsPageSize)),
+		http.WithQueryParam("page", strconv.Itoa(page)),
+	)...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var markets CoinsMarketsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&markets); err != nil {
+		return nil, err
+	}
+
+	return markets, nil
+}
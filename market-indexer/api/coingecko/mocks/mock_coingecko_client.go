@@ -0,0 +1,155 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	coingecko "github.com/skip-mev/connect-mmu/market-indexer/api/coingecko"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Client is an autogenerated mock type for the Client type
+type Client struct {
+	mock.Mock
+}
+
+type Client_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Client) EXPECT() *Client_Expecter {
+	return &Client_Expecter{mock: &_m.Mock}
+}
+
+// CoinsList provides a mock function with given fields: ctx
+func (_m *Client) CoinsList(ctx context.Context) (coingecko.CoinsListResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CoinsList")
+	}
+
+	var r0 coingecko.CoinsListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (coingecko.CoinsListResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) coingecko.CoinsListResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(coingecko.CoinsListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CoinsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CoinsList'
+type Client_CoinsList_Call struct {
+	*mock.Call
+}
+
+// CoinsList is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) CoinsList(ctx interface{}) *Client_CoinsList_Call {
+	return &Client_CoinsList_Call{Call: _e.mock.On("CoinsList", ctx)}
+}
+
+func (_c *Client_CoinsList_Call) Run(run func(ctx context.Context)) *Client_CoinsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_CoinsList_Call) Return(_a0 coingecko.CoinsListResponse, _a1 error) *Client_CoinsList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CoinsList_Call) RunAndReturn(run func(context.Context) (coingecko.CoinsListResponse, error)) *Client_CoinsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CoinsMarkets provides a mock function with given fields: ctx, page
+func (_m *Client) CoinsMarkets(ctx context.Context, page int) (coingecko.CoinsMarketsResponse, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CoinsMarkets")
+	}
+
+	var r0 coingecko.CoinsMarketsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (coingecko.CoinsMarketsResponse, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) coingecko.CoinsMarketsResponse); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(coingecko.CoinsMarketsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CoinsMarkets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CoinsMarkets'
+type Client_CoinsMarkets_Call struct {
+	*mock.Call
+}
+
+// CoinsMarkets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - page int
+func (_e *Client_Expecter) CoinsMarkets(ctx interface{}, page interface{}) *Client_CoinsMarkets_Call {
+	return &Client_CoinsMarkets_Call{Call: _e.mock.On("CoinsMarkets", ctx, page)}
+}
+
+func (_c *Client_CoinsMarkets_Call) Run(run func(ctx context.Context, page int)) *Client_CoinsMarkets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Client_CoinsMarkets_Call) Return(_a0 coingecko.CoinsMarketsResponse, _a1 error) *Client_CoinsMarkets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CoinsMarkets_Call) RunAndReturn(run func(context.Context, int) (coingecko.CoinsMarketsResponse, error)) *Client_CoinsMarkets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
+	mock := &Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
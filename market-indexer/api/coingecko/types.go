@@ -0,0 +1,69 @@
+package coingecko
+
+const (
+	Name = "coingecko"
+)
+
+// CoinsListResponse is the payload returned from coingecko using the
+//
+// https://api.coingecko.com/api/v3/coins/list?include_platform=true
+//
+// Query. More documentation can be found here: https://docs.coingecko.com/reference/coins-list
+//
+// Example:
+// [
+//
+//	{
+//	  "id": "usd-coin",
+//	  "symbol": "usdc",
+//	  "name": "USDC",
+//	  "platforms": {
+//	    "ethereum": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+//	    "solana": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+//	  }
+//	}
+//
+// ]
+type CoinsListResponse []CoinData
+
+// CoinData is a single coin listed on CoinGecko.
+type CoinData struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+	// Platforms maps CoinGecko platform IDs to the contract address of the coin on that platform.
+	Platforms map[string]string `json:"platforms"`
+}
+
+// CoinsMarketsResponse is the payload returned from coingecko using the
+//
+// https://api.coingecko.com/api/v3/coins/markets?vs_currency=usd&order=market_cap_desc&per_page=250&page=1
+//
+// Query. More documentation can be found here: https://docs.coingecko.com/reference/coins-markets
+//
+// Example:
+// [
+//
+//	{
+//	  "id": "bitcoin",
+//	  "symbol": "btc",
+//	  "name": "Bitcoin",
+//	  "current_price": 67187.34,
+//	  "market_cap": 1317802988326,
+//	  "market_cap_rank": 1,
+//	  "total_volume": 31260929299
+//	}
+//
+// ]
+type CoinsMarketsResponse []MarketData
+
+// MarketData is the market data of a single coin on CoinGecko.
+type MarketData struct {
+	ID            string  `json:"id"`
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	CurrentPrice  float64 `json:"current_price"`
+	MarketCap     float64 `json:"market_cap"`
+	MarketCapRank int64   `json:"market_cap_rank"`
+	TotalVolume   float64 `json:"total_volume"`
+}
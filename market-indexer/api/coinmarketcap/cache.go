@@ -0,0 +1,237 @@
+package coinmarketcap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+// ErrCacheMiss is returned by an offline cached Client for responses that are not cached.
+var ErrCacheMiss = errors.New("coinmarketcap response not cached")
+
+var _ Client = &cachedClient{}
+
+// cacheEntry is a cached response along with the time it was fetched.
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// cachedClient is a Client that caches the responses of another Client on disk. Each response is stored
+// in <dir>/<endpoint>/<key>.json.
+type cachedClient struct {
+	client Client
+	cfg    config.CoinMarketCapCacheConfig
+}
+
+// NewClient creates a Client from the given config and http client options. If caching is configured, responses
+// are cached on disk.
+func NewClient(cfg config.CoinMarketCapConfig, opts ...http.ClientOption) Client {
+	if cfg.RateLimit > 0 {
+		opts = append(opts, http.WithRateLimit(cfg.RateLimit), http.WithBurst(cfg.Burst))
+	}
+	if cfg.MaxInFlight > 0 {
+		opts = append(opts, http.WithMaxInFlight(cfg.MaxInFlight))
+	}
+
+	client := NewHTTPClient(cfg.APIKey, opts...)
+	if !cfg.Cache.Enabled() {
+		return client
+	}
+
+	return NewCachedClient(client, cfg.Cache)
+}
+
+// NewCachedClient creates a Client that caches the responses of client in the configured directory.
+// TTLs that are not configured use the defaults of their endpoint.
+func NewCachedClient(client Client, cfg config.CoinMarketCapCacheConfig) Client {
+	if cfg.IDMapTTL == 0 {
+		cfg.IDMapTTL = config.DefaultCMCIDMapTTL
+	}
+	if cfg.InfoTTL == 0 {
+		cfg.InfoTTL = config.DefaultCMCInfoTTL
+	}
+	if cfg.MarketsTTL == 0 {
+		cfg.MarketsTTL = config.DefaultCMCMarketsTTL
+	}
+	if cfg.QuotesTTL == 0 {
+		cfg.QuotesTTL = config.DefaultCMCQuotesTTL
+	}
+
+	return &cachedClient{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// cached returns the cached response for the endpoint and key if it is younger than ttl, or the response
+// of fetch otherwise. Responses that fail validate are returned without being cached.
+func cached[T any](
+	c *cachedClient,
+	endpoint, key string,
+	ttl time.Duration,
+	fetch func() (T, error),
+	validate func(T) error,
+) (T, error) {
+	var response T
+
+	path := filepath.Join(c.cfg.Dir, endpoint, key+".json")
+	entry, err := readCacheEntry(path)
+	switch {
+	case err == nil && (c.cfg.Offline || time.Since(entry.FetchedAt) < ttl):
+		if err := json.Unmarshal(entry.Data, &response); err != nil {
+			return response, fmt.Errorf("failed to decode cached response %s: %w", path, err)
+		}
+		return response, nil
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return response, fmt.Errorf("failed to read cached response %s: %w", path, err)
+	case c.cfg.Offline:
+		return response, fmt.Errorf("%w: %s/%s", ErrCacheMiss, endpoint, key)
+	}
+
+	response, err = fetch()
+	if err != nil {
+		return response, err
+	}
+
+	if validate != nil {
+		if err := validate(response); err != nil {
+			return response, nil
+		}
+	}
+
+	if err := writeCacheEntry(path, response); err != nil {
+		return response, fmt.Errorf("failed to cache response %s: %w", path, err)
+	}
+
+	return response, nil
+}
+
+func readCacheEntry(path string) (cacheEntry, error) {
+	var entry cacheEntry
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+
+	err = json.Unmarshal(bz, &entry)
+	return entry, err
+}
+
+// writeCacheEntry writes the response to a temporary file which is then renamed, so that a concurrent
+// reader never sees a partially written entry.
+func writeCacheEntry(path string, response any) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(cacheEntry{
+		FetchedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// idsKey returns the cache key of a list of IDs. Requests for the same IDs in a different order are
+// different requests to CoinMarketCap, so the IDs are not sorted.
+func idsKey(ids []int64) string {
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = strconv.FormatInt(id, 10)
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(strIDs, ",")))
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *cachedClient) CryptoIDMap(ctx context.Context) (CryptoIDMapResponse, error) {
+	return cached(c, "crypto_map", "all", c.cfg.IDMapTTL, func() (CryptoIDMapResponse, error) {
+		return c.client.CryptoIDMap(ctx)
+	}, nil)
+}
+
+func (c *cachedClient) ExchangeIDMap(ctx context.Context) (ExchangeIDMapResponse, error) {
+	return cached(c, "exchange_map", "all", c.cfg.IDMapTTL, func() (ExchangeIDMapResponse, error) {
+		return c.client.ExchangeIDMap(ctx)
+	}, func(r ExchangeIDMapResponse) error { return r.Status.Validate() })
+}
+
+func (c *cachedClient) ExchangeAssets(ctx context.Context, exchange int) (ExchangeAssetsResponse, error) {
+	return cached(c, "exchange_assets", strconv.Itoa(exchange), c.cfg.MarketsTTL, func() (ExchangeAssetsResponse, error) {
+		return c.client.ExchangeAssets(ctx, exchange)
+	}, func(r ExchangeAssetsResponse) error { return r.Status.Validate() })
+}
+
+func (c *cachedClient) ExchangeMarkets(ctx context.Context, exchange int) (ExchangeMarketsResponse, error) {
+	return cached(c, "exchange_markets", strconv.Itoa(exchange), c.cfg.MarketsTTL, func() (ExchangeMarketsResponse, error) {
+		return c.client.ExchangeMarkets(ctx, exchange)
+	}, func(r ExchangeMarketsResponse) error { return r.Status.Validate() })
+}
+
+// DexMarkets responses are validated page by page by the underlying client.
+func (c *cachedClient) DexMarkets(ctx context.Context, networkID int, dexID int) (DexMarketsResponse, error) {
+	key := fmt.Sprintf("%d-%d", networkID, dexID)
+	return cached(c, "dex_markets", key, c.cfg.MarketsTTL, func() (DexMarketsResponse, error) {
+		return c.client.DexMarkets(ctx, networkID, dexID)
+	}, nil)
+}
+
+func (c *cachedClient) FiatMap(ctx context.Context) (FiatResponse, error) {
+	return cached(c, "fiat_map", "all", c.cfg.IDMapTTL, func() (FiatResponse, error) {
+		return c.client.FiatMap(ctx)
+	}, func(r FiatResponse) error { return r.Status.Validate() })
+}
+
+func (c *cachedClient) Quote(ctx context.Context, id int64) (QuoteResponse, error) {
+	return cached(c, "quotes", strconv.FormatInt(id, 10), c.cfg.QuotesTTL, func() (QuoteResponse, error) {
+		return c.client.Quote(ctx, id)
+	}, func(r QuoteResponse) error { return r.Status.Validate() })
+}
+
+func (c *cachedClient) Quotes(ctx context.Context, ids []int64) (QuoteResponse, error) {
+	return cached(c, "quotes", idsKey(ids), c.cfg.QuotesTTL, func() (QuoteResponse, error) {
+		return c.client.Quotes(ctx, ids)
+	}, func(r QuoteResponse) error { return r.Status.Validate() })
+}
+
+func (c *cachedClient) Info(ctx context.Context, ids []int64) (InfoResponse, error) {
+	return cached(c, "info", idsKey(ids), c.cfg.InfoTTL, func() (InfoResponse, error) {
+		return c.client.Info(ctx, ids)
+	}, func(r InfoResponse) error { return r.Status.Validate() })
+}
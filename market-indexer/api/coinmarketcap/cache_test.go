@@ -0,0 +1,98 @@
+package coinmarketcap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap/mocks"
+)
+
+var quotes = coinmarketcap.QuoteResponse{
+	Data: map[string]coinmarketcap.QuoteData{
+		"1": {ID: 1, Symbol: "BTC"},
+	},
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fresh responses are served from the cache", func(t *testing.T) {
+		dir := t.TempDir()
+		client := mocks.NewClient(t)
+		client.On("Quotes", mock.Anything, []int64{1}).Return(quotes, nil).Once()
+
+		cached := coinmarketcap.NewCachedClient(client, config.CoinMarketCapCacheConfig{Dir: dir})
+		for range 2 {
+			resp, err := cached.Quotes(ctx, []int64{1})
+			require.NoError(t, err)
+			require.Equal(t, quotes, resp)
+		}
+
+		// a new client reads the cache written by the previous one.
+		resp, err := coinmarketcap.NewCachedClient(mocks.NewClient(t), config.CoinMarketCapCacheConfig{Dir: dir}).Quotes(ctx, []int64{1})
+		require.NoError(t, err)
+		require.Equal(t, quotes, resp)
+	})
+
+	t.Run("expired responses are refetched", func(t *testing.T) {
+		client := mocks.NewClient(t)
+		client.On("Quotes", mock.Anything, []int64{1}).Return(quotes, nil).Twice()
+
+		cached := coinmarketcap.NewCachedClient(client, config.CoinMarketCapCacheConfig{Dir: t.TempDir(), QuotesTTL: time.Nanosecond})
+		for range 2 {
+			_, err := cached.Quotes(ctx, []int64{1})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("failed responses are not cached", func(t *testing.T) {
+		failed := coinmarketcap.QuoteResponse{Status: coinmarketcap.Status{ErrorCode: 429, ErrorMessage: "rate limited"}}
+		client := mocks.NewClient(t)
+		client.On("Quotes", mock.Anything, []int64{1}).Return(failed, nil).Once()
+		client.On("Quotes", mock.Anything, []int64{1}).Return(quotes, nil).Once()
+		client.On("Quotes", mock.Anything, []int64{2}).Return(coinmarketcap.QuoteResponse{}, errors.New("unavailable")).Once()
+
+		cached := coinmarketcap.NewCachedClient(client, config.CoinMarketCapCacheConfig{Dir: t.TempDir()})
+		resp, err := cached.Quotes(ctx, []int64{1})
+		require.NoError(t, err)
+		require.Equal(t, failed, resp)
+
+		resp, err = cached.Quotes(ctx, []int64{1})
+		require.NoError(t, err)
+		require.Equal(t, quotes, resp)
+
+		_, err = cached.Quotes(ctx, []int64{2})
+		require.Error(t, err)
+	})
+
+	t.Run("offline serves expired responses and fails on misses", func(t *testing.T) {
+		dir := t.TempDir()
+		client := mocks.NewClient(t)
+		client.On("Quotes", mock.Anything, []int64{1}).Return(quotes, nil).Once()
+
+		_, err := coinmarketcap.NewCachedClient(client, config.CoinMarketCapCacheConfig{Dir: dir}).Quotes(ctx, []int64{1})
+		require.NoError(t, err)
+
+		offline := coinmarketcap.NewCachedClient(mocks.NewClient(t), config.CoinMarketCapCacheConfig{
+			Dir:       dir,
+			Offline:   true,
+			QuotesTTL: time.Nanosecond,
+		})
+		resp, err := offline.Quotes(ctx, []int64{1})
+		require.NoError(t, err)
+		require.Equal(t, quotes, resp)
+
+		_, err = offline.Quotes(ctx, []int64{1, 2})
+		require.ErrorIs(t, err, coinmarketcap.ErrCacheMiss)
+
+		_, err = offline.CryptoIDMap(ctx)
+		require.ErrorIs(t, err, coinmarketcap.ErrCacheMiss)
+	})
+}
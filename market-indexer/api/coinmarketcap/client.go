@@ -65,9 +65,9 @@ type httpClient struct {
 	apiKey string
 }
 
-func NewHTTPClient(apiKey string) Client {
+func NewHTTPClient(apiKey string, opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 		apiKey: apiKey,
 	}
 }
@@ -0,0 +1,153 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	cmc_api "github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// Files written by --archive-intermediate-steps, which an Indexer created with NewIndexerFromArchive replays.
+const (
+	DefaultArchiveDir = "tmp"
+
+	ArchiveFileCMCCryptoData  = "cmc_crypto_data.json"
+	ArchiveFileCMCFiatData    = "cmc_fiat_data.json"
+	ArchiveFileCMCMarketPairs = "cmc_market_pairs.json"
+	ArchiveFileCMCQuotes      = "cmc_quotes.json"
+	ArchiveFileCoinGeckoCoins = "coingecko_coins.json"
+	ArchiveFileRun            = "run.json"
+)
+
+// ArchivedRun is the index run that wrote an archive.
+type ArchivedRun struct {
+	// ObservedAt is the observation time of the records of the run. A replay stamps its records with it, so
+	// that it writes the same provider data.
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// ReadArchivedRun reads the index run that wrote the archive in dir.
+func ReadArchivedRun(dir string) (ArchivedRun, error) {
+	path := filepath.Join(dir, ArchiveFileRun)
+	run, err := file.ReadJSONFromFile[ArchivedRun](path)
+	if err != nil {
+		return ArchivedRun{}, fmt.Errorf("failed to read archived file %s: %w", path, err)
+	}
+
+	return run, nil
+}
+
+// ArchiveRun archives the index run if the --archive-intermediate-steps flag is true.
+func (idx *Indexer) ArchiveRun(run ArchivedRun) error {
+	return idx.archiveIntermediateFile(run, ArchiveFileRun)
+}
+
+// ArchiveFileIngesterMarkets returns the name of the file the markets of an ingester are archived in.
+func ArchiveFileIngesterMarkets(ingester string) string {
+	return fmt.Sprintf("ingester_%s_markets.json", ingester)
+}
+
+// ArchiveFileIngesterOrderBookMarkets returns the name of the file the associated markets of an ingester are
+// archived in once their order book depth is set.
+func ArchiveFileIngesterOrderBookMarkets(ingester string) string {
+	return fmt.Sprintf("ingester_%s_order_book_markets.json", ingester)
+}
+
+// NewIndexerFromArchive creates an Indexer that replays the intermediate files archived in dir by a previous
+// run with --archive-intermediate-steps, instead of requesting CoinMarketCap, CoinGecko, and the ingesters.
+// Replaying the archive of a run reproduces its index. httpOpts are applied to the http clients of the Indexer,
+// as in NewIndexer.
+func NewIndexerFromArchive(
+	cfg config.MarketConfig,
+	logger *zap.Logger,
+	writer provider.Store,
+	registry *ingesters.Registry,
+	aliases *symbols.AliasRegistry,
+	dir string,
+	httpOpts ...http.ClientOption,
+) (*Indexer, error) {
+	idx, err := NewIndexer(cfg, logger, writer, registry, aliases, false, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.fromArchive = dir
+
+	// every quote the run used is archived, so any other CoinMarketCap request fails as a cache miss
+	// instead of reaching the network.
+	idx.cmcIndexer = coinmarketcap.NewWithClient(logger, cmc_api.NewCachedClient(nil, config.CoinMarketCapCacheConfig{
+		Dir:     dir,
+		Offline: true,
+	}), registry)
+
+	for i, ig := range idx.igs {
+		idx.igs[i] = &archivedIngester{idx: idx, name: ig.Name()}
+	}
+
+	return idx, nil
+}
+
+var _ ingesters.Ingester = &archivedIngester{}
+
+// archivedIngester is an Ingester that returns the archived markets of an ingester.
+type archivedIngester struct {
+	idx  *Indexer
+	name string
+}
+
+func (ig *archivedIngester) GetProviderMarkets(context.Context) ([]provider.CreateProviderMarket, error) {
+	return readArchiveFile[[]provider.CreateProviderMarket](ig.idx, ArchiveFileIngesterMarkets(ig.name))
+}
+
+func (ig *archivedIngester) Name() string {
+	return ig.name
+}
+
+// loadOrFetch returns the archived data of the file when replaying an archive. Otherwise, it returns the
+// data returned by fetch, archiving it if --archive-intermediate-steps is set.
+func loadOrFetch[T any](idx *Indexer, filename string, fetch func() (T, error)) (T, error) {
+	if idx.fromArchive != "" {
+		return readArchiveFile[T](idx, filename)
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return data, err
+	}
+
+	return data, idx.archiveIntermediateFile(data, filename)
+}
+
+// readArchiveFile reads a file of the archive that is replayed.
+func readArchiveFile[T any](idx *Indexer, filename string) (T, error) {
+	path := filepath.Join(idx.fromArchive, filename)
+	idx.logger.Info("reading archived file", zap.String("path", path))
+
+	data, err := file.ReadJSONFromFile[T](path)
+	if err != nil {
+		return data, fmt.Errorf("failed to read archived file %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// archiveIntermediateFile writes data to a JSON file in the archive directory if the --archive-intermediate-steps
+// flag is true.
+func (idx *Indexer) archiveIntermediateFile(data interface{}, filename string) error {
+	if !idx.archiveIntermediateSteps {
+		return nil
+	}
+
+	return file.CreateAndWriteJSONToFile(filepath.Join(idx.archiveDir, filename), data)
+}
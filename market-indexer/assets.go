@@ -3,53 +3,99 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
-	"github.com/skip-mev/connect-mmu/lib/file"
 	"github.com/skip-mev/connect-mmu/lib/symbols"
 	cmc_api "github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
+	"github.com/skip-mev/connect-mmu/market-indexer/coingecko"
 	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
 	"github.com/skip-mev/connect-mmu/market-indexer/utils"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 const (
-	ValueUnknown = "UNKNOWN"
-	VenueFiat    = "fiat"
+	ValueUnknown       = "UNKNOWN"
+	VenueFiat          = "fiat"
+	VenueCoinMarketCap = "coinmarketcap"
+	VenueCoinGecko     = "coingecko"
 )
 
 // SetupAssets setup up the Indexer database with AssetInfo for all assets it can scrape.
 // These assets are later referenced when ProviderMarkets are indexed as they consist of a pair of two assets.
+//
+// If CoinGecko is enabled, its coins are cross-checked against the CoinMarketCap assets. If CoinMarketCap is
+// unavailable, assets are identified with CoinGecko only.
 func (idx *Indexer) SetupAssets(ctx context.Context) (coinmarketcap.ProviderMarketPairs, error) {
 	// index everything since we have no assets in the db
-	return idx.IndexKnownAssetInfo(ctx)
+	cmcMarketPairs, err := idx.IndexKnownAssetInfo(ctx)
+	if err != nil {
+		if idx.cgIndexer == nil {
+			return coinmarketcap.ProviderMarketPairs{}, err
+		}
+
+		idx.logger.Error("failed to index coinmarketcap assets - falling back to coingecko", zap.Bool("mmu_datadog", true), zap.Error(err))
+		idx.cmcUnavailable = true
+		cmcMarketPairs = coinmarketcap.ProviderMarketPairs{}
+	}
+
+	if idx.cgIndexer == nil {
+		return cmcMarketPairs, nil
+	}
+
+	if err := idx.IndexCoinGeckoAssetInfo(ctx); err != nil {
+		if idx.cmcUnavailable {
+			return coinmarketcap.ProviderMarketPairs{}, err
+		}
+
+		idx.logger.Error("failed to index coingecko assets - continuing with coinmarketcap only", zap.Bool("mmu_datadog", true), zap.Error(err))
+	}
+
+	return cmcMarketPairs, nil
 }
 
-func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.ProviderMarketPairs, error) {
-	// Get CMC crypto map data
-	cmcCryptoData, err := idx.cmcIndexer.CryptoIDMap(ctx)
+// IndexCoinGeckoAssetInfo fetches the coins listed on CoinGecko and records their IDs on the matching known assets.
+func (idx *Indexer) IndexCoinGeckoAssetInfo(ctx context.Context) error {
+	coins, err := loadOrFetch(idx, ArchiveFileCoinGeckoCoins, func() ([]coingecko.Coin, error) {
+		return idx.cgIndexer.FetchCoins(ctx)
+	})
 	if err != nil {
-		return coinmarketcap.ProviderMarketPairs{}, err
+		return err
 	}
 
-	if err := idx.archiveIntermediateFile(cmcCryptoData, "cmc_crypto_data.json"); err != nil {
-		return coinmarketcap.ProviderMarketPairs{}, err
+	aggregator := &coinGeckoAggregator{
+		idx:    idx,
+		coins:  coingecko.NewCoins(coins),
+		assets: make(map[string]provider.AssetInfo),
+	}
+	if err := aggregator.crossCheck(ctx, idx.knownAssets); err != nil {
+		return err
 	}
 
-	// Get CMC fiat map data
-	cmcFiatData, err := idx.cmcIndexer.FiatIDMap(ctx)
+	idx.coinGecko = aggregator
+	return nil
+}
+
+func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.ProviderMarketPairs, error) {
+	// Get CMC crypto map data
+	cmcCryptoData, err := loadOrFetch(idx, ArchiveFileCMCCryptoData, func() (coinmarketcap.CryptoIDMap, error) {
+		return idx.cmcIndexer.CryptoIDMap(ctx)
+	})
 	if err != nil {
 		return coinmarketcap.ProviderMarketPairs{}, err
 	}
 
-	if err := idx.archiveIntermediateFile(cmcFiatData, "cmc_fiat_data.json"); err != nil {
+	// Get CMC fiat map data
+	cmcFiatData, err := loadOrFetch(idx, ArchiveFileCMCFiatData, func() (coinmarketcap.FiatIDMap, error) {
+		return idx.cmcIndexer.FiatIDMap(ctx)
+	})
+	if err != nil {
 		return coinmarketcap.ProviderMarketPairs{}, err
 	}
 
-	// TODO: get other aggregator sources
 	idx.knownAssets = make(utils.AssetMap, len(cmcCryptoData)+len(cmcFiatData))
 
 	// create entries for known non-crypto assets
@@ -80,6 +126,41 @@ func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.Prov
 		idx.knownAssets.AddAssetFromInfo(info)
 	}
 
+	cmcMarketPairs, err := idx.cmcMarketPairs(ctx)
+	if err != nil {
+		return coinmarketcap.ProviderMarketPairs{}, err
+	}
+
+	// check pairs in key order so that asset infos are created in the same order across runs.
+	keys := maps.Keys(cmcMarketPairs.Data)
+	slices.Sort(keys)
+	for _, key := range keys {
+		pair := cmcMarketPairs.Data[key]
+		_, _, err := idx.CheckPair(ctx, pair)
+		if err != nil {
+			idx.logger.Error("error checking pair", zap.Error(err), zap.Any("pair", pair))
+			return coinmarketcap.ProviderMarketPairs{}, err
+		}
+	}
+
+	idx.logger.Info("committing aggregate info tx to db...")
+
+	return cmcMarketPairs, nil
+}
+
+// cmcMarketPairs returns the CoinMarketCap market pairs of the configured ingesters, with the quotes of
+// their assets cached. When replaying an archive, both are read from the archive.
+func (idx *Indexer) cmcMarketPairs(ctx context.Context) (coinmarketcap.ProviderMarketPairs, error) {
+	if idx.fromArchive != "" {
+		quotes, err := readArchiveFile[map[int64]cmc_api.QuoteData](idx, ArchiveFileCMCQuotes)
+		if err != nil {
+			return coinmarketcap.ProviderMarketPairs{}, err
+		}
+		idx.cmcIndexer.SetCachedQuotes(quotes)
+
+		return readArchiveFile[coinmarketcap.ProviderMarketPairs](idx, ArchiveFileCMCMarketPairs)
+	}
+
 	// iterate through market pairs we care about and add any extra info to the DB:
 	cmcMarketPairs, err := idx.cmcIndexer.GetProviderMarketsPairs(ctx, idx.config)
 	if err != nil {
@@ -92,7 +173,9 @@ func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.Prov
 		idSet[pair.CMCInfo.BaseID] = struct{}{}
 		idSet[pair.CMCInfo.QuoteID] = struct{}{}
 	}
+	// sort the IDs so that quote requests are identical across runs.
 	ids := maps.Keys(idSet)
+	slices.Sort(ids)
 	failedQuoteIDs, err := idx.cmcIndexer.CacheQuotes(ctx, ids)
 	if err != nil {
 		return coinmarketcap.ProviderMarketPairs{}, err
@@ -117,33 +200,17 @@ func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.Prov
 		idx.logger.Error("failed to fetch quote(s) for some CMC ID(s), excluding associated market pairs from index results", zap.Bool("mmu_datadog", true), zap.Int64s("failedIDs", failedQuoteIDsKeys), zap.Strings("failedPairs", failedQuotePairs))
 	}
 
-	for _, pair := range cmcMarketPairs.Data {
-		_, _, err := idx.CheckPair(ctx, pair)
-		if err != nil {
-			idx.logger.Error("error checking pair", zap.Error(err), zap.Any("pair", pair))
-			return coinmarketcap.ProviderMarketPairs{}, err
-		}
+	if err := idx.archiveIntermediateFile(idx.cmcIndexer.CachedQuotes(), ArchiveFileCMCQuotes); err != nil {
+		return coinmarketcap.ProviderMarketPairs{}, err
 	}
 
-	idx.logger.Info("committing aggregate info tx to db...")
-
-	if err := idx.archiveIntermediateFile(cmcMarketPairs, "cmc_market_pairs.json"); err != nil {
+	if err := idx.archiveIntermediateFile(cmcMarketPairs, ArchiveFileCMCMarketPairs); err != nil {
 		return coinmarketcap.ProviderMarketPairs{}, err
 	}
 
 	return cmcMarketPairs, nil
 }
 
-// achiveIntermediateFile writes data to a JSON file in the tmp directory if the --archive-intermediate-steps flag is true
-func (idx *Indexer) archiveIntermediateFile(data interface{}, filename string) error {
-	if !idx.archiveIntermediateSteps {
-		return nil
-	}
-
-	filepath := fmt.Sprintf("tmp/%s", filename)
-	return file.CreateAndWriteJSONToFile(filepath, data)
-}
-
 // FiatAssetInfoFromData creates a fiat asset from coinmarketcap data.
 func FiatAssetInfoFromData(data cmc_api.FiatData) provider.CreateAssetInfoParams {
 	assetAddress := utils.AssetAddress{
@@ -223,73 +290,91 @@ func CryptoAssetInfoFromData(data coinmarketcap.WrappedCryptoIDMapData) (provide
 	}, nil
 }
 
+// AssociateCoinMarketCap sets the asset info IDs of the provider markets using CoinMarketCap market pairs and assets.
+// Markets whose assets cannot be identified are dropped.
 func (idx *Indexer) AssociateCoinMarketCap(
 	ctx context.Context,
 	inputs []provider.CreateProviderMarket,
 	providerMarketPairs coinmarketcap.ProviderMarketPairs,
 ) ([]provider.CreateProviderMarket, error) {
-	var err error
 	associatedInputs := make([]provider.CreateProviderMarket, 0, len(inputs))
 	for _, input := range inputs {
-		// check pairs
-		data, found := providerMarketPairs.Data[coinmarketcap.ProviderMarketPairKey(
-			input.Create.ProviderName,
-			input.Create.TargetBase,
-			input.Create.TargetQuote,
-		)]
-		if found && input.BaseAddress == "" { // pair data does use addresses for matching, so do not use for defi
-			idx.logger.Debug("using exchange pair info for CMC info",
-				zap.String("base", input.Create.TargetBase),
-				zap.String("quote", input.Create.TargetQuote),
-				zap.String("provider name", input.Create.ProviderName),
-			)
-
-			input.Create.BaseAssetInfoID, input.Create.QuoteAssetInfoID, err = idx.CheckPair(ctx, data)
-			if err != nil {
-				idx.logger.Debug("failed to check pair info for CMC info")
-				continue
-			}
-
-		} else {
-			idx.logger.Debug("using asset info for CMC info",
-				zap.String("base", input.Create.TargetBase),
-				zap.String("quote", input.Create.TargetQuote),
-				zap.String("provider name", input.Create.ProviderName),
-			)
-
-			// check individual assets if we cannot match a pair
-			info, ok := idx.knownAssets.LookupAssetInfo(input.Create.TargetBase, input.BaseAddress)
-			if !ok {
-				idx.logger.Debug("failed to check known base asset info for CMC info", zap.Any("input", input))
-				continue
-			}
-			input.Create.BaseAssetInfoID = info.ID
-
-			info, ok = idx.knownAssets.LookupAssetInfo(input.Create.TargetQuote, input.QuoteAddress)
-			if !ok {
-				idx.logger.Debug("failed to check known quote asset info for CMC info", zap.Any("input", input))
-				continue
-			}
-			input.Create.QuoteAssetInfoID = info.ID
+		associated, ok, err := idx.associateCoinMarketCap(ctx, input, providerMarketPairs)
+		if err != nil {
+			return nil, err
 		}
 
-		// add pair data to supplement
-		if found {
-			input = addPairDataToCreateProviderMarket(input, data)
-		} else {
-			idx.logger.Debug("failed to find pair data for CMC info",
-				zap.String("base", input.Create.TargetBase),
-				zap.String("quote", input.Create.TargetQuote),
-				zap.String("provider name", input.Create.ProviderName),
-			)
+		if ok {
+			associatedInputs = append(associatedInputs, associated)
 		}
-
-		associatedInputs = append(associatedInputs, input)
 	}
 
 	return associatedInputs, nil
 }
 
+func (idx *Indexer) associateCoinMarketCap(
+	ctx context.Context,
+	input provider.CreateProviderMarket,
+	providerMarketPairs coinmarketcap.ProviderMarketPairs,
+) (provider.CreateProviderMarket, bool, error) {
+	var err error
+
+	// check pairs
+	data, found := providerMarketPairs.Data[coinmarketcap.ProviderMarketPairKey(
+		input.Create.ProviderName,
+		input.Create.TargetBase,
+		input.Create.TargetQuote,
+	)]
+	if found && input.BaseAddress == "" { // pair data does use addresses for matching, so do not use for defi
+		idx.logger.Debug("using exchange pair info for CMC info",
+			zap.String("base", input.Create.TargetBase),
+			zap.String("quote", input.Create.TargetQuote),
+			zap.String("provider name", input.Create.ProviderName),
+		)
+
+		input.Create.BaseAssetInfoID, input.Create.QuoteAssetInfoID, err = idx.CheckPair(ctx, data)
+		if err != nil {
+			idx.logger.Debug("failed to check pair info for CMC info")
+			return input, false, nil
+		}
+
+	} else {
+		idx.logger.Debug("using asset info for CMC info",
+			zap.String("base", input.Create.TargetBase),
+			zap.String("quote", input.Create.TargetQuote),
+			zap.String("provider name", input.Create.ProviderName),
+		)
+
+		// check individual assets if we cannot match a pair
+		info, ok := idx.knownAssets.LookupAssetInfo(input.Create.TargetBase, input.BaseAddress)
+		if !ok {
+			idx.logger.Debug("failed to check known base asset info for CMC info", zap.Any("input", input))
+			return input, false, nil
+		}
+		input.Create.BaseAssetInfoID = info.ID
+
+		info, ok = idx.knownAssets.LookupAssetInfo(input.Create.TargetQuote, input.QuoteAddress)
+		if !ok {
+			idx.logger.Debug("failed to check known quote asset info for CMC info", zap.Any("input", input))
+			return input, false, nil
+		}
+		input.Create.QuoteAssetInfoID = info.ID
+	}
+
+	// add pair data to supplement
+	if found {
+		input = addPairDataToCreateProviderMarket(input, data)
+	} else {
+		idx.logger.Debug("failed to find pair data for CMC info",
+			zap.String("base", input.Create.TargetBase),
+			zap.String("quote", input.Create.TargetQuote),
+			zap.String("provider name", input.Create.ProviderName),
+		)
+	}
+
+	return input, true, nil
+}
+
 func addPairDataToCreateProviderMarket(
 	create provider.CreateProviderMarket,
 	data coinmarketcap.ProviderMarketData,
@@ -0,0 +1,193 @@
+package coingecko
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	cg "github.com/skip-mev/connect-mmu/market-indexer/api/coingecko"
+)
+
+type Indexer struct {
+	logger *zap.Logger
+
+	client    cg.Client
+	rankPages int
+}
+
+// New creates a new coingecko Indexer from the given config and http client options.
+func New(logger *zap.Logger, cfg config.CoinGeckoConfig, opts ...http.ClientOption) *Indexer {
+	return NewWithClient(logger, cg.NewHTTPClient(cfg.APIKey, cfg.Pro, opts...), cfg.RankPages)
+}
+
+// NewWithClient creates a new coingecko Indexer. rankPages is the number of pages of the market cap
+// ranking that are fetched, if set to 0, config.DefaultCoinGeckoRankPages is used.
+func NewWithClient(logger *zap.Logger, client cg.Client, rankPages int) *Indexer {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	if rankPages == 0 {
+		rankPages = config.DefaultCoinGeckoRankPages
+	}
+
+	return &Indexer{
+		logger:    logger.With(zap.String("indexer", cg.Name)),
+		client:    client,
+		rankPages: rankPages,
+	}
+}
+
+// Coin is a coin listed on CoinGecko.
+type Coin struct {
+	ID string `json:"id"`
+	// Symbol is the coin's symbol as a ticker string.
+	Symbol string `json:"symbol"`
+	// Rank is the market cap rank of the coin, or 0 if the coin is not in the fetched ranking.
+	Rank int64 `json:"rank,omitempty"`
+	// Platforms maps CoinGecko platform IDs to the contract address of the coin on that platform.
+	Platforms map[string]string `json:"platforms,omitempty"`
+}
+
+// better returns true if coin a is ranked better than coin b. Unranked coins rank last.
+func better(a, b Coin) bool {
+	switch {
+	case a.Rank == b.Rank:
+		return a.ID < b.ID
+	case a.Rank == 0:
+		return false
+	case b.Rank == 0:
+		return true
+	default:
+		return a.Rank < b.Rank
+	}
+}
+
+// Coins indexes the coins listed on CoinGecko by symbol and contract address.
+type Coins struct {
+	bySymbol  map[string][]Coin
+	byAddress map[string][]Coin
+}
+
+// NewCoins creates Coins from the given coins. The coins of each symbol and address are ordered by rank.
+func NewCoins(coins []Coin) Coins {
+	c := Coins{
+		bySymbol:  make(map[string][]Coin),
+		byAddress: make(map[string][]Coin),
+	}
+
+	for _, coin := range coins {
+		c.bySymbol[coin.Symbol] = append(c.bySymbol[coin.Symbol], coin)
+		for _, address := range coin.Platforms {
+			if address == "" {
+				continue
+			}
+			key := NormalizeAddress(address)
+			c.byAddress[key] = append(c.byAddress[key], coin)
+		}
+	}
+
+	for _, list := range c.bySymbol {
+		sort.Slice(list, func(i, j int) bool { return better(list[i], list[j]) })
+	}
+	for _, list := range c.byAddress {
+		sort.Slice(list, func(i, j int) bool { return better(list[i], list[j]) })
+	}
+
+	return c
+}
+
+// NormalizeAddress returns the address used to match contract addresses across aggregators. EVM addresses
+// are case-insensitive so they are lowercased, other addresses (e.g. Solana) are case-sensitive.
+func NormalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+
+	return address
+}
+
+// LookupByAddress returns the best ranked coin with the given contract address.
+func (c Coins) LookupByAddress(address string) (Coin, bool) {
+	list := c.byAddress[NormalizeAddress(address)]
+	if len(list) == 0 {
+		return Coin{}, false
+	}
+
+	return list[0], true
+}
+
+// LookupBySymbol returns the best ranked coin with the given symbol. Symbols are not unique on CoinGecko,
+// so an unranked coin is only returned if it is the only coin with the symbol.
+func (c Coins) LookupBySymbol(symbol string) (Coin, bool) {
+	list := c.bySymbol[symbol]
+	switch {
+	case len(list) == 0:
+		return Coin{}, false
+	case list[0].Rank == 0 && len(list) > 1:
+		return Coin{}, false
+	default:
+		return list[0], true
+	}
+}
+
+// Coins fetches all coins listed on CoinGecko along with the configured pages of the market cap ranking.
+func (i *Indexer) Coins(ctx context.Context) (Coins, error) {
+	coins, err := i.FetchCoins(ctx)
+	if err != nil {
+		return Coins{}, err
+	}
+
+	return NewCoins(coins), nil
+}
+
+// FetchCoins fetches all coins listed on CoinGecko, ranked by the configured pages of the market cap ranking.
+func (i *Indexer) FetchCoins(ctx context.Context) ([]Coin, error) {
+	i.logger.Info("fetching coins")
+
+	list, err := i.client.CoinsList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make(map[string]int64)
+	for page := 1; page <= i.rankPages; page++ {
+		markets, err := i.client.CoinsMarkets(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, market := range markets {
+			ranks[market.ID] = market.MarketCapRank
+		}
+
+		if len(markets) < cg.MarketsPageSize {
+			break
+		}
+	}
+
+	coins := make([]Coin, 0, len(list))
+	for _, data := range list {
+		symbol, err := symbols.ToTickerString(data.Symbol)
+		if err != nil {
+			i.logger.Debug("unable to convert coin symbol to ticker string", zap.String("id", data.ID), zap.Error(err))
+			continue
+		}
+
+		coins = append(coins, Coin{
+			ID:        data.ID,
+			Symbol:    symbol,
+			Rank:      ranks[data.ID],
+			Platforms: data.Platforms,
+		})
+	}
+
+	i.logger.Info("fetched coins", zap.Int("coins", len(coins)), zap.Int("ranked", len(ranks)))
+
+	return coins, nil
+}
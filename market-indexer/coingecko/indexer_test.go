@@ -0,0 +1,91 @@
+package coingecko_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	cg "github.com/skip-mev/connect-mmu/market-indexer/api/coingecko"
+	"github.com/skip-mev/connect-mmu/market-indexer/api/coingecko/mocks"
+	"github.com/skip-mev/connect-mmu/market-indexer/coingecko"
+)
+
+func TestIndexerCoins(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("CoinsList", mock.Anything).Return(cg.CoinsListResponse{
+		{ID: "usd-coin", Symbol: "usdc", Platforms: map[string]string{"ethereum": "0xA0b86991c6218b36c1D19D4a2e9Eb0cE3606eB48"}},
+		{ID: "bridged-usdc", Symbol: "usdc", Platforms: map[string]string{"ethereum": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"}},
+		{ID: "pepe", Symbol: "pepe"},
+		{ID: "pepe-2", Symbol: "pepe"},
+		{ID: "solo", Symbol: "solo"},
+	}, nil)
+
+	// a full first page, so the second page is fetched too.
+	page := make(cg.CoinsMarketsResponse, cg.MarketsPageSize)
+	for i := range page {
+		page[i] = cg.MarketData{ID: fmt.Sprintf("coin-%d", i), MarketCapRank: int64(i + 1)}
+	}
+	page[5] = cg.MarketData{ID: "usd-coin", MarketCapRank: 6}
+	client.On("CoinsMarkets", mock.Anything, 1).Return(page, nil).Once()
+	client.On("CoinsMarkets", mock.Anything, 2).Return(cg.CoinsMarketsResponse{
+		{ID: "bridged-usdc", MarketCapRank: 300},
+	}, nil).Once()
+
+	coins, err := coingecko.NewWithClient(zap.NewNop(), client, 3).Coins(context.Background())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		lookup func() (coingecko.Coin, bool)
+		id     string
+		found  bool
+	}{
+		{
+			name: "address lookup returns the best ranked coin",
+			lookup: func() (coingecko.Coin, bool) {
+				return coins.LookupByAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+			},
+			id:    "usd-coin",
+			found: true,
+		},
+		{
+			name: "evm addresses are case-insensitive",
+			lookup: func() (coingecko.Coin, bool) {
+				return coins.LookupByAddress("0xA0B86991C6218B36C1D19D4A2E9EB0CE3606EB48")
+			},
+			id:    "usd-coin",
+			found: true,
+		},
+		{
+			name:   "symbol lookup returns the best ranked coin",
+			lookup: func() (coingecko.Coin, bool) { return coins.LookupBySymbol("USDC") },
+			id:     "usd-coin",
+			found:  true,
+		},
+		{
+			name:   "ambiguous unranked symbols are not returned",
+			lookup: func() (coingecko.Coin, bool) { return coins.LookupBySymbol("PEPE") },
+		},
+		{
+			name:   "unique unranked symbol",
+			lookup: func() (coingecko.Coin, bool) { return coins.LookupBySymbol("SOLO") },
+			id:     "solo",
+			found:  true,
+		},
+		{
+			name:   "unknown address",
+			lookup: func() (coingecko.Coin, bool) { return coins.LookupByAddress("0xdead") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coin, found := tt.lookup()
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.id, coin.ID)
+		})
+	}
+}
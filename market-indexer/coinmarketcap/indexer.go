@@ -6,6 +6,7 @@ import (
 	"maps"
 	"strconv"
 
+	"github.com/skip-mev/connect-mmu/lib/http"
 	skipslices "github.com/skip-mev/connect-mmu/lib/slices"
 	"github.com/skip-mev/connect-mmu/lib/symbols"
 
@@ -13,12 +14,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/config"
 	cmc "github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/coinbase"
-	crypto_com "github.com/skip-mev/connect-mmu/market-indexer/ingesters/crypto.com"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gecko"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/names"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/types"
 )
 
@@ -31,26 +27,30 @@ type Indexer struct {
 	logger *zap.Logger
 
 	client   cmc.Client
+	registry *ingesters.Registry
 	quotes   map[int64]cmc.QuoteData
 	cmcIDMap map[int]cmc.CryptoIDMapData
 }
 
-// New creates a new coinmarketcap Indexer.
-func New(logger *zap.Logger, apiKey string) *Indexer {
+// New creates a new coinmarketcap Indexer. The registry is used to resolve
+// configured ingesters to their CoinMarketCap exchanges, and the client is created with the given http client
+// options. Responses are cached on disk if the config enables it.
+func New(logger *zap.Logger, cfg config.CoinMarketCapConfig, registry *ingesters.Registry, opts ...http.ClientOption) *Indexer {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Indexer{
 		logger:   logger.With(zap.String("indexer", cmc.Name)),
-		client:   cmc.NewHTTPClient(apiKey),
+		client:   cmc.NewClient(cfg, opts...),
+		registry: registry,
 		quotes:   make(map[int64]cmc.QuoteData),
 		cmcIDMap: make(map[int]cmc.CryptoIDMapData),
 	}
 }
 
 // NewWithClient creates a new coinmarketcap Indexer.
-func NewWithClient(logger *zap.Logger, client cmc.Client) *Indexer {
+func NewWithClient(logger *zap.Logger, client cmc.Client, registry *ingesters.Registry) *Indexer {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
@@ -58,6 +58,7 @@ func NewWithClient(logger *zap.Logger, client cmc.Client) *Indexer {
 	return &Indexer{
 		logger:   logger.With(zap.String("ingester", cmc.Name)),
 		client:   client,
+		registry: registry,
 		quotes:   make(map[int64]cmc.QuoteData),
 		cmcIDMap: make(map[int]cmc.CryptoIDMapData),
 	}
@@ -164,6 +165,16 @@ func (i *Indexer) CacheQuotes(ctx context.Context, ids []int64) (map[int64]struc
 	return failedQuoteIDs, nil
 }
 
+// CachedQuotes returns the quotes cached by CacheQuotes.
+func (i *Indexer) CachedQuotes() map[int64]cmc.QuoteData {
+	return i.quotes
+}
+
+// SetCachedQuotes replaces the cached quotes, e.g. with quotes read from an archive.
+func (i *Indexer) SetCachedQuotes(quotes map[int64]cmc.QuoteData) {
+	i.quotes = quotes
+}
+
 // Quotes fetches the QuoteData for the given CMC IDs and returns them as a map.
 // If a desired ID is not returned, we fall back to individually fetch the data for the ID.
 // If that fails, it usually indicates that the CMC ID is invalid, so we add the ID to a set of failed quotes to be returned and logged for monitoring.
@@ -288,7 +299,7 @@ func (i *Indexer) GetProviderMarketsPairs(ctx context.Context, cfg config.Market
 			}
 
 			key := ProviderMarketPairKey(
-				names.GetProviderName(name),
+				i.registry.ProviderName(name),
 				pair.MarketPairBase.ExchangeSymbol,
 				pair.MarketPairQuote.ExchangeSymbol,
 			)
@@ -365,44 +376,18 @@ func (i *Indexer) getIngesterMapping(ctx context.Context, cfg config.MarketConfi
 	}
 
 	for _, ingester := range cfg.Ingesters {
-		if ingester.Name == gecko.Name {
-			for _, pair := range cfg.GeckoNetworkDexPairs {
-				name := resolveIngesterNameAliases(pair.Dex)
-				err := addNameToMap(name, pair.Dex)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			continue
+		registration, found := i.registry.Get(ingester.Name)
+		if !found {
+			return nil, fmt.Errorf("ingester %s is not registered", ingester.Name)
 		}
 
-		name := resolveIngesterNameAliases(ingester.Name)
-		err := addNameToMap(name, ingester.Name)
-		if err != nil {
-			return nil, err
+		for _, venue := range registration.GetVenues(cfg) {
+			err := addNameToMap(venue.CMCExchangeSlug, venue.Name)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return ingesterNameToID, nil
 }
-
-// resolveIngesterNameAliases resolves any sauron ingester names to their corresponding
-// slug names on cointmarketcap.
-func resolveIngesterNameAliases(ingesterName string) string {
-	switch ingesterName {
-	case coinbase.Name:
-		return "coinbase-exchange"
-	case crypto_com.Name:
-		return "crypto-com-exchange"
-	case gate.Name:
-		return "gate"
-	case huobi.Name:
-		return "htx"
-	case "uniswap_v3":
-		return "uniswap-v3"
-
-	default:
-		return ingesterName
-	}
-}
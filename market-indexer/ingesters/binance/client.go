@@ -3,12 +3,18 @@ package binance
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 
 	"github.com/skip-mev/connect-mmu/lib/http"
 )
 
 const (
-	EndpointTickers = "https://api.binance.com/api/v3/ticker/24hr"
+	EndpointTickers   = "https://api.binance.com/api/v3/ticker/24hr"
+	EndpointOrderBook = "https://api.binance.com/api/v3/depth"
+
+	// orderBookLimit is the number of levels fetched per side of an order book.
+	orderBookLimit = 1000
 )
 
 var _ Client = &httpClient{}
@@ -19,15 +25,18 @@ var _ Client = &httpClient{}
 type Client interface {
 	// Tickers gets all tickers from Binance.
 	Tickers(ctx context.Context) ([]TickerData, error)
+
+	// OrderBook gets the order book of a symbol from Binance.
+	OrderBook(ctx context.Context, symbol string) (OrderBookData, error)
 }
 
 type httpClient struct {
 	client *http.Client
 }
 
-func NewHTTPClient() Client {
+func NewHTTPClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -46,3 +55,20 @@ func (h *httpClient) Tickers(ctx context.Context) ([]TickerData, error) {
 
 	return tickers, nil
 }
+
+// OrderBook gets the order book of a symbol from Binance using the HTTP client.
+func (h *httpClient) OrderBook(ctx context.Context, symbol string) (OrderBookData, error) {
+	endpoint := fmt.Sprintf("%s?symbol=%s&limit=%d", EndpointOrderBook, url.QueryEscape(symbol), orderBookLimit)
+	resp, err := h.client.GetWithContext(ctx, endpoint)
+	if err != nil {
+		return OrderBookData{}, err
+	}
+	defer resp.Body.Close()
+
+	var book OrderBookData
+	if err := json.NewDecoder(resp.Body).Decode(&book); err != nil {
+		return OrderBookData{}, err
+	}
+
+	return book, nil
+}
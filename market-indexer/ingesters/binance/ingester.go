@@ -2,10 +2,12 @@ package binance
 
 import (
 	"context"
-	"strings"
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -16,36 +18,60 @@ const (
 	ProviderName = Name + types.ProviderNameSuffixWS
 )
 
-var _ ingesters.Ingester = &Ingester{}
+var _ ingesters.OrderBookIngester = &Ingester{}
 
 // Ingester is the binance implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new binance Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPClient(opts...),
 	}
 }
 
 // NewWithClient creates a new binance Ingester.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Binance ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -63,15 +89,17 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 		if ticker.FirstID == -1 && ticker.LastID == -1 {
 			continue
 		}
-		// Skip tickers that start with "1000" (like 1000SATSUSDT) or "1M" (like 1MBABYDOGEUSDT) bc the price will be off from other providers
-		// TODO: Remove this when sidecar supports adjusting the price for these binance markets
-		if strings.HasPrefix(ticker.Symbol, "1000") || strings.HasPrefix(ticker.Symbol, "1M") {
+
+		pm, err := ticker.toProviderMarket(i.aliases)
+		if err != nil {
+			i.logger.Error("failed to convert ticker to providerMarket", zap.Error(err), zap.String("ingester", Name), zap.Any("ticker", ticker))
 			continue
 		}
 
-		pm, err := ticker.toProviderMarket()
+		// tickers like 1000SATSUSDT or 1MBABYDOGEUSDT trade a multiple of the underlying asset.
+		pm, err = types.UnscaleProviderMarket(pm)
 		if err != nil {
-			i.logger.Error("failed to convert ticker to providerMarket", zap.Error(err), zap.String("ingester", Name), zap.Any("ticker", ticker))
+			i.logger.Error("failed to unscale providerMarket", zap.Error(err), zap.String("ingester", Name), zap.Any("ticker", ticker))
 			continue
 		}
 
@@ -85,3 +113,13 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 func (i *Ingester) Name() string {
 	return Name
 }
+
+// OrderBook returns a snapshot of the order book of the given market.
+func (i *Ingester) OrderBook(ctx context.Context, market provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	book, err := i.client.OrderBook(ctx, market.Create.OffChainTicker)
+	if err != nil {
+		return ingesters.OrderBook{}, err
+	}
+
+	return ingesters.ParseOrderBook(book.Bids, book.Asks)
+}
@@ -3,20 +3,25 @@ package binance_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if binance ingester's products endpoint returns an error, the
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := binance.NewWithClient(zap.NewNop(), client)
+	ingester := binance.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return(nil, fmt.Errorf("error"))
@@ -27,7 +32,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 
 func TestIngesterIgnoresSpunDownMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := binance.NewWithClient(zap.NewNop(), client)
+	ingester := binance.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 
@@ -66,7 +71,7 @@ func TestIngesterIgnoresSpunDownMarkets(t *testing.T) {
 // Test that the ingester only returns markets that are Trading.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := binance.NewWithClient(zap.NewNop(), client)
+	ingester := binance.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 
@@ -88,7 +93,7 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 			QuoteVolume: "50000",
 			LastPrice:   "12.55",
 		},
-		{ // skipped because starts with "1M"
+		{ // scaled by 1,000,000
 			Symbol:      "1MBABYDOGEUSDT",
 			HighPrice:   "",
 			LowPrice:    "",
@@ -96,13 +101,13 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 			QuoteVolume: "50000",
 			LastPrice:   "12.55",
 		},
-		{ // skipped because starts with "1000"
+		{ // scaled by 1,000
 			Symbol:      "1000CATUSDT",
 			HighPrice:   "",
 			LowPrice:    "",
 			Volume:      "",
 			QuoteVolume: "50000",
-			LastPrice:   "12.55",
+			LastPrice:   "0.05",
 		},
 	}, nil)
 
@@ -111,14 +116,80 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 2)
+	require.Len(t, markets, 4)
 	require.Equal(t, "BTC", markets[0].Create.TargetBase)
 	require.Equal(t, "USDT", markets[0].Create.TargetQuote)
 	require.Equal(t, float64(100000), markets[0].Create.QuoteVolume)
 	require.Equal(t, 12.32, markets[0].Create.ReferencePrice)
+	require.Empty(t, markets[0].Create.MetadataJSON)
 
 	require.Equal(t, "ETH", markets[1].Create.TargetBase)
 	require.Equal(t, "USDT", markets[1].Create.TargetQuote)
 	require.Equal(t, float64(50000), markets[1].Create.QuoteVolume)
 	require.Equal(t, 12.55, markets[1].Create.ReferencePrice)
+
+	// scaled markets are indexed on the underlying asset with the multiplier in their metadata.
+	require.Equal(t, "BABYDOGE", markets[2].Create.TargetBase)
+	require.Equal(t, "1MBABYDOGEUSDT", markets[2].Create.OffChainTicker)
+	require.Equal(t, float64(50000), markets[2].Create.QuoteVolume)
+	require.InDelta(t, 0.00001255, markets[2].Create.ReferencePrice, 1e-15)
+	require.JSONEq(t, `{"multiplier":1000000,"scaled_base":"1MBABYDOGE"}`, string(markets[2].Create.MetadataJSON))
+
+	require.Equal(t, "CAT", markets[3].Create.TargetBase)
+	require.Equal(t, "1000CATUSDT", markets[3].Create.OffChainTicker)
+	require.InDelta(t, 0.00005, markets[3].Create.ReferencePrice, 1e-15)
+	require.JSONEq(t, `{"multiplier":1000,"scaled_base":"1000CAT"}`, string(markets[3].Create.MetadataJSON))
+}
+
+func TestIngesterBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/ticker/24hr", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","quoteVolume":"100000","lastPrice":"65000.5"}]`))
+	}))
+	defer server.Close()
+
+	registry := ingesters.NewRegistry()
+	require.NoError(t, registry.RegisterIngester(binance.Registration()))
+
+	ingester, err := registry.CreateIngester(zap.NewNop(), binance.Name, config.MarketConfig{
+		Ingesters: []config.IngesterConfig{
+			{Name: binance.Name, Options: map[string]any{"base_url": server.URL, "request_timeout": "5s"}},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	markets, err := ingester.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	require.Equal(t, "BTCUSDT", markets[0].Create.OffChainTicker)
+	require.Equal(t, 65000.5, markets[0].Create.ReferencePrice)
+}
+
+func TestIngesterOrderBook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/depth", r.URL.Path)
+		require.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		_, _ = w.Write([]byte(`{"lastUpdateId":1,"bids":[["64000","1.5"],["63000","2"]],"asks":[["64001","0.5"]]}`))
+	}))
+	defer server.Close()
+
+	registry := ingesters.NewRegistry()
+	require.NoError(t, registry.RegisterIngester(binance.Registration()))
+
+	ingester, err := registry.CreateIngester(zap.NewNop(), binance.Name, config.MarketConfig{
+		Ingesters: []config.IngesterConfig{
+			{Name: binance.Name, Options: map[string]any{"base_url": server.URL}},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	orderBookIngester, ok := ingester.(ingesters.OrderBookIngester)
+	require.True(t, ok)
+
+	book, err := orderBookIngester.OrderBook(context.Background(), provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{OffChainTicker: "BTCUSDT"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ingesters.OrderBookLevel{{Price: 64000, Size: 1.5}, {Price: 63000, Size: 2}}, book.Bids)
+	require.Equal(t, []ingesters.OrderBookLevel{{Price: 64001, Size: 0.5}}, book.Asks)
 }
@@ -23,6 +23,63 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// OrderBook provides a mock function with given fields: ctx, symbol
+func (_m *Client) OrderBook(ctx context.Context, symbol string) (binance.OrderBookData, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderBook")
+	}
+
+	var r0 binance.OrderBookData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (binance.OrderBookData, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) binance.OrderBookData); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Get(0).(binance.OrderBookData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_OrderBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderBook'
+type Client_OrderBook_Call struct {
+	*mock.Call
+}
+
+// OrderBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *Client_Expecter) OrderBook(ctx interface{}, symbol interface{}) *Client_OrderBook_Call {
+	return &Client_OrderBook_Call{Call: _e.mock.On("OrderBook", ctx, symbol)}
+}
+
+func (_c *Client_OrderBook_Call) Run(run func(ctx context.Context, symbol string)) *Client_OrderBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) Return(_a0 binance.OrderBookData, _a1 error) *Client_OrderBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) RunAndReturn(run func(context.Context, string) (binance.OrderBookData, error)) *Client_OrderBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: ctx
 func (_m *Client) Tickers(ctx context.Context) ([]binance.TickerData, error) {
 	ret := _m.Called(ctx)
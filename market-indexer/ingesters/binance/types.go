@@ -57,7 +57,7 @@ type TickerData struct {
 	LastID    int    `json:"lastId"`
 }
 
-func (d *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (d *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	quoteVolume, err := strconv.ParseFloat(d.QuoteVolume, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert quoteVolume: %w", err)
@@ -73,11 +73,11 @@ func (d *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
 		return provider.CreateProviderMarket{}, err
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
@@ -108,3 +108,20 @@ func symbolToBaseQuote(symbol string) (string, string, error) {
 
 	return "", "", fmt.Errorf(`symbol "%s" does not have a known quote`, symbol)
 }
+
+// OrderBookData is the data payload returned from the Binance API
+// for the OrderBook API request.
+//
+// Docs: https://binance-docs.github.io/apidocs/spot/en/#order-book
+//
+// Ex.
+//
+//	{
+//	  "lastUpdateId": 1027024,
+//	  "bids": [["4.00000000", "431.00000000"]],
+//	  "asks": [["4.00000200", "12.00000000"]]
+//	}
+type OrderBookData struct {
+	Bids [][]any `json:"bids"`
+	Asks [][]any `json:"asks"`
+}
@@ -25,9 +25,9 @@ type httpClient struct {
 	client *http.Client
 }
 
-func NewHTTPClient() Client {
+func NewHTTPClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -6,6 +6,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
 	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
@@ -21,32 +23,56 @@ var _ ingesters.Ingester = &Ingester{}
 
 // Ingester is the bitfinex implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new bitfinex Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPClient(opts...),
 	}
 }
 
 // NewWithClient creates a new bitfinex Ingester with the given client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Bitfinex ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -87,12 +113,12 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 			return nil, fmt.Errorf("received non-float64 type in response lastPrice: %v", data[indexLastPrice])
 		}
 
-		targetBase, err := symbols.ToTickerString(replaceAliases(base))
+		targetBase, err := symbols.ToCanonicalTickerString(i.aliases, Name, base)
 		if err != nil {
 			i.logger.Debug("unable to replace base symbol", zap.Error(err))
 			continue
 		}
-		targetQuote, err := symbols.ToTickerString(replaceAliases(quote))
+		targetQuote, err := symbols.ToCanonicalTickerString(i.aliases, Name, quote)
 		if err != nil {
 			i.logger.Debug("unable to replace quote symbol", zap.Error(err))
 			continue
@@ -22,7 +22,7 @@ var (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := bitfinex.NewWithClient(zap.NewNop(), client)
+	ingester := bitfinex.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return(nil, fmt.Errorf("error"))
@@ -34,7 +34,7 @@ func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 // Test that the ingester only returns valid markets that are trading.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := bitfinex.NewWithClient(zap.NewNop(), client)
+	ingester := bitfinex.NewWithClient(zap.NewNop(), nil, client)
 
 	require.NoError(t, json.Unmarshal(rawResp, &respI))
 
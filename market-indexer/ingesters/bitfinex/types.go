@@ -94,18 +94,6 @@ func checkKnownQuotes(symbol string) (string, string, error) {
 	return "", "", fmt.Errorf("invalid symbol %s", symbol)
 }
 
-// replaceAliases replaces symbol aliases known from the Bitfinex API.
-func replaceAliases(symbol string) string {
-	switch symbol {
-	case "UST":
-		return "USDT"
-	case "MNA":
-		return "MANA"
-	default:
-		return symbol
-	}
-}
-
 // getVolume parses volume from data interface response
 // and returns the quote denominated volume.
 func getVolume(data []interface{}) (float64, error) {
@@ -0,0 +1,79 @@
+package bitget
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+const (
+	EndpointSymbols = "https://api.bitget.com/api/v2/spot/public/symbols"
+	EndpointTickers = "https://api.bitget.com/api/v2/spot/market/tickers"
+)
+
+var _ Client = &httpClient{}
+
+// Client is an interface for a client that can interact with
+// the Bitget api
+//
+//go:generate mockery --name Client --filename mock_bitget_client.go
+type Client interface {
+	// Symbols returns all spot symbols on bitget.
+	Symbols(context.Context) (SymbolsResponse, error)
+
+	// Tickers returns all spot tickers on bitget.
+	Tickers(context.Context) (TickersResponse, error)
+}
+
+type httpClient struct {
+	client *http.Client
+}
+
+// NewClient is the default implementation
+// of the Client using HTTP.
+func NewClient(opts ...http.ClientOption) Client {
+	return &httpClient{
+		client: http.NewClient(opts...),
+	}
+}
+
+// Symbols returns all spot symbols on the Bitget API.
+func (h *httpClient) Symbols(ctx context.Context) (SymbolsResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointSymbols)
+	if err != nil {
+		return SymbolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var symbolsResp SymbolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&symbolsResp); err != nil {
+		return SymbolsResponse{}, err
+	}
+
+	if err := symbolsResp.Validate(); err != nil {
+		return SymbolsResponse{}, err
+	}
+
+	return symbolsResp, nil
+}
+
+// Tickers returns all spot tickers on the Bitget API.
+func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointTickers)
+	if err != nil {
+		return TickersResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var tickersResp TickersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tickersResp); err != nil {
+		return TickersResponse{}, err
+	}
+
+	if err := tickersResp.Validate(); err != nil {
+		return TickersResponse{}, err
+	}
+
+	return tickersResp, nil
+}
@@ -0,0 +1,133 @@
+package bitget
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name         = "bitget"
+	ProviderName = Name + types.ProviderNameSuffixWS
+
+	// CMCExchangeSlug is the slug of Bitget on CoinMarketCap.
+	CMCExchangeSlug = "bitget"
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// Ingester is the bitget implementation of a market data Ingester.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	client Client
+}
+
+// New creates a new bitget Ingester.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(opts...),
+	}
+}
+
+// NewWithClient creates a new bitget Ingester with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Bitget ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:            Name,
+		ProviderName:    ProviderName,
+		CMCExchangeSlug: CMCExchangeSlug,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
+	}
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	ig.logger.Info("fetching data")
+
+	symbols, err := ig.client.Symbols(ctx)
+	if err != nil {
+		ig.logger.Error("failed to fetch symbols", zap.Error(err))
+		return nil, err
+	}
+
+	tickers, err := ig.client.Tickers(ctx)
+	if err != nil {
+		ig.logger.Error("failed to fetch tickers", zap.Error(err))
+		return nil, err
+	}
+
+	ig.logger.Info("fetched data", zap.Int("count", len(tickers.Data)))
+
+	pms := make([]provider.CreateProviderMarket, 0, len(symbols.Data))
+	tickerMap := tickers.toMap()
+	for _, data := range symbols.Data {
+		if data.Status != StatusOnline {
+			continue
+		}
+
+		ticker, found := tickerMap[data.Symbol]
+		if !found {
+			return nil, fmt.Errorf("ticker %s not found in ticker map", data.Symbol)
+		}
+
+		pm, err := data.toProviderMarket(ig.aliases, ticker)
+		if err != nil {
+			ig.logger.Warn("ignoring symbol because can not convert to provider market",
+				zap.String("exchange", Name),
+				zap.Any("symbol", data),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		pms = append(pms, pm)
+	}
+
+	ig.logger.Info("creates", zap.Int("count", len(pms)))
+
+	return pms, nil
+}
+
+// Name returns the Ingester's human-readable name.
+func (ig *Ingester) Name() string {
+	return Name
+}
@@ -0,0 +1,116 @@
+package bitget_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitget"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitget/mocks"
+)
+
+// Test that if bitget ingester's symbols endpoint returns an error, the
+// GetProviderMarkets method should return an error.
+func TestIngesterReturnsErrorOnSymbolsError(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bitget.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Symbols", ctx).Return(bitget.SymbolsResponse{}, fmt.Errorf("error"))
+
+	_, err := ingester.GetProviderMarkets(ctx)
+	require.Error(t, err)
+}
+
+// Test that the ingester only returns markets that are online.
+func TestIngesterIgnoresOfflineSymbols(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bitget.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Symbols", ctx).Return(bitget.SymbolsResponse{
+		Response: bitget.Response{
+			Code: bitget.CodeSuccess,
+			Msg:  "success",
+		},
+		Data: []bitget.SymbolData{
+			{
+				Symbol:    "BTCUSDT",
+				BaseCoin:  "BTC",
+				QuoteCoin: "USDT",
+				Status:    bitget.StatusOnline,
+			},
+			{
+				Symbol:    "ETHUSDC",
+				BaseCoin:  "ETH",
+				QuoteCoin: "USDC",
+				Status:    bitget.StatusOnline,
+			},
+			{
+				Symbol:    "LTCUSDT",
+				BaseCoin:  "LTC",
+				QuoteCoin: "USDT",
+				Status:    "halt",
+			},
+		},
+	}, nil)
+
+	client.On("Tickers", ctx).Return(bitget.TickersResponse{
+		Response: bitget.Response{
+			Code: bitget.CodeSuccess,
+			Msg:  "success",
+		},
+		Data: []bitget.TickerData{
+			{
+				Symbol:      "BTCUSDT",
+				LastPr:      "67000.23",
+				QuoteVolume: "3000000",
+				BaseVolume:  "44.7",
+			},
+			{
+				Symbol:      "ETHUSDC",
+				LastPr:      "3200.32",
+				QuoteVolume: "6000000",
+				BaseVolume:  "1875",
+			},
+			{
+				Symbol:      "LTCUSDT",
+				LastPr:      "10.23",
+				QuoteVolume: "34235",
+				BaseVolume:  "3346",
+			},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, markets, 2)
+	require.Equal(t, "BTC", markets[0].Create.TargetBase)
+	require.Equal(t, "USDT", markets[0].Create.TargetQuote)
+	require.Equal(t, "BTCUSDT", markets[0].Create.OffChainTicker)
+	require.Equal(t, bitget.ProviderName, markets[0].Create.ProviderName)
+	require.Equal(t, float64(3000000), markets[0].Create.QuoteVolume)
+	require.Equal(t, 67000.23, markets[0].Create.ReferencePrice)
+
+	require.Equal(t, "ETH", markets[1].Create.TargetBase)
+	require.Equal(t, "USDC", markets[1].Create.TargetQuote)
+	require.Equal(t, float64(6000000), markets[1].Create.QuoteVolume)
+	require.Equal(t, 3200.32, markets[1].Create.ReferencePrice)
+}
+
+func TestIngesterErrorsOnTickersError(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bitget.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Symbols", ctx).Return(bitget.SymbolsResponse{}, nil)
+
+	client.On("Tickers", ctx).Return(bitget.TickersResponse{}, fmt.Errorf("error"))
+
+	_, err := ingester.GetProviderMarkets(ctx)
+	require.Error(t, err)
+}
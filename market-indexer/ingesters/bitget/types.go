@@ -0,0 +1,176 @@
+package bitget
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	// CodeSuccess is the code returned by the bitget API for successful requests.
+	CodeSuccess = "00000"
+
+	// StatusOnline is the status of a symbol that is open for trading.
+	StatusOnline = "online"
+)
+
+// Response is a common shared field for all responses from the
+// bitget API.
+type Response struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// SymbolsResponse is a response to the spot symbols
+// API.
+type SymbolsResponse struct {
+	Response
+	Data []SymbolData `json:"data"`
+}
+
+// Validate checks if the code is valid from the response.
+func (sr *SymbolsResponse) Validate() error {
+	if sr.Code != CodeSuccess {
+		return fmt.Errorf("invalid symbols response: %s", sr.Msg)
+	}
+
+	return nil
+}
+
+// SymbolData is the data payload included in a
+// SymbolsResponse.
+//
+// Docs: https://www.bitget.com/api-doc/spot/market/Get-Symbols
+//
+// Ex.
+//
+//	{
+//	   "code": "00000",
+//	   "msg": "success",
+//	   "requestTime": 1695808949356,
+//	   "data": [
+//	       {
+//	           "symbol": "BTCUSDT",
+//	           "baseCoin": "BTC",
+//	           "quoteCoin": "USDT",
+//	           "minTradeAmount": "0.0001",
+//	           "maxTradeAmount": "10000",
+//	           "takerFeeRate": "0.001",
+//	           "makerFeeRate": "0.001",
+//	           "pricePrecision": "4",
+//	           "quantityPrecision": "8",
+//	           "quotePrecision": "4",
+//	           "minTradeUSDT": "5",
+//	           "status": "online",
+//	           "buyLimitPriceRatio": "0.05",
+//	           "sellLimitPriceRatio": "0.05"
+//	       }
+//	   ]
+//	}
+type SymbolData struct {
+	Symbol    string `json:"symbol"`
+	BaseCoin  string `json:"baseCoin"`
+	QuoteCoin string `json:"quoteCoin"`
+	Status    string `json:"status"`
+}
+
+func (sd *SymbolData) toProviderMarket(aliases *symbols.AliasRegistry, td TickerData) (provider.CreateProviderMarket, error) {
+	quoteVolume, err := strconv.ParseFloat(td.QuoteVolume, 64)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert quoteVolume: %w", err)
+	}
+
+	refPrice, err := strconv.ParseFloat(td.LastPr, 64)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert lastPr: %w", err)
+	}
+
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, sd.BaseCoin)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, sd.QuoteCoin)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:     targetBase,
+			TargetQuote:    targetQuote,
+			OffChainTicker: sd.Symbol,
+			ProviderName:   ProviderName,
+			QuoteVolume:    quoteVolume,
+			ReferencePrice: refPrice,
+		},
+	}
+
+	return pm, pm.ValidateBasic()
+}
+
+// TickersResponse is a response to the spot tickers
+// API.
+type TickersResponse struct {
+	Response
+	Data []TickerData `json:"data"`
+}
+
+// Validate checks if the code is valid from the response.
+func (tr *TickersResponse) Validate() error {
+	if tr.Code != CodeSuccess {
+		return fmt.Errorf("invalid tickers response: %s", tr.Msg)
+	}
+
+	return nil
+}
+
+// TickerData is the data payload included in a
+// TickersResponse.
+//
+// Docs: https://www.bitget.com/api-doc/spot/market/Get-Tickers
+//
+// Ex.
+//
+//	{
+//	   "code": "00000",
+//	   "msg": "success",
+//	   "requestTime": 1695808949356,
+//	   "data": [
+//	       {
+//	           "symbol": "BTCUSDT",
+//	           "high24h": "37775.65",
+//	           "open": "35134.2",
+//	           "low24h": "34413.1",
+//	           "lastPr": "34413.1",
+//	           "quoteVolume": "0",
+//	           "baseVolume": "0",
+//	           "usdtVolume": "0",
+//	           "bidPr": "0",
+//	           "askPr": "0",
+//	           "bidSz": "0.0663",
+//	           "askSz": "0.0119",
+//	           "openUtc": "23856.72",
+//	           "ts": "1625125755277",
+//	           "changeUtc24h": "0.00301",
+//	           "change24h": "0.00069"
+//	       }
+//	   ]
+//	}
+type TickerData struct {
+	Symbol      string `json:"symbol"`
+	LastPr      string `json:"lastPr"`
+	QuoteVolume string `json:"quoteVolume"`
+	BaseVolume  string `json:"baseVolume"`
+}
+
+func (tr *TickersResponse) toMap() map[string]TickerData {
+	m := make(map[string]TickerData, len(tr.Data))
+
+	for _, d := range tr.Data {
+		m[d.Symbol] = d
+	}
+
+	return m
+}
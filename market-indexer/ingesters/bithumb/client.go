@@ -0,0 +1,55 @@
+package bithumb
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+const (
+	EndpointTickers = "https://api.bithumb.com/public/ticker/ALL_" + QuoteKRW
+)
+
+var _ Client = &httpClient{}
+
+// Client is an interface for a client that can interact with
+// the Bithumb api
+//
+//go:generate mockery --name Client --filename mock_bithumb_client.go
+type Client interface {
+	// Tickers returns all KRW tickers on bithumb.
+	Tickers(context.Context) (TickersResponse, error)
+}
+
+type httpClient struct {
+	client *http.Client
+}
+
+// NewClient is the default implementation
+// of the Client using HTTP.
+func NewClient(opts ...http.ClientOption) Client {
+	return &httpClient{
+		client: http.NewClient(opts...),
+	}
+}
+
+// Tickers returns all KRW tickers on the Bithumb API.
+func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointTickers)
+	if err != nil {
+		return TickersResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var tickersResp TickersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tickersResp); err != nil {
+		return TickersResponse{}, err
+	}
+
+	if err := tickersResp.Validate(); err != nil {
+		return TickersResponse{}, err
+	}
+
+	return tickersResp, nil
+}
@@ -0,0 +1,109 @@
+package bithumb
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name         = "bithumb"
+	ProviderName = Name + types.ProviderNameSuffixWS
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// Ingester is the bithumb implementation of a market data Ingester.
+// Only KRW markets are indexed.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	client Client
+}
+
+// New creates a new bithumb Ingester.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(opts...),
+	}
+}
+
+// NewWithClient creates a new bithumb Ingester with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Bithumb ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
+	}
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	resp, err := ig.client.Tickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers, err := resp.Tickers()
+	if err != nil {
+		return nil, err
+	}
+
+	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
+	for _, ticker := range tickers {
+		ig.logger.Debug("parsing", zap.Any("ticker", ticker))
+
+		pm, err := ticker.toProviderMarket(ig.aliases)
+		if err != nil {
+			ig.logger.Error("failed to convert ticker to providerMarket", zap.Error(err))
+			continue
+		}
+
+		pms = append(pms, pm)
+	}
+
+	return pms, nil
+}
+
+// Name returns the Ingester's human-readable name.
+func (ig *Ingester) Name() string {
+	return Name
+}
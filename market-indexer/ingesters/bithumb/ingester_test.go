@@ -0,0 +1,58 @@
+package bithumb_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bithumb"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bithumb/mocks"
+)
+
+func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bithumb.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return(bithumb.TickersResponse{}, fmt.Errorf("error"))
+
+	_, err := ingester.GetProviderMarkets(ctx)
+	require.Error(t, err)
+}
+
+func TestIngesterParsesKRWTickers(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bithumb.NewWithClient(zap.NewNop(), nil, client)
+
+	var resp bithumb.TickersResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"status": "0000",
+		"data": {
+			"XRP": {"closing_price": "750", "acc_trade_value_24H": "30000000000", "units_traded_24H": "40000000"},
+			"BTC": {"closing_price": "91500000", "acc_trade_value_24H": "56739150000", "units_traded_24H": "620.1"},
+			"BAD": {"closing_price": "", "acc_trade_value_24H": "1", "units_traded_24H": "1"},
+			"date": "1729051952000"
+		}
+	}`), &resp))
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return(resp, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, markets, 2)
+	require.Equal(t, "BTC", markets[0].Create.TargetBase)
+	require.Equal(t, "KRW", markets[0].Create.TargetQuote)
+	require.Equal(t, "BTC_KRW", markets[0].Create.OffChainTicker)
+	require.Equal(t, bithumb.ProviderName, markets[0].Create.ProviderName)
+	require.Equal(t, float64(56739150000), markets[0].Create.QuoteVolume)
+	require.Equal(t, float64(91500000), markets[0].Create.ReferencePrice)
+
+	require.Equal(t, "XRP", markets[1].Create.TargetBase)
+	require.Equal(t, "XRP_KRW", markets[1].Create.OffChainTicker)
+}
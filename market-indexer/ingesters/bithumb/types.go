@@ -0,0 +1,129 @@
+package bithumb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	// QuoteKRW is the only quote currency indexed from bithumb.
+	QuoteKRW = "KRW"
+
+	// StatusSuccess is the status returned by the bithumb API for successful requests.
+	StatusSuccess = "0000"
+
+	// dateKey is the key of the response timestamp, which is returned alongside the tickers.
+	dateKey = "date"
+
+	delimiter = "_"
+)
+
+// TickersResponse is the response of the bithumb ALL_KRW ticker API. The data payload
+// maps each base currency to its TickerData, with the exception of the "date" key.
+//
+// Docs: https://apidocs.bithumb.com/v1.2.0/reference/현재가-정보-조회-all
+//
+// Ex.
+//
+//	{
+//	  "status": "0000",
+//	  "data": {
+//	    "BTC": {
+//	      "opening_price": "91000000",
+//	      "closing_price": "91500000",
+//	      "min_price": "90500000",
+//	      "max_price": "92000000",
+//	      "units_traded": "312.5",
+//	      "acc_trade_value": "28593750000",
+//	      "prev_closing_price": "91000000",
+//	      "units_traded_24H": "620.1",
+//	      "acc_trade_value_24H": "56739150000",
+//	      "fluctate_24H": "500000",
+//	      "fluctate_rate_24H": "0.55"
+//	    },
+//	    "date": "1729051952000"
+//	  }
+//	}
+type TickersResponse struct {
+	Status  string                     `json:"status"`
+	Message string                     `json:"message"`
+	Data    map[string]json.RawMessage `json:"data"`
+}
+
+// Validate checks if the status is valid from the response.
+func (tr *TickersResponse) Validate() error {
+	if tr.Status != StatusSuccess {
+		return fmt.Errorf("invalid tickers response: %s %s", tr.Status, tr.Message)
+	}
+
+	return nil
+}
+
+// Tickers decodes the tickers of the response, sorted by base currency.
+func (tr *TickersResponse) Tickers() ([]TickerData, error) {
+	tickers := make([]TickerData, 0, len(tr.Data))
+	for base, raw := range tr.Data {
+		if base == dateKey {
+			continue
+		}
+
+		var ticker TickerData
+		if err := json.Unmarshal(raw, &ticker); err != nil {
+			return nil, fmt.Errorf("failed to decode ticker %s: %w", base, err)
+		}
+		ticker.Base = base
+
+		tickers = append(tickers, ticker)
+	}
+
+	sort.Slice(tickers, func(i, j int) bool {
+		return tickers[i].Base < tickers[j].Base
+	})
+
+	return tickers, nil
+}
+
+// TickerData is the ticker of a single KRW market on bithumb.
+type TickerData struct {
+	// Base is the base currency of the market. It is the key of the ticker in the response.
+	Base         string `json:"-"`
+	ClosingPrice string `json:"closing_price"`
+	// AccTradeValue24H is the 24hr volume in terms of the quote currency.
+	AccTradeValue24H string `json:"acc_trade_value_24H"`
+	UnitsTraded24H   string `json:"units_traded_24H"`
+}
+
+func (td *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
+	quoteVol, err := strconv.ParseFloat(td.AccTradeValue24H, 64)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert acc_trade_value_24H: %w", err)
+	}
+
+	refPrice, err := strconv.ParseFloat(td.ClosingPrice, 64)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert closing_price: %w", err)
+	}
+
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, td.Base)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:     targetBase,
+			TargetQuote:    QuoteKRW,
+			OffChainTicker: td.Base + delimiter + QuoteKRW,
+			ProviderName:   ProviderName,
+			QuoteVolume:    quoteVol,
+			ReferencePrice: refPrice,
+		},
+	}
+
+	return pm, pm.ValidateBasic()
+}
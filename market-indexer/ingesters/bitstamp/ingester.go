@@ -5,6 +5,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -19,32 +22,56 @@ var _ ingesters.Ingester = &Ingester{}
 
 // Ingester is the bitstamp implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new bitstamp Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(opts...),
 	}
 }
 
 // NewWithClient creates a new okx Ingester with the given Client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Bitstamp ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -63,7 +90,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 	for _, ticker := range tickers {
 		ig.logger.Debug("parsing", zap.Any("ticker", ticker))
 
-		pm, err := ticker.toProviderMarket()
+		pm, err := ticker.toProviderMarket(ig.aliases)
 		if err != nil {
 			return nil, err
 		}
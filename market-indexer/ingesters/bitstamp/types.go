@@ -54,7 +54,7 @@ func symbolToBaseQuote(symbol string) (string, string, error) {
 	return splitSymbol[0], splitSymbol[1], nil
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	baseVol, err := strconv.ParseFloat(td.Volume, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -83,11 +83,11 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert open: %w", err)
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
@@ -6,6 +6,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -22,32 +25,56 @@ var _ ingesters.Ingester = &Ingester{}
 
 // Ingester is the bybit implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new Bybit ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPClient(opts...),
 	}
 }
 
 // NewWithClient creates a new Bybit ingester.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Bybit ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -79,10 +106,17 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 		ig.logger.Debug("ticker", zap.Any("data", ticker))
 
-		pm, err := item.toProviderMarket(ticker)
+		pm, err := item.toProviderMarket(ig.aliases, ticker)
 		if err != nil {
 			return nil, err
 		}
+
+		// symbols like 1000000MOGUSDT trade a multiple of the underlying asset.
+		pm, err = types.UnscaleProviderMarket(pm)
+		if err != nil {
+			ig.logger.Error("failed to unscale providerMarket", zap.Error(err), zap.String("ingester", Name), zap.Any("ticker", ticker))
+			continue
+		}
 		pms = append(pms, pm)
 	}
 	return pms, nil
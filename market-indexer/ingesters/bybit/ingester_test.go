@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := bybit.NewWithClient(zap.NewNop(), client)
+	ingester := bybit.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(bybit.InstrumentsResponse{}, fmt.Errorf("error"))
@@ -28,7 +28,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 // Test that the ingester only returns markets that are Trading.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := bybit.NewWithClient(zap.NewNop(), client)
+	ingester := bybit.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -87,9 +87,71 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 	require.Equal(t, 124.32003, markets[0].Create.ReferencePrice)
 }
 
+// Test that scaled symbols are indexed on their underlying asset, while symbols that only look scaled are not.
+func TestIngesterUnscalesScaledMarkets(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bybit.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Instruments", ctx).Return(bybit.InstrumentsResponse{
+		Result: bybit.InstrumentsResult{
+			List: []bybit.InstrumentData{
+				{
+					Symbol:    "1000000MOGUSDT",
+					Status:    bybit.StatusTrading,
+					BaseCoin:  "1000000MOG",
+					QuoteCoin: "USDT",
+				},
+				{
+					Symbol:    "1000XUSDT",
+					Status:    bybit.StatusTrading,
+					BaseCoin:  "1000X",
+					QuoteCoin: "USDT",
+				},
+			},
+		},
+	}, nil)
+
+	client.On("Tickers", ctx).Return(bybit.TickersResponse{
+		Result: bybit.TickersResult{
+			List: []bybit.TickerData{
+				{
+					Symbol:       "1000000MOGUSDT",
+					HighPrice24H: "2",
+					LowPrice24H:  "1",
+					Volume24H:    "1000",
+					LastPrice:    "1.5",
+				},
+				{
+					Symbol:       "1000XUSDT",
+					HighPrice24H: "2",
+					LowPrice24H:  "1",
+					Volume24H:    "1000",
+					LastPrice:    "1.5",
+				},
+			},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, markets, 2)
+	require.Equal(t, "MOG", markets[0].Create.TargetBase)
+	require.Equal(t, "USDT", markets[0].Create.TargetQuote)
+	require.Equal(t, "1000000MOGUSDT", markets[0].Create.OffChainTicker)
+	require.Equal(t, float64(1500), markets[0].Create.QuoteVolume)
+	require.InDelta(t, 0.0000015, markets[0].Create.ReferencePrice, 1e-15)
+	require.JSONEq(t, `{"multiplier":1000000,"scaled_base":"1000000MOG"}`, string(markets[0].Create.MetadataJSON))
+
+	require.Equal(t, "1000X", markets[1].Create.TargetBase)
+	require.Equal(t, 1.5, markets[1].Create.ReferencePrice)
+	require.Empty(t, markets[1].Create.MetadataJSON)
+}
+
 func TestIngesterErrorsOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := bybit.NewWithClient(zap.NewNop(), client)
+	ingester := bybit.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(bybit.InstrumentsResponse{}, nil)
@@ -63,7 +63,7 @@ type InstrumentData struct {
 }
 
 // toProviderMarket converts InstrumentData to a CreateProviderMarketParams object.
-func (rd *InstrumentData) toProviderMarket(tickerData TickerData) (provider.CreateProviderMarket, error) {
+func (rd *InstrumentData) toProviderMarket(aliases *symbols.AliasRegistry, tickerData TickerData) (provider.CreateProviderMarket, error) {
 	baseVol, err := strconv.ParseFloat(tickerData.Volume24H, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -87,11 +87,11 @@ func (rd *InstrumentData) toProviderMarket(tickerData TickerData) (provider.Crea
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert lastPrice: %w", err)
 	}
 
-	targetBase, err := symbols.ToTickerString(rd.BaseCoin)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, rd.BaseCoin)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(rd.QuoteCoin)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, rd.QuoteCoin)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
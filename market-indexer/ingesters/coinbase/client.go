@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/skip-mev/connect-mmu/lib/http"
 )
@@ -11,6 +12,7 @@ import (
 const (
 	allTickersEndpoint     = "https://api.exchange.coinbase.com/products"
 	statsPerTickerEndpoint = "https://api.exchange.coinbase.com/products/stats"
+	orderBookEndpoint      = "https://api.exchange.coinbase.com/products/%s/book?level=2"
 )
 
 // Client is an interface for a client that can interact with
@@ -25,13 +27,17 @@ type Client interface {
 	// Stats returns the stats for all markets from the coinbase api
 	// in accordance with the products/stats api
 	Stats(context.Context) (Stats, error)
+
+	// OrderBook returns the aggregated order book of a product from the coinbase api
+	// in accordance with the products/{product_id}/book api
+	OrderBook(ctx context.Context, productID string) (OrderBook, error)
 }
 
 // NewHTTPCoinbaseClient creates a new coinbase client that interacts with
 // the coinbase api over HTTP.
-func NewHTTPCoinbaseClient() Client {
+func NewHTTPCoinbaseClient(opts ...http.ClientOption) Client {
 	return &httpCoinbaseClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -75,3 +81,19 @@ func (c *httpCoinbaseClient) Stats(ctx context.Context) (Stats, error) {
 
 	return stats, nil
 }
+
+func (c *httpCoinbaseClient) OrderBook(ctx context.Context, productID string) (OrderBook, error) {
+	// query the level 2 order book endpoint
+	resp, err := c.client.GetWithContext(ctx, fmt.Sprintf(orderBookEndpoint, url.PathEscape(productID)))
+	if err != nil {
+		return OrderBook{}, err
+	}
+	defer resp.Body.Close()
+
+	var book OrderBook
+	if err := json.NewDecoder(resp.Body).Decode(&book); err != nil {
+		return OrderBook{}, fmt.Errorf("failed to decode response from %s-ingester: %w", Name, err)
+	}
+
+	return book, nil
+}
@@ -7,7 +7,10 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
 	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
@@ -15,27 +18,57 @@ import (
 const (
 	Name         = "coinbase"
 	ProviderName = Name + types.ProviderNameSuffixWS
+
+	// CMCExchangeSlug is the slug of Coinbase on CoinMarketCap.
+	CMCExchangeSlug = "coinbase-exchange"
 )
 
+var _ ingesters.OrderBookIngester = &Ingester{}
+
 // Ingester is the coinbase implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
-	client Client
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+	client  Client
 }
 
 // New creates a new coinbase Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPCoinbaseClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPCoinbaseClient(opts...),
 	}
 }
 
 // NewWithClient creates a new coinbase Ingester with a custom client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Coinbase ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:            Name,
+		ProviderName:    ProviderName,
+		CMCExchangeSlug: CMCExchangeSlug,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -51,12 +84,12 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 
 	markets := make([]provider.CreateProviderMarket, 0, len(products))
 	for _, product := range products {
-		targetBase, err := symbols.ToTickerString(product.Base)
+		targetBase, err := symbols.ToCanonicalTickerString(i.aliases, Name, product.Base)
 		if err != nil {
 			i.logger.Debug("skip creating a ticker", zap.Error(err))
 			continue
 		}
-		targetQuote, err := symbols.ToTickerString(product.Quote)
+		targetQuote, err := symbols.ToCanonicalTickerString(i.aliases, Name, product.Quote)
 		if err != nil {
 			i.logger.Debug("skip creating a ticker", zap.Error(err))
 			continue
@@ -130,3 +163,13 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 func (i *Ingester) Name() string {
 	return Name
 }
+
+// OrderBook returns a snapshot of the order book of the given market.
+func (i *Ingester) OrderBook(ctx context.Context, market provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	book, err := i.client.OrderBook(ctx, market.Create.OffChainTicker)
+	if err != nil {
+		return ingesters.OrderBook{}, err
+	}
+
+	return ingesters.ParseOrderBook(book.Bids, book.Asks)
+}
@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestCoinbaseIngestorReturnsErrorOnProductsError(t *testing.T) {
 	client := coinbasemocks.NewClient(t)
-	ingester := coinbase.NewWithClient(zap.NewNop(), client)
+	ingester := coinbase.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Products", ctx).Return(coinbase.Products{}, fmt.Errorf("error"))
@@ -29,7 +29,7 @@ func TestCoinbaseIngestorReturnsErrorOnProductsError(t *testing.T) {
 // and status == "online".
 func TestCoinbaseIngestorIgnoresNonTradingMarkets(t *testing.T) {
 	client := coinbasemocks.NewClient(t)
-	ingester := coinbase.NewWithClient(zap.NewNop(), client)
+	ingester := coinbase.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -73,7 +73,7 @@ func TestCoinbaseIngestorIgnoresNonTradingMarkets(t *testing.T) {
 
 func TestCoinbaseIngestorErrorsOnStatsError(t *testing.T) {
 	client := coinbasemocks.NewClient(t)
-	ingester := coinbase.NewWithClient(zap.NewNop(), client)
+	ingester := coinbase.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Products", ctx).Return(coinbase.Products{}, nil)
@@ -86,7 +86,7 @@ func TestCoinbaseIngestorErrorsOnStatsError(t *testing.T) {
 
 func TestCoinbaseIngestorUpdatesVolumes(t *testing.T) {
 	client := coinbasemocks.NewClient(t)
-	ingester := coinbase.NewWithClient(zap.NewNop(), client)
+	ingester := coinbase.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -23,6 +23,63 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// OrderBook provides a mock function with given fields: ctx, productID
+func (_m *Client) OrderBook(ctx context.Context, productID string) (coinbase.OrderBook, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderBook")
+	}
+
+	var r0 coinbase.OrderBook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (coinbase.OrderBook, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) coinbase.OrderBook); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(coinbase.OrderBook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_OrderBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderBook'
+type Client_OrderBook_Call struct {
+	*mock.Call
+}
+
+// OrderBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID string
+func (_e *Client_Expecter) OrderBook(ctx interface{}, productID interface{}) *Client_OrderBook_Call {
+	return &Client_OrderBook_Call{Call: _e.mock.On("OrderBook", ctx, productID)}
+}
+
+func (_c *Client_OrderBook_Call) Run(run func(ctx context.Context, productID string)) *Client_OrderBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) Return(_a0 coinbase.OrderBook, _a1 error) *Client_OrderBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) RunAndReturn(run func(context.Context, string) (coinbase.OrderBook, error)) *Client_OrderBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Products provides a mock function with given fields: _a0
 func (_m *Client) Products(_a0 context.Context) (coinbase.Products, error) {
 	ret := _m.Called(_a0)
@@ -51,3 +51,18 @@ type StatsPerMarket struct {
 
 // Stats is a map of market id to the stats for that market.
 type Stats map[string]StatsPerMarket
+
+// OrderBook is the level 2 order book of a single market according to the coinbase
+// book api (https://api.exchange.coinbase.com/products/{product_id}/book?level=2)
+//
+// Example response:
+//
+//	{
+//	    "bids": [["64000.01", "0.5", 3]],
+//	    "asks": [["64000.02", "1.2", 5]],
+//	    "sequence": 13051505638,
+//	}
+type OrderBook struct {
+	Bids [][]any `json:"bids"`
+	Asks [][]any `json:"asks"`
+}
@@ -27,9 +27,9 @@ type Client interface {
 
 // NewHTTPClient creates a new coinbase client that interacts with
 // the crypto.com api over HTTP.
-func NewHTTPClient() Client {
+func NewHTTPClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -7,6 +7,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -16,6 +19,9 @@ const (
 	Name         = "crypto_dot_com"
 	ProviderName = Name + types.ProviderNameSuffixWS
 
+	// CMCExchangeSlug is the slug of Crypto.com on CoinMarketCap.
+	CMCExchangeSlug = "crypto-com-exchange"
+
 	InstrumentTypePerpetual = "PERPETUAL_SWAP"
 	InstrumentTypeCCYPair   = "CCY_PAIR"
 )
@@ -24,32 +30,57 @@ var _ ingesters.Ingester = &Ingester{}
 
 // Ingester is the crypto.com implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new crypto.com Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPClient(opts...),
 	}
 }
 
 // NewWithClient creates a new crypto.com Ingester with the given Client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Crypto.com ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:            Name,
+		ProviderName:    ProviderName,
+		CMCExchangeSlug: CMCExchangeSlug,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -79,7 +110,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 			return nil, fmt.Errorf("ticker not found for symbol %s", result.Symbol)
 		}
 
-		pm, err := result.toProviderMarket(ticker)
+		pm, err := result.toProviderMarket(ig.aliases, ticker)
 		if err != nil {
 			ig.logger.Warn("ignoring instrument because can not convert to provider market",
 				zap.String("exchange", Name),
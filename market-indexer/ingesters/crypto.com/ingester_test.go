@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	client := crypto_com_mocks.NewClient(t)
-	ingester := crypto_com.NewWithClient(zap.NewNop(), client)
+	ingester := crypto_com.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(crypto_com.InstrumentsResponse{}, fmt.Errorf("error"))
@@ -28,7 +28,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 // Test that the ingester only returns markets that are SPOT.
 func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 	client := crypto_com_mocks.NewClient(t)
-	ingester := crypto_com.NewWithClient(zap.NewNop(), client)
+	ingester := crypto_com.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -101,7 +101,7 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 
 func TestIngesterErrorsOnVolumesError(t *testing.T) {
 	client := crypto_com_mocks.NewClient(t)
-	ingester := crypto_com.NewWithClient(zap.NewNop(), client)
+	ingester := crypto_com.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(crypto_com.InstrumentsResponse{}, nil)
@@ -62,7 +62,7 @@ type InstrumentsData struct {
 	Tradable    bool   `json:"tradable"`
 }
 
-func (d *InstrumentsData) toProviderMarket(ticker TickerData) (provider.CreateProviderMarket, error) {
+func (d *InstrumentsData) toProviderMarket(aliases *symbols.AliasRegistry, ticker TickerData) (provider.CreateProviderMarket, error) {
 	baseVol, err := strconv.ParseFloat(ticker.V, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -91,11 +91,11 @@ func (d *InstrumentsData) toProviderMarket(ticker TickerData) (provider.CreatePr
 	avg := (high + low) / 2
 	quoteVol := baseVol * avg
 
-	targetBase, err := symbols.ToTickerString(d.BaseCcy)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, d.BaseCcy)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(d.QuoteCcy)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, d.QuoteCcy)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
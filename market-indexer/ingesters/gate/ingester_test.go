@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := gate.NewWithClient(zap.NewNop(), client)
+	ingester := gate.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return(nil, fmt.Errorf("error"))
@@ -29,7 +29,7 @@ func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 // Ignore leverage perp markets.
 func TestIngesterTickers(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := gate.NewWithClient(zap.NewNop(), client)
+	ingester := gate.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return([]gate.TickerData{
@@ -53,7 +53,7 @@ func (td *TickerData) isSpot() bool {
 	return td.EtfNetValue == "" && td.EtfPreNetValue == "" && td.EtfPreTimestamp == 0 && td.EtfLeverage == ""
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.CurrencyPair)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -69,11 +69,11 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert last price: %w", err)
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
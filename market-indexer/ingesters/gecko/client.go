@@ -17,6 +17,9 @@ const (
 	// we can only make 30 calls a minute.
 	maxCalls     = 30
 	callInterval = 1 * time.Minute
+	// callBurst is the number of calls that can be made at once. Calls refill at maxCalls per interval,
+	// so they are spread evenly over the interval.
+	callBurst = 1
 )
 
 //go:generate mockery --name Client --filename mock_gecko_client.go
@@ -32,23 +35,26 @@ type geckoClientImpl struct {
 	client       *http.Client
 	logger       *zap.Logger
 	baseEndpoint string
-
-	limiter *APIRateLimiter
 }
 
-func newClient(logger *zap.Logger, baseEndpoint string) Client {
+func newClient(logger *zap.Logger, baseEndpoint string, opts ...http.ClientOption) Client {
 	if baseEndpoint == "" {
 		baseEndpoint = BaseEndpoint
 	}
+
+	// the default rate limit comes first, so that it can be overridden by the ingester options.
+	opts = append([]http.ClientOption{
+		http.WithRateLimit(float64(maxCalls) / callInterval.Seconds()),
+		http.WithBurst(callBurst),
+	}, opts...)
+
 	return &geckoClientImpl{
-		client:       http.NewClient(),
+		client:       http.NewClient(opts...),
 		logger:       logger,
 		baseEndpoint: baseEndpoint,
-		limiter:      newRateLimiter(maxCalls, callInterval),
 	}
 }
 
 func (c *geckoClientImpl) GetWithContext(ctx context.Context, endpoint string) (*http2.Response, error) {
-	c.limiter.WaitForNextAvailableCall()
 	return c.client.GetWithContext(ctx, endpoint)
 }
@@ -11,6 +11,8 @@ import (
 	"gopkg.in/typ.v4/maps"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
@@ -22,25 +24,64 @@ const (
 var _ ingesters.Ingester = &Ingester{}
 
 type Ingester struct {
-	logger *zap.Logger
-	client Client
-	pairs  []config.GeckoNetworkDexPair
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+	client  Client
+	pairs   []config.GeckoNetworkDexPair
 }
 
 // New returns a new gecko terminal ingester. Options may be used to specify more networks and dexes to query.
 // The default ingester only queries uniswap v3 on the ethereum network.
-func New(logger *zap.Logger, marketConfig config.MarketConfig) *Ingester {
-	if err := validatePairs(marketConfig.GeckoNetworkDexPairs); err != nil {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, marketConfig config.MarketConfig, opts ...http.ClientOption) *Ingester {
+	pairs, err := validatePairs(marketConfig.GeckoNetworkDexPairs)
+	if err != nil {
 		panic("invalid pairs: " + err.Error())
 	}
 	ing := &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		pairs:  marketConfig.GeckoNetworkDexPairs,
-		client: newClient(logger, BaseEndpoint),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		pairs:   pairs,
+		client:  newClient(logger, BaseEndpoint, opts...),
 	}
 	return ing
 }
 
+// Registration returns the registration of the GeckoTerminal ingester for an ingesters.Registry.
+// The ingester indexes one venue for each configured network/dex pair.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name: Name,
+		Factory: func(
+			logger *zap.Logger,
+			cfg config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := validatePairs(cfg.GeckoNetworkDexPairs); err != nil {
+				return nil, fmt.Errorf("invalid pairs: %w", err)
+			}
+			return New(logger, aliases, cfg, clientOpts...), nil
+		},
+		Venues: func(cfg config.MarketConfig) []ingesters.Venue {
+			venues := make([]ingesters.Venue, 0, len(cfg.GeckoNetworkDexPairs))
+			for _, pair := range cfg.GeckoNetworkDexPairs {
+				pair = pair.WithDefaults()
+				venues = append(venues, ingesters.Venue{
+					Name:            pair.Dex,
+					ProviderName:    pair.ProviderName,
+					CMCExchangeSlug: pair.CMCExchangeSlug,
+				})
+			}
+			return venues
+		},
+	}
+}
+
 func (ig *Ingester) Name() string {
 	return Name
 }
@@ -136,19 +177,19 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 				continue
 			}
 
-			targetBase, err := pool.Base()
+			targetBase, err := pool.Base(ig.aliases)
 			if err != nil {
 				ig.logger.Debug("failed to convert target base to ticker string - skipping", zap.Error(err))
 				continue
 			}
 
-			targetQuote, err := pool.Quote()
+			targetQuote, err := pool.Quote(ig.aliases)
 			if err != nil {
 				ig.logger.Debug("failed to convert target quote to ticker string - skipping", zap.Error(err))
 				continue
 			}
 
-			offChainTicker, err := pool.OffChainTicker()
+			offChainTicker, err := pool.OffChainTicker(ig.aliases, pair.TickerVenue)
 			if err != nil {
 				ig.logger.Debug("gecko client: failed to convert off chain ticker to ticker string", zap.Error(err))
 				continue
@@ -159,7 +200,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 					TargetBase:       targetBase,
 					TargetQuote:      targetQuote,
 					OffChainTicker:   offChainTicker,
-					ProviderName:     geckoDexToConnectDex(pool.Venue()),
+					ProviderName:     pair.ProviderName,
 					QuoteVolume:      quoteVolF64,
 					UsdVolume:        usdVol,
 					MetadataJSON:     metaDataBz,
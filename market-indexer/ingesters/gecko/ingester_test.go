@@ -3,7 +3,7 @@ package gecko
 import (
 	"context"
 	"encoding/json"
-	"net/http"
+	nethttp "net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
@@ -33,7 +34,7 @@ func TestGetProviderMarkets(t *testing.T) {
 	var tokens TokensMultiResponse
 	err = json.Unmarshal(tokensResponse, &tokens)
 	require.NoError(t, err)
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
 		var file []byte
 		switch {
 		case r.URL.Path == "/networks/eth/dexes/uniswap_v3/pools":
@@ -42,7 +43,7 @@ func TestGetProviderMarkets(t *testing.T) {
 			file = tokensResponse
 		default:
 			t.Logf("Unexpected request: %s", r.URL.Path)
-			http.Error(w, "Not found", http.StatusNotFound)
+			nethttp.Error(w, "Not found", nethttp.StatusNotFound)
 			return
 		}
 
@@ -52,13 +53,13 @@ func TestGetProviderMarkets(t *testing.T) {
 	logger, err := zap.NewDevelopment()
 	require.NoError(t, err)
 
-	// create gecko client but with test server URL
-	client := newClient(logger, server.URL)
+	// create gecko client but with test server URL, without the GeckoTerminal rate limit.
+	client := newClient(logger, server.URL, http.WithRateLimit(0))
 
 	ingester := &Ingester{
 		logger: logger,
 		client: client,
-		pairs:  []config.GeckoNetworkDexPair{{Network: "eth", Dex: "uniswap_v3"}},
+		pairs:  []config.GeckoNetworkDexPair{config.KnownGeckoNetworkDexPairs[0]},
 	}
 
 	// get the provider markets.
@@ -85,22 +86,22 @@ func TestGetProviderMarkets(t *testing.T) {
 	metaData2Bz, err := json.Marshal(metaData2)
 	require.NoError(t, err)
 
-	targetBase0, err := pools.Data[0].Base()
+	targetBase0, err := pools.Data[0].Base(nil)
 	require.NoError(t, err)
-	targetQuote0, err := pools.Data[0].Quote()
+	targetQuote0, err := pools.Data[0].Quote(nil)
 	require.NoError(t, err)
-	offChainTicker0, err := pools.Data[0].OffChainTicker()
+	offChainTicker0, err := pools.Data[0].OffChainTicker(nil, "UNISWAP_V3")
 	require.NoError(t, err)
 	liquidity0, err := pools.Data[0].Liquidity()
 	require.NoError(t, err)
 	usdVolume0, err := pools.Data[0].UsdVolume()
 	require.NoError(t, err)
 
-	targetBase1, err := pools.Data[1].Base()
+	targetBase1, err := pools.Data[1].Base(nil)
 	require.NoError(t, err)
-	targetQuote1, err := pools.Data[1].Quote()
+	targetQuote1, err := pools.Data[1].Quote(nil)
 	require.NoError(t, err)
-	offChainTicker1, err := pools.Data[1].OffChainTicker()
+	offChainTicker1, err := pools.Data[1].OffChainTicker(nil, "UNISWAP_V3")
 	require.NoError(t, err)
 	liquidity1, err := pools.Data[1].Liquidity()
 	require.NoError(t, err)
@@ -114,7 +115,7 @@ func TestGetProviderMarkets(t *testing.T) {
 				TargetBase:       targetBase0,
 				TargetQuote:      targetQuote0,
 				OffChainTicker:   offChainTicker0,
-				ProviderName:     geckoDexToConnectDex(pools.Data[0].Venue()),
+				ProviderName:     "uniswapv3_api-ethereum",
 				QuoteVolume:      281462633.1550315,
 				UsdVolume:        usdVolume0,
 				MetadataJSON:     metaData1Bz,
@@ -130,7 +131,7 @@ func TestGetProviderMarkets(t *testing.T) {
 				TargetBase:       targetBase1,
 				TargetQuote:      targetQuote1,
 				OffChainTicker:   offChainTicker1,
-				ProviderName:     geckoDexToConnectDex(pools.Data[1].Venue()),
+				ProviderName:     "uniswapv3_api-ethereum",
 				QuoteVolume:      3639.743321519964,
 				UsdVolume:        usdVolume1,
 				MetadataJSON:     metaData2Bz,
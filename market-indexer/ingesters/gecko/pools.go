@@ -203,8 +203,8 @@ func (p *PoolData) VenueAddress() string {
 	return p.Attributes.Address
 }
 
-// Base returns the properly formated base symbol.
-func (p *PoolData) Base() (string, error) {
+// Base returns the properly formated base symbol, resolved with aliases.
+func (p *PoolData) Base(aliases *symbols.AliasRegistry) (string, error) {
 	split, err := p.SplitSymbol()
 	if err != nil {
 		return "", err
@@ -216,7 +216,7 @@ func (p *PoolData) Base() (string, error) {
 	}
 
 	// remove all numbers and decimals. Gecko pairs are returned as QNT/WETH0.3, and we want QNT/WETH.
-	return removeTrailingNumbers(s), nil
+	return aliases.Symbol(Name, removeTrailingNumbers(s)), nil
 }
 
 func (p *PoolData) SplitSymbol() ([]string, error) {
@@ -228,8 +228,8 @@ func (p *PoolData) SplitSymbol() ([]string, error) {
 	return split, nil
 }
 
-// Quote returns the properly formated quote symbol.
-func (p *PoolData) Quote() (string, error) {
+// Quote returns the properly formated quote symbol, resolved with aliases.
+func (p *PoolData) Quote(aliases *symbols.AliasRegistry) (string, error) {
 	split, err := p.SplitSymbol()
 	if err != nil {
 		return "", err
@@ -241,7 +241,7 @@ func (p *PoolData) Quote() (string, error) {
 	}
 
 	// remove all numbers and decimals. Gecko pairs are returned as QNT/WETH0.3, and we want QNT/WETH.
-	return removeTrailingNumbers(s), nil
+	return aliases.Symbol(Name, removeTrailingNumbers(s)), nil
 }
 
 func (p *PoolData) ReferencePrice() (float64, error) {
@@ -260,26 +260,28 @@ func (p *PoolData) Liquidity() (float64, error) {
 	return liquidity, nil
 }
 
-func (p *PoolData) OffChainTicker() (string, error) {
-	targetBase, err := p.Base()
+// OffChainTicker returns the Connect off-chain ticker of the pool on the given ticker venue, with symbols resolved
+// with aliases.
+func (p *PoolData) OffChainTicker(aliases *symbols.AliasRegistry, tickerVenue string) (string, error) {
+	targetBase, err := p.Base(aliases)
 	if err != nil {
 		return "", err
 	}
 
-	targetQuote, err := p.Quote()
+	targetQuote, err := p.Quote(aliases)
 	if err != nil {
 		return "", err
 	}
 
 	targetBaseOffchain := strings.Join([]string{
 		targetBase,
-		geckoDexToConnectTickerVenue(p.Venue()),
+		tickerVenue,
 		p.BaseAddress(),
 	}, types.DefiTickerDelimiter)
 
 	targetQuoteOffchain := strings.Join([]string{
 		targetQuote,
-		geckoDexToConnectTickerVenue(p.Venue()),
+		tickerVenue,
 		p.QuoteAddress(),
 	}, types.DefiTickerDelimiter)
 
@@ -23,7 +23,7 @@ func TestPoolsData(t *testing.T) {
 
 	pool := pools.Data[0]
 
-	ticker, err := pool.OffChainTicker()
+	ticker, err := pool.OffChainTicker(nil, "UNISWAP_V3")
 	require.NoError(t, err)
 	// see: testdata/pools_response_example.json
 	expected := strings.ToUpper("WETH,uniswap_v3,0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2/USDC,uniswap_v3," +
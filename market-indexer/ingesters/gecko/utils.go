@@ -5,8 +5,6 @@ import (
 	"math"
 	"strings"
 
-	"golang.org/x/exp/maps"
-
 	"github.com/skip-mev/connect-mmu/config"
 )
 
@@ -20,49 +18,20 @@ func getAfterUnderscore(s string) string {
 	return str[1]
 }
 
-func validatePairs(pairs []config.GeckoNetworkDexPair) error {
+// validatePairs validates the pairs and returns them with their defaults applied.
+func validatePairs(pairs []config.GeckoNetworkDexPair) ([]config.GeckoNetworkDexPair, error) {
 	if len(pairs) == 0 {
-		return fmt.Errorf("no pairs specified")
+		return nil, fmt.Errorf("no pairs specified")
 	}
+
+	validated := make([]config.GeckoNetworkDexPair, 0, len(pairs))
 	for _, pair := range pairs {
-		if _, exist := validPairs[pair]; !exist {
-			return fmt.Errorf("invalid pair: %v: must be one of %v", pair, maps.Keys(validPairs))
+		if err := pair.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid pair %s: %w", pair, err)
 		}
+		validated = append(validated, pair.WithDefaults())
 	}
-	return nil
-}
-
-const (
-	ProviderNameUniswapEth  = "uniswapv3_api-ethereum"
-	ProviderNameUniswapBase = "uniswapv3_api-base"
-
-	TickerVenueUniswapEth  = "UNISWAP_V3"
-	TickerVenueUniswapBase = "UNISWAP_V3_BASE"
-
-	GeckoVenueUniswapEth  = "uniswap_v3"
-	GeckoVenueUniswapBase = "uniswap-v3-base"
-)
-
-func geckoDexToConnectDex(dex string) string {
-	switch dex {
-	case GeckoVenueUniswapEth:
-		return ProviderNameUniswapEth
-	case GeckoVenueUniswapBase:
-		return ProviderNameUniswapBase
-	default:
-		return dex
-	}
-}
-
-func geckoDexToConnectTickerVenue(dex string) string {
-	switch dex {
-	case GeckoVenueUniswapEth:
-		return TickerVenueUniswapEth
-	case GeckoVenueUniswapBase:
-		return TickerVenueUniswapBase
-	default:
-		return dex
-	}
+	return validated, nil
 }
 
 func isValidFloat64(f float64) bool {
@@ -72,8 +41,3 @@ func isValidFloat64(f float64) bool {
 	}
 	return true
 }
-
-var validPairs = map[config.GeckoNetworkDexPair]struct{}{
-	{Network: "eth", Dex: GeckoVenueUniswapEth}:   {},
-	{Network: "base", Dex: GeckoVenueUniswapBase}: {},
-}
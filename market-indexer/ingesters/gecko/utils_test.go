@@ -35,6 +35,7 @@ func TestValidatePairs(t *testing.T) {
 	tests := []struct {
 		name   string
 		pairs  []config.GeckoNetworkDexPair
+		want   []config.GeckoNetworkDexPair
 		errMsg string
 	}{
 		{
@@ -47,13 +48,37 @@ func TestValidatePairs(t *testing.T) {
 			pairs: []config.GeckoNetworkDexPair{
 				{Network: "eth", Dex: "uniswap_v3"},
 			},
+			want: []config.GeckoNetworkDexPair{
+				{
+					Network:         "eth",
+					Dex:             "uniswap_v3",
+					ProviderName:    "uniswapv3_api-ethereum",
+					TickerVenue:     "UNISWAP_V3",
+					CMCExchangeSlug: "uniswap-v3",
+				},
+			},
+		},
+		{
+			name: "Configured pair",
+			pairs: []config.GeckoNetworkDexPair{
+				{Network: "base", Dex: "sushiswap-v3-base", ProviderName: "uniswapv3_api-base", TickerVenue: "SUSHISWAP_V3_BASE"},
+			},
+			want: []config.GeckoNetworkDexPair{
+				{
+					Network:         "base",
+					Dex:             "sushiswap-v3-base",
+					ProviderName:    "uniswapv3_api-base",
+					TickerVenue:     "SUSHISWAP_V3_BASE",
+					CMCExchangeSlug: "sushiswap-v3-base",
+				},
+			},
 		},
 		{
 			name: "Invalid pair",
 			pairs: []config.GeckoNetworkDexPair{
 				{Network: "btc", Dex: "pancakeswap"},
 			},
-			errMsg: "invalid pair: {btc pancakeswap}",
+			errMsg: "invalid pair btc/pancakeswap",
 		},
 		{
 			name: "Mixed valid and invalid pairs",
@@ -61,18 +86,26 @@ func TestValidatePairs(t *testing.T) {
 				{Network: "eth", Dex: "uniswap_v3"},
 				{Network: "btc", Dex: "pancakeswap"},
 			},
-			errMsg: "invalid pair: {btc pancakeswap}",
+			errMsg: "invalid pair btc/pancakeswap",
+		},
+		{
+			name: "Unsupported provider",
+			pairs: []config.GeckoNetworkDexPair{
+				{Network: "bsc", Dex: "pancakeswap_v3", ProviderName: "pancakeswap_api-bsc", TickerVenue: "PANCAKESWAP_V3"},
+			},
+			errMsg: "is not a Connect uniswapv3 provider",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePairs(tt.pairs)
+			pairs, err := validatePairs(tt.pairs)
 			if tt.errMsg != "" {
 				require.Error(t, err)
 				require.ErrorContains(t, err, tt.errMsg)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.want, pairs)
 			}
 		})
 	}
@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := huobi.NewWithClient(zap.NewNop(), client)
+	ingester := huobi.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return(huobi.TickersResponse{}, fmt.Errorf("error"))
@@ -28,7 +28,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 // Test that the ingester returns tickers from the response.
 func TestIngesterTickers(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := huobi.NewWithClient(zap.NewNop(), client)
+	ingester := huobi.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
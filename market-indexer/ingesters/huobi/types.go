@@ -76,17 +76,17 @@ type TickerData struct {
 	Open float64 `json:"open"`
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.Symbol)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
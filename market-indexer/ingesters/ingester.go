@@ -15,3 +15,11 @@ type Ingester interface {
 	// Name returns the name of the Ingester.
 	Name() string
 }
+
+// SkipReporter is an Ingester that reports the markets it skipped while fetching its markets.
+type SkipReporter interface {
+	Ingester
+
+	// SkippedMarkets returns the number of markets skipped by the last GetProviderMarkets call, by reason.
+	SkippedMarkets() map[string]int
+}
@@ -3,6 +3,8 @@ package kraken
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 
 	"github.com/skip-mev/connect-mmu/lib/http"
 )
@@ -10,6 +12,10 @@ import (
 const (
 	EndpointAssets  = "https://api.kraken.com/0/public/AssetPairs"
 	EndpointTickers = "https://api.kraken.com/0/public/Ticker"
+	EndpointDepth   = "https://api.kraken.com/0/public/Depth"
+
+	// depthCount is the number of levels fetched per side of an order book.
+	depthCount = 500
 )
 
 var _ Client = &httpClient{}
@@ -23,15 +29,18 @@ type Client interface {
 
 	// Tickers gets all ticker from Kraken.
 	Tickers(ctx context.Context) (TickersResponse, error)
+
+	// Depth gets the order book of an asset pair from Kraken.
+	Depth(ctx context.Context, pair string) (DepthResponse, error)
 }
 
 type httpClient struct {
 	client *http.Client
 }
 
-func NewHTTPClient() Client {
+func NewHTTPClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -64,3 +73,19 @@ func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
 
 	return tickerResp, nil
 }
+
+func (h *httpClient) Depth(ctx context.Context, pair string) (DepthResponse, error) {
+	endpoint := fmt.Sprintf("%s?pair=%s&count=%d", EndpointDepth, url.QueryEscape(pair), depthCount)
+	resp, err := h.client.GetWithContext(ctx, endpoint)
+	if err != nil {
+		return DepthResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var depthResp DepthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&depthResp); err != nil {
+		return DepthResponse{}, err
+	}
+
+	return depthResp, nil
+}
@@ -6,6 +6,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -16,36 +19,60 @@ const (
 	ProviderName = Name + types.ProviderNameSuffixAPI
 )
 
-var _ ingesters.Ingester = &Ingester{}
+var _ ingesters.OrderBookIngester = &Ingester{}
 
 // Ingester is the kraken implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new kraken Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewHTTPClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewHTTPClient(opts...),
 	}
 }
 
 // NewWithClient creates a new kraken Ingester with the given Client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Kraken ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -75,7 +102,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 		ig.logger.Debug("ticker", zap.String("offchain ticker", offChainTicker), zap.Any("data", data))
 
-		pm, err := result.toProviderMarket(offChainTicker, data)
+		pm, err := result.toProviderMarket(ig.aliases, offChainTicker, data)
 		if err != nil {
 			return nil, err
 		}
@@ -92,3 +119,22 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 func (ig *Ingester) Name() string {
 	return Name
 }
+
+// OrderBook returns a snapshot of the order book of the given market.
+func (ig *Ingester) OrderBook(ctx context.Context, market provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	resp, err := ig.client.Depth(ctx, market.Create.OffChainTicker)
+	if err != nil {
+		return ingesters.OrderBook{}, err
+	}
+
+	if len(resp.Error) > 0 {
+		return ingesters.OrderBook{}, fmt.Errorf("kraken depth error: %v", resp.Error)
+	}
+
+	book, found := resp.Result[market.Create.OffChainTicker]
+	if !found {
+		return ingesters.OrderBook{}, fmt.Errorf("order book %s not found", market.Create.OffChainTicker)
+	}
+
+	return ingesters.ParseOrderBook(book.Bids, book.Asks)
+}
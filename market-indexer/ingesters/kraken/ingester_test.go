@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken/mocks"
 )
@@ -16,7 +18,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnAssetPairsError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := kraken.NewWithClient(zap.NewNop(), client)
+	ingester := kraken.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("AssetPairs", ctx).Return(kraken.AssetPairsResponse{}, fmt.Errorf("error"))
@@ -28,7 +30,7 @@ func TestIngesterReturnsErrorOnAssetPairsError(t *testing.T) {
 // Test that the ingester only returns markets that are enabled.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := kraken.NewWithClient(zap.NewNop(), client)
+	ingester := kraken.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -82,9 +84,48 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 	require.Equal(t, 10.23, markets[0].Create.ReferencePrice)
 }
 
+// Test that the ingester resolves kraken symbols with the aliases it is created with.
+func TestIngesterResolvesAliases(t *testing.T) {
+	client := mocks.NewClient(t)
+	aliases := config.DefaultAliasConfig().Registry()
+	ingester := kraken.NewWithClient(zap.NewNop(), aliases, client)
+
+	ctx := context.Background()
+	client.On("AssetPairs", ctx).Return(kraken.AssetPairsResponse{
+		Result: map[string]kraken.AssetData{
+			"XXBTZUSD": {
+				Wsname: "XBT/USD",
+				Base:   "XXBT",
+				Quote:  "ZUSD",
+				Status: kraken.StatusOnline,
+			},
+		},
+	}, nil)
+	client.On("Tickers", ctx).Return(kraken.TickersResponse{
+		Result: map[string]kraken.TickerData{
+			"XXBTZUSD": {
+				V: []string{"1108.13627835", "1350.13382379"},
+				L: []string{"61774.60000", "61774.60000"},
+				H: []string{"63300.00000", "63908.00000"},
+				C: []string{"10.23", "0.23"},
+			},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	require.Equal(t, "BTC", markets[0].Create.TargetBase)
+	require.Equal(t, "USD", markets[0].Create.TargetQuote)
+	require.Equal(t, []symbols.AliasUsage{
+		{Kind: symbols.AliasKindSymbol, Venue: kraken.Name, Alias: "XXBT", Canonical: "BTC", Count: 1},
+		{Kind: symbols.AliasKindSymbol, Venue: kraken.Name, Alias: "ZUSD", Canonical: "USD", Count: 1},
+	}, aliases.Report())
+}
+
 func TestIngesterErrorsOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := kraken.NewWithClient(zap.NewNop(), client)
+	ingester := kraken.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("AssetPairs", ctx).Return(kraken.AssetPairsResponse{}, nil)
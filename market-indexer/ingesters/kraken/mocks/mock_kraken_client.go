@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	kraken "github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -79,6 +78,63 @@ func (_c *Client_AssetPairs_Call) RunAndReturn(run func(context.Context) (kraken
 	return _c
 }
 
+// Depth provides a mock function with given fields: ctx, pair
+func (_m *Client) Depth(ctx context.Context, pair string) (kraken.DepthResponse, error) {
+	ret := _m.Called(ctx, pair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Depth")
+	}
+
+	var r0 kraken.DepthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (kraken.DepthResponse, error)); ok {
+		return rf(ctx, pair)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) kraken.DepthResponse); ok {
+		r0 = rf(ctx, pair)
+	} else {
+		r0 = ret.Get(0).(kraken.DepthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pair)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Depth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Depth'
+type Client_Depth_Call struct {
+	*mock.Call
+}
+
+// Depth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pair string
+func (_e *Client_Expecter) Depth(ctx interface{}, pair interface{}) *Client_Depth_Call {
+	return &Client_Depth_Call{Call: _e.mock.On("Depth", ctx, pair)}
+}
+
+func (_c *Client_Depth_Call) Run(run func(ctx context.Context, pair string)) *Client_Depth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_Depth_Call) Return(_a0 kraken.DepthResponse, _a1 error) *Client_Depth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Depth_Call) RunAndReturn(run func(context.Context, string) (kraken.DepthResponse, error)) *Client_Depth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: ctx
 func (_m *Client) Tickers(ctx context.Context) (kraken.TickersResponse, error) {
 	ret := _m.Called(ctx)
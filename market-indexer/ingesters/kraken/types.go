@@ -12,7 +12,7 @@ const (
 	StatusOnline = "online"
 )
 
-func (d *AssetData) toProviderMarket(offChainTicker string, data TickerData) (provider.CreateProviderMarket, error) {
+func (d *AssetData) toProviderMarket(aliases *symbols.AliasRegistry, offChainTicker string, data TickerData) (provider.CreateProviderMarket, error) {
 	quoteVol, err := data.volumeInQuote()
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -23,11 +23,11 @@ func (d *AssetData) toProviderMarket(offChainTicker string, data TickerData) (pr
 		return provider.CreateProviderMarket{}, err
 	}
 
-	targetBase, err := symbols.ToTickerString(decode(d.Base))
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, d.Base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(decode(d.Quote))
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, d.Quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
@@ -107,49 +107,6 @@ type AssetData struct {
 	Status      string `json:"status"`
 }
 
-// decode decodes an input ticker to the standardized Slinky ticker.
-func decode(input string) string {
-	switch input {
-	case "ZEUR":
-		return "EUR"
-	case "ZUSD":
-		return "USD"
-	case "XXBT":
-		return "BTC"
-	case "XETH":
-		return "ETH"
-	case "XXRP":
-		return "XRP"
-	case "ZGBP":
-		return "GBP"
-	case "ZJPY":
-		return "JPY"
-	case "XLTC":
-		return "LTC"
-	case "XXDG":
-		return "DOGE"
-	case "ZCAD":
-		return "CAD"
-	case "XXMR":
-		return "XMR"
-	case "ZAUD":
-		return "AUD"
-	case "XREP":
-		return "REP"
-	case "XZEC":
-		return "ZEC"
-	case "XETC":
-		return "ETC"
-	case "XMLN":
-		return "MLN"
-	case "XXLM":
-		return "XLM"
-
-	default:
-		return input
-	}
-}
-
 type TickersResponse struct {
 	Error  []interface{}         `json:"error"`
 	Result map[string]TickerData `json:"result"`
@@ -250,3 +207,33 @@ func (td *TickerData) volumeInQuote() (float64, error) {
 	avg := (low + high) / 2
 	return volume * avg, nil
 }
+
+type DepthResponse struct {
+	Error  []interface{}        `json:"error"`
+	Result map[string]DepthData `json:"result"`
+}
+
+// DepthData is the struct representing an entry in the data payload map
+// in the Depth API response. Each level is [price, volume, timestamp].
+//
+// Docs: https://docs.kraken.com/rest/#tag/Spot-Market-Data/operation/getOrderBook
+//
+// Ex.
+//
+//	{
+//	 "error": [],
+//	 "result": {
+//	   "XXBTZUSD": {
+//	     "asks": [
+//	       ["30384.10000", "2.059", 1688671659]
+//	     ],
+//	     "bids": [
+//	       ["30297.00000", "0.115", 1688671656]
+//	     ]
+//	   }
+//	 }
+//	}
+type DepthData struct {
+	Asks [][]any `json:"asks"`
+	Bids [][]any `json:"bids"`
+}
@@ -61,7 +61,7 @@ type Ticker struct {
 	AveragePrice string `json:"averagePrice"`
 }
 
-func (td *Ticker) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *Ticker) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	quoteVol, err := strconv.ParseFloat(td.VolValue, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -81,11 +81,11 @@ func (td *Ticker) toProviderMarket() (provider.CreateProviderMarket, error) {
 		}
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
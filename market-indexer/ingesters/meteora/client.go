@@ -0,0 +1,67 @@
+package meteora
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+)
+
+const (
+	EndpointPairs = "https://dlmm-api.meteora.ag/pair/all"
+)
+
+var _ Client = &client{}
+
+// Client is a simple client for accessing the Meteora DLMM API and Solana nodes.
+//
+//go:generate mockery --name Client --filename mock_meteora_client.go
+type Client interface {
+	// Pairs fetches all pairs from the meteora dlmm api.
+	Pairs(ctx context.Context) (Pairs, error)
+	// GetMultipleAccounts gets multiple accounts from a solana node.
+	GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error)
+	// ValidateClientConfiguration ensures client is configured correctly.
+	ValidateClientConfiguration() error
+}
+
+type client struct {
+	httpClient     *http.Client
+	multiRPCClient *solanarpc.MultiRPC
+}
+
+func NewClient(logger *zap.Logger, cfg config.MarketConfig, opts ...http.ClientOption) Client {
+	return &client{
+		httpClient:     http.NewClient(opts...),
+		multiRPCClient: solanarpc.NewMultiRPC(logger, cfg.RaydiumNodes, http.TransportOf(opts...)),
+	}
+}
+
+func (h *client) Pairs(ctx context.Context) (Pairs, error) {
+	resp, err := h.httpClient.GetWithContext(ctx, EndpointPairs)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pairs Pairs
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func (h *client) ValidateClientConfiguration() error {
+	return h.multiRPCClient.ValidateClientConfiguration()
+}
+
+func (h *client) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
+	return h.multiRPCClient.GetMultipleAccounts(ctx, accounts)
+}
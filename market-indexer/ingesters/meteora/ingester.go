@@ -0,0 +1,299 @@
+package meteora
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name         = "meteora"
+	ProviderName = Name + types.ProviderNameSuffixAPI
+
+	// CMCExchangeSlug is the slug of Meteora DLMM on CoinMarketCap.
+	CMCExchangeSlug = "meteora"
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// Ingester is the Meteora DLMM implementation of a market data Ingester.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	client Client
+}
+
+// New creates a new meteora Ingester.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, cfg config.MarketConfig, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(logger, cfg, opts...),
+	}
+}
+
+// NewWithClient creates a new meteora Ingester with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Meteora ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:            Name,
+		ProviderName:    ProviderName,
+		CMCExchangeSlug: CMCExchangeSlug,
+		Factory: func(
+			logger *zap.Logger,
+			cfg config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, cfg, clientOpts...), nil
+		},
+	}
+}
+
+// Name returns the Ingester's human-readable name.
+func (ig *Ingester) Name() string {
+	return Name
+}
+
+// pair is a pair from the api along with its decoded lb pair account.
+type pair struct {
+	data      PairData
+	state     LbPair
+	liquidity float64
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	ig.logger.Info("fetching data")
+
+	resp, err := ig.client.Pairs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch pairs: %w", err)
+	}
+
+	candidates := make([]pair, 0, len(resp))
+	addresses := make([]solana.PublicKey, 0, len(resp))
+	for _, data := range resp {
+		if data.Hide {
+			continue
+		}
+
+		liquidity, err := strconv.ParseFloat(data.Liquidity, 64)
+		if err != nil || liquidity <= 0 {
+			continue
+		}
+
+		address, err := solana.PublicKeyFromBase58(data.Address)
+		if err != nil {
+			ig.logger.Debug("invalid pair address - skipping", zap.String("address", data.Address), zap.Error(err))
+			continue
+		}
+
+		candidates = append(candidates, pair{data: data, liquidity: liquidity})
+		addresses = append(addresses, address)
+	}
+
+	ig.logger.Info("pairs", zap.Int("amount", len(candidates)))
+
+	accounts, err := solanarpc.ChunkedGetMultipleAccounts(ctx, ig.logger, ig.client, addresses, solanarpc.DefaultRequestChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]pair, 0, len(accounts))
+	mintSet := make(map[solana.PublicKey]struct{})
+	mints := make([]solana.PublicKey, 0)
+	for i, acct := range accounts {
+		p := candidates[i]
+		if acct == nil || acct.Data == nil || !acct.Owner.Equals(ProgramID) {
+			continue
+		}
+
+		p.state, err = DecodeLbPair(acct.Data.GetBinary())
+		if err != nil {
+			ig.logger.Debug("failed to decode lb pair - skipping", zap.String("address", p.data.Address), zap.Error(err))
+			continue
+		}
+
+		if p.state.TokenXMint.String() != p.data.MintX || p.state.TokenYMint.String() != p.data.MintY {
+			ig.logger.Debug("lb pair mints do not match api mints - skipping", zap.String("address", p.data.Address))
+			continue
+		}
+
+		pairs = append(pairs, p)
+		for _, mint := range []solana.PublicKey{p.state.TokenXMint, p.state.TokenYMint} {
+			if _, found := mintSet[mint]; !found {
+				mintSet[mint] = struct{}{}
+				mints = append(mints, mint)
+			}
+		}
+	}
+
+	// lb pairs do not store the decimals of their tokens, so they are read from the mint accounts.
+	mintAccounts, err := solanarpc.ChunkedGetMultipleAccounts(ctx, ig.logger, ig.client, mints, solanarpc.DefaultRequestChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals := make(map[solana.PublicKey]uint64, len(mints))
+	for i, acct := range mintAccounts {
+		d, err := solanarpc.MintDecimals(acct)
+		if err != nil {
+			ig.logger.Debug("failed to decode mint - skipping", zap.String("mint", mints[i].String()), zap.Error(err))
+			continue
+		}
+		decimals[mints[i]] = d
+	}
+
+	pms := make([]provider.CreateProviderMarket, 0, len(pairs))
+	for _, p := range pairs {
+		pm, err := ig.toProviderMarket(p, decimals)
+		if err != nil {
+			ig.logger.Debug("failed to create provider market - skipping", zap.String("address", p.data.Address), zap.Error(err))
+			continue
+		}
+
+		pms = append(pms, pm)
+	}
+
+	// the api only reports volume in USD, so the quote volume, which the generator filters by, is derived
+	// from the USD prices of the quotes.
+	usdPrices := ingesters.QuoteUSDPrices(pms)
+	for i, pm := range pms {
+		usdPrice, found := usdPrices[strings.ToUpper(pm.Create.TargetQuote)]
+		if !found {
+			ig.logger.Debug("no usd price for quote - quote volume is unknown", zap.String("market", pm.Create.OffChainTicker))
+			continue
+		}
+		pms[i].Create.QuoteVolume = pm.Create.UsdVolume / usdPrice
+	}
+
+	ig.logger.Info("fetched data", zap.Int("markets", len(pms)))
+
+	return pms, nil
+}
+
+func (ig *Ingester) toProviderMarket(p pair, decimals map[solana.PublicKey]uint64) (provider.CreateProviderMarket, error) {
+	decimalsX, foundX := decimals[p.state.TokenXMint]
+	decimalsY, foundY := decimals[p.state.TokenYMint]
+	if !foundX || !foundY {
+		return provider.CreateProviderMarket{}, fmt.Errorf("missing token decimals")
+	}
+
+	refPrice := referencePrice(p.state.ActiveID, p.state.BinStep, decimalsX, decimalsY)
+	if math.IsInf(refPrice, 0) || math.IsNaN(refPrice) || refPrice == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid reference price %f", refPrice)
+	}
+
+	meta := TickerMetadata{
+		BaseTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: p.state.ReserveX.String(),
+			TokenDecimals:     decimalsX,
+		},
+		QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: p.state.ReserveY.String(),
+			TokenDecimals:     decimalsY,
+		},
+		LbPairAddress: p.data.Address,
+	}
+	if err := meta.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	bz, err := json.Marshal(meta)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to marshal provider market metadata: %w", err)
+	}
+
+	// pair names are formatted as BASE-QUOTE.
+	nameSplit := strings.Split(p.data.Name, "-")
+	if len(nameSplit) != 2 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid pair name %q", p.data.Name)
+	}
+
+	targetBase, err := symbols.ToCanonicalTickerString(ig.aliases, Name, nameSplit[0])
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	targetQuote, err := symbols.ToCanonicalTickerString(ig.aliases, Name, nameSplit[1])
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	targetBaseOffchain := strings.ToUpper(strings.Join([]string{targetBase, Name, p.data.MintX},
+		types.DefiTickerDelimiter))
+	targetQuoteOffchain := strings.ToUpper(strings.Join([]string{targetQuote, Name, p.data.MintY},
+		types.DefiTickerDelimiter))
+
+	cp := connecttypes.NewCurrencyPair(targetBaseOffchain, targetQuoteOffchain)
+	if err := cp.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:       targetBase,
+			TargetQuote:      targetQuote,
+			OffChainTicker:   strings.Join([]string{targetBaseOffchain, targetQuoteOffchain}, types.TickerSeparator),
+			ProviderName:     ProviderName,
+			UsdVolume:        p.data.TradeVolume24H,
+			MetadataJSON:     bz,
+			ReferencePrice:   refPrice,
+			PositiveDepthTwo: p.liquidity / 2,
+			NegativeDepthTwo: p.liquidity / 2,
+		},
+		BaseAddress:  p.data.MintX,
+		QuoteAddress: p.data.MintY,
+	}
+
+	if err := pm.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid provider market: %w: %v", err, pm)
+	}
+
+	return pm, nil
+}
+
+// referencePrice returns the price of token X in token Y of the active bin,
+// (1 + binStep / 10000) ^ activeID, adjusted for the token decimals.
+func referencePrice(activeID int32, binStep uint16, decimalsX, decimalsY uint64) float64 {
+	price := math.Pow(1+float64(binStep)/10000, float64(activeID))
+	return price * math.Pow10(int(decimalsX)-int(decimalsY))
+}
@@ -0,0 +1,192 @@
+package meteora_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	mmtypes "github.com/dydxprotocol/slinky/x/marketmap/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	generatortypes "github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/meteora"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/meteora/mocks"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
+)
+
+var (
+	solMint  = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	usdcMint = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	jupMint  = solana.MustPublicKeyFromBase58("JUPyiwrYJFskUPiHa7hkeR8VUtAeFoSYbKedZNsDvCN")
+	reserveX = solana.MustPublicKeyFromBase58("EYj9xKw6ZszwpyNibHY7JD5o3QgTVrSdcBp1fMJhrR9o")
+	reserveY = solana.MustPublicKeyFromBase58("CoaxzEh8p5YyGLcj36Eo3cUThVJxeKCs7qvLAGDYwBcz")
+
+	solUsdcPair  = solana.MustPublicKeyFromBase58("5rCf1DM8LjKTw4YqhnoLcngyZYeNnQqztScTogYHAS6")
+	hiddenPair   = solana.MustPublicKeyFromBase58("BGm1tav58oGcsQJehL9WXBFXF7D27vZsKefj4xJKD5Y")
+	emptyPair    = solana.MustPublicKeyFromBase58("HTvjzsfX3yU6BUodCjZ5vZkUrAxMDTrBs3CJaq43ashR")
+	mismatchPair = solana.MustPublicKeyFromBase58("83v8iPyZihDEjDdY8RdZddyZNyUtXngz69Lgo9Kt5d6d")
+	jupSolPair   = solana.MustPublicKeyFromBase58("7qbRF6YsyGuLUVs6Y1q64bdVrfe4ZcUUz1JRdoVNUJnm")
+)
+
+// lbPairAccount encodes an lb pair account.
+func lbPairAccount(mintX, mintY solana.PublicKey, activeID int32, binStep uint16) []byte {
+	data := make([]byte, 904)
+	discriminator := sha256.Sum256([]byte("account:LbPair"))
+	copy(data, discriminator[:8])
+
+	binary.LittleEndian.PutUint32(data[76:], uint32(activeID))
+	binary.LittleEndian.PutUint16(data[80:], binStep)
+	copy(data[88:], mintX[:])
+	copy(data[120:], mintY[:])
+	copy(data[152:], reserveX[:])
+	copy(data[184:], reserveY[:])
+
+	return data
+}
+
+// mintAccount encodes an SPL token mint account.
+func mintAccount(decimals uint8) []byte {
+	data := make([]byte, 82)
+	data[44] = decimals
+	return data
+}
+
+func pairData(address solana.PublicKey, liquidity string, hide bool) meteora.PairData {
+	return meteora.PairData{
+		Address:        address.String(),
+		Name:           "SOL-USDC",
+		MintX:          solMint.String(),
+		MintY:          usdcMint.String(),
+		ReserveX:       reserveX.String(),
+		ReserveY:       reserveY.String(),
+		BinStep:        100,
+		Liquidity:      liquidity,
+		TradeVolume24H: 250000,
+		Hide:           hide,
+	}
+}
+
+func newTestIngester(t *testing.T) *meteora.Ingester {
+	t.Helper()
+
+	jupSol := pairData(jupSolPair, "1000000", false)
+	jupSol.Name = "JUP-SOL"
+	jupSol.MintX = jupMint.String()
+	jupSol.MintY = solMint.String()
+
+	client := mocks.NewClient(t)
+	client.On("Pairs", mock.Anything).Return(meteora.Pairs{
+		pairData(solUsdcPair, "1000000", false),
+		pairData(hiddenPair, "1000000", true),
+		pairData(emptyPair, "0", false),
+		pairData(mismatchPair, "1000000", false),
+		jupSol,
+	}, nil)
+	client.On("ValidateClientConfiguration").Return(nil)
+	client.On("GetMultipleAccounts", mock.Anything, []solana.PublicKey{solUsdcPair, mismatchPair, jupSolPair}).Return([]*rpc.Account{
+		{Owner: meteora.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(lbPairAccount(solMint, usdcMint, 10, 100))},
+		{Owner: meteora.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(lbPairAccount(usdcMint, solMint, 10, 100))},
+		{Owner: meteora.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(lbPairAccount(jupMint, solMint, -100, 100))},
+	}, nil)
+	client.On("GetMultipleAccounts", mock.Anything, []solana.PublicKey{solMint, usdcMint, jupMint}).Return([]*rpc.Account{
+		{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(mintAccount(9))},
+		{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(mintAccount(6))},
+		{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(mintAccount(6))},
+	}, nil)
+
+	return meteora.NewWithClient(zap.NewNop(), nil, client)
+}
+
+func TestIngester(t *testing.T) {
+	ig := newTestIngester(t)
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 2)
+
+	market := markets[0]
+	require.Equal(t, "SOL", market.Create.TargetBase)
+	require.Equal(t, "USDC", market.Create.TargetQuote)
+	require.Equal(t, meteora.ProviderName, market.Create.ProviderName)
+	require.Equal(t, "SOL,METEORA,SO11111111111111111111111111111111111111112/USDC,METEORA,EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V",
+		market.Create.OffChainTicker)
+	// (1 + 100 / 10000) ^ 10, adjusted for 9 SOL and 6 USDC decimals.
+	require.InDelta(t, math.Pow(1.01, 10)*1000, market.Create.ReferencePrice, 1e-9)
+	require.Equal(t, 250000.0, market.Create.UsdVolume)
+	// the volume of USD quoted markets is the same in the quote.
+	require.Equal(t, 250000.0, market.Create.QuoteVolume)
+	require.Equal(t, 500000.0, market.Create.NegativeDepthTwo)
+
+	var meta meteora.TickerMetadata
+	require.NoError(t, json.Unmarshal(market.Create.MetadataJSON, &meta))
+	require.Equal(t, meteora.TickerMetadata{
+		BaseTokenVault:  connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: reserveX.String(), TokenDecimals: 9},
+		QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: reserveY.String(), TokenDecimals: 6},
+		LbPairAddress:   solUsdcPair.String(),
+	}, meta)
+}
+
+func TestIngesterQuoteVolume(t *testing.T) {
+	ig := newTestIngester(t)
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 2)
+
+	// the quote volume of JUP/SOL is its USD volume in SOL, priced by the SOL/USDC market.
+	jupSol := markets[1]
+	require.Equal(t, "JUP", jupSol.Create.TargetBase)
+	require.Equal(t, "SOL", jupSol.Create.TargetQuote)
+	require.InDelta(t, 250000/markets[0].Create.ReferencePrice, jupSol.Create.QuoteVolume, 1e-9)
+
+	// the markets pass the generator's quote volume filter.
+	cfg := config.GenerateConfig{
+		Quotes: map[string]config.QuoteConfig{
+			"USDC": {MinProviderVolume: 100000},
+			"SOL":  {MinProviderVolume: 100},
+		},
+	}
+	feeds := make(generatortypes.Feeds, 0, len(markets))
+	for _, market := range markets {
+		ticker := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair(market.Create.TargetBase, market.Create.TargetQuote)}
+		feeds = append(feeds, generatortypes.NewFeed(ticker, mmtypes.ProviderConfig{Name: market.Create.ProviderName},
+			market.Create.QuoteVolume, market.Create.UsdVolume, market.Create.ReferencePrice, mmutypes.LiquidityInfo{},
+			mmutypes.CoinMarketCapInfo{}))
+	}
+
+	transformed, dropped, err := transformer.PruneByQuoteVolume()(context.Background(), zap.NewNop(), cfg, feeds, mmtypes.MarketMap{})
+	require.NoError(t, err)
+	require.Empty(t, dropped)
+	require.Len(t, transformed, 2)
+}
+
+func TestDecodeLbPair(t *testing.T) {
+	data := lbPairAccount(solMint, usdcMint, -1897, 10)
+
+	pair, err := meteora.DecodeLbPair(data)
+	require.NoError(t, err)
+	require.Equal(t, meteora.LbPair{
+		ActiveID:   -1897,
+		BinStep:    10,
+		TokenXMint: solMint,
+		TokenYMint: usdcMint,
+		ReserveX:   reserveX,
+		ReserveY:   reserveY,
+	}, pair)
+
+	_, err = meteora.DecodeLbPair(data[:100])
+	require.Error(t, err)
+
+	data[0]++
+	_, err = meteora.DecodeLbPair(data)
+	require.ErrorContains(t, err, "not an lb pair")
+}
@@ -0,0 +1,204 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	meteora "github.com/skip-mev/connect-mmu/market-indexer/ingesters/meteora"
+
+	rpc "github.com/gagliardetto/solana-go/rpc"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+// Client is an autogenerated mock type for the Client type
+type Client struct {
+	mock.Mock
+}
+
+type Client_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Client) EXPECT() *Client_Expecter {
+	return &Client_Expecter{mock: &_m.Mock}
+}
+
+// GetMultipleAccounts provides a mock function with given fields: ctx, accounts
+func (_m *Client) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
+	ret := _m.Called(ctx, accounts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultipleAccounts")
+	}
+
+	var r0 []*rpc.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []solana.PublicKey) ([]*rpc.Account, error)); ok {
+		return rf(ctx, accounts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []solana.PublicKey) []*rpc.Account); ok {
+		r0 = rf(ctx, accounts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*rpc.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []solana.PublicKey) error); ok {
+		r1 = rf(ctx, accounts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetMultipleAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultipleAccounts'
+type Client_GetMultipleAccounts_Call struct {
+	*mock.Call
+}
+
+// GetMultipleAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accounts []solana.PublicKey
+func (_e *Client_Expecter) GetMultipleAccounts(ctx interface{}, accounts interface{}) *Client_GetMultipleAccounts_Call {
+	return &Client_GetMultipleAccounts_Call{Call: _e.mock.On("GetMultipleAccounts", ctx, accounts)}
+}
+
+func (_c *Client_GetMultipleAccounts_Call) Run(run func(ctx context.Context, accounts []solana.PublicKey)) *Client_GetMultipleAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]solana.PublicKey))
+	})
+	return _c
+}
+
+func (_c *Client_GetMultipleAccounts_Call) Return(_a0 []*rpc.Account, _a1 error) *Client_GetMultipleAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetMultipleAccounts_Call) RunAndReturn(run func(context.Context, []solana.PublicKey) ([]*rpc.Account, error)) *Client_GetMultipleAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pairs provides a mock function with given fields: ctx
+func (_m *Client) Pairs(ctx context.Context) (meteora.Pairs, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pairs")
+	}
+
+	var r0 meteora.Pairs
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (meteora.Pairs, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) meteora.Pairs); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(meteora.Pairs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Pairs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pairs'
+type Client_Pairs_Call struct {
+	*mock.Call
+}
+
+// Pairs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) Pairs(ctx interface{}) *Client_Pairs_Call {
+	return &Client_Pairs_Call{Call: _e.mock.On("Pairs", ctx)}
+}
+
+func (_c *Client_Pairs_Call) Run(run func(ctx context.Context)) *Client_Pairs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Pairs_Call) Return(_a0 meteora.Pairs, _a1 error) *Client_Pairs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Pairs_Call) RunAndReturn(run func(context.Context) (meteora.Pairs, error)) *Client_Pairs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateClientConfiguration provides a mock function with no fields
+func (_m *Client) ValidateClientConfiguration() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateClientConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_ValidateClientConfiguration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateClientConfiguration'
+type Client_ValidateClientConfiguration_Call struct {
+	*mock.Call
+}
+
+// ValidateClientConfiguration is a helper method to define mock.On call
+func (_e *Client_Expecter) ValidateClientConfiguration() *Client_ValidateClientConfiguration_Call {
+	return &Client_ValidateClientConfiguration_Call{Call: _e.mock.On("ValidateClientConfiguration")}
+}
+
+func (_c *Client_ValidateClientConfiguration_Call) Run(run func()) *Client_ValidateClientConfiguration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_ValidateClientConfiguration_Call) Return(_a0 error) *Client_ValidateClientConfiguration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ValidateClientConfiguration_Call) RunAndReturn(run func() error) *Client_ValidateClientConfiguration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
+	mock := &Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
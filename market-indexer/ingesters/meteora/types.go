@@ -0,0 +1,126 @@
+package meteora
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+)
+
+// Pairs is an alias for an array of PairData objects.
+type Pairs []PairData
+
+// PairData is a single pair returned by the DLMM pair API.
+//
+// https://dlmm-api.meteora.ag/pair/all
+//
+// Ex:
+//
+//	{
+//	  "address": "5rCf1DM8LjKTw4YqhnoLcngyZYeNnQqztScTogYHAS6",
+//	  "name": "SOL-USDC",
+//	  "mint_x": "So11111111111111111111111111111111111111112",
+//	  "mint_y": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+//	  "reserve_x": "EYj9xKw6ZszwpyNibHY7JD5o3QgTVrSdcBp1fMJhrR9o",
+//	  "reserve_y": "CoaxzEh8p5YyGLcj36Eo3cUThVJxeKCs7qvLAGDYwBcz",
+//	  "bin_step": 4,
+//	  "liquidity": "7396591.53",
+//	  "trade_volume_24h": 121360281.79,
+//	  "current_price": 143.21,
+//	  "hide": false
+//	}
+type PairData struct {
+	Address  string `json:"address"`
+	Name     string `json:"name"`
+	MintX    string `json:"mint_x"`
+	MintY    string `json:"mint_y"`
+	ReserveX string `json:"reserve_x"`
+	ReserveY string `json:"reserve_y"`
+	BinStep  uint16 `json:"bin_step"`
+	// Liquidity is the liquidity of the pair in USD.
+	Liquidity string `json:"liquidity"`
+	// TradeVolume24H is the 24 hour volume of the pair in USD.
+	TradeVolume24H float64 `json:"trade_volume_24h"`
+	CurrentPrice   float64 `json:"current_price"`
+	Hide           bool    `json:"hide"`
+}
+
+// ProgramID is the address of the Meteora DLMM program.
+var ProgramID = solana.MustPublicKeyFromBase58("LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo")
+
+var lbPairDiscriminator = solanarpc.AccountDiscriminator("LbPair")
+
+// lb pair account field offsets, see
+// https://github.com/MeteoraAg/dlmm-sdk/blob/main/programs/lb_clmm/src/state/lb_pair.rs
+const (
+	offsetActiveID   = 76
+	offsetBinStep    = 80
+	offsetTokenXMint = 88
+	offsetTokenYMint = 120
+	offsetReserveX   = 152
+	offsetReserveY   = 184
+	lbPairMinSize    = 216
+)
+
+// LbPair is the decoded state of an lb pair account that is relevant to pricing.
+type LbPair struct {
+	// ActiveID is the id of the bin the pair is currently trading in.
+	ActiveID   int32
+	BinStep    uint16
+	TokenXMint solana.PublicKey
+	TokenYMint solana.PublicKey
+	ReserveX   solana.PublicKey
+	ReserveY   solana.PublicKey
+}
+
+// DecodeLbPair decodes the data of an lb pair account.
+func DecodeLbPair(data []byte) (LbPair, error) {
+	if len(data) < lbPairMinSize {
+		return LbPair{}, fmt.Errorf("invalid lb pair account data length %d", len(data))
+	}
+
+	if !bytes.Equal(data[:8], lbPairDiscriminator) {
+		return LbPair{}, fmt.Errorf("account is not an lb pair")
+	}
+
+	return LbPair{
+		ActiveID:   int32(binary.LittleEndian.Uint32(data[offsetActiveID:])),
+		BinStep:    binary.LittleEndian.Uint16(data[offsetBinStep:]),
+		TokenXMint: solana.PublicKeyFromBytes(data[offsetTokenXMint : offsetTokenXMint+solana.PublicKeyLength]),
+		TokenYMint: solana.PublicKeyFromBytes(data[offsetTokenYMint : offsetTokenYMint+solana.PublicKeyLength]),
+		ReserveX:   solana.PublicKeyFromBytes(data[offsetReserveX : offsetReserveX+solana.PublicKeyLength]),
+		ReserveY:   solana.PublicKeyFromBytes(data[offsetReserveY : offsetReserveY+solana.PublicKeyLength]),
+	}, nil
+}
+
+// TickerMetadata is the metadata of a DLMM market. It follows the vault/decimals shape of Connect's
+// raydium metadata, with the lb pair account in place of the raydium AMM accounts.
+type TickerMetadata struct {
+	// BaseTokenVault is the metadata associated with the base token's reserve.
+	BaseTokenVault connectraydium.AMMTokenVaultMetadata `json:"base_token_vault"`
+
+	// QuoteTokenVault is the metadata associated with the quote token's reserve.
+	QuoteTokenVault connectraydium.AMMTokenVaultMetadata `json:"quote_token_vault"`
+
+	// LbPairAddress is the address of the lb pair account.
+	LbPairAddress string `json:"lb_pair_address"`
+}
+
+// ValidateBasic checks that the solana addresses are valid.
+func (metadata TickerMetadata) ValidateBasic() error {
+	for _, address := range []string{
+		metadata.BaseTokenVault.TokenVaultAddress,
+		metadata.QuoteTokenVault.TokenVaultAddress,
+		metadata.LbPairAddress,
+	} {
+		if _, err := solana.PublicKeyFromBase58(address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
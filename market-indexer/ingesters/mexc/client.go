@@ -29,9 +29,9 @@ type httpClient struct {
 
 // NewClient is the default implementation
 // of the Client using HTTP.
-func NewClient() Client {
+func NewClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -16,7 +16,7 @@ import (
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := mexc.NewWithClient(zap.NewNop(), client)
+	ingester := mexc.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return(nil, fmt.Errorf("error"))
@@ -28,7 +28,7 @@ func TestIngesterReturnsErrorOnTickersError(t *testing.T) {
 // Test that the ingester returns tickers from the response.
 func TestIngesterTickers(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := mexc.NewWithClient(zap.NewNop(), client)
+	ingester := mexc.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Tickers", ctx).Return([]mexc.TickerData{
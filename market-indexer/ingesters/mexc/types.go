@@ -46,7 +46,7 @@ type TickerData struct {
 	OpenPrice   string `json:"openPrice"`
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *TickerData) toProviderMarket(aliases *symbols.AliasRegistry) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.Symbol)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -62,11 +62,11 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert OpenPrice: %w", err)
 	}
 
-	targetBase, err := symbols.ToTickerString(base)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(quote)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
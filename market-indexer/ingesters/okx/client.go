@@ -3,6 +3,8 @@ package okx
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 
 	"github.com/skip-mev/connect-mmu/lib/http"
 )
@@ -10,6 +12,10 @@ import (
 const (
 	EndpointInstruments = "https://www.okx.com/api/v5/public/instruments?instType=SPOT"
 	EndpointTickers     = "https://www.okx.com/api/v5/market/tickers?instType=SPOT"
+	EndpointOrderBook   = "https://www.okx.com/api/v5/market/books"
+
+	// orderBookDepth is the number of levels fetched per side of an order book.
+	orderBookDepth = 400
 )
 
 var _ Client = &httpClient{}
@@ -24,6 +30,9 @@ type Client interface {
 
 	// Tickers returns all tickers on okx.
 	Tickers(context.Context) (TickersResponse, error)
+
+	// OrderBook returns the order book of an instrument on okx.
+	OrderBook(ctx context.Context, instID string) (OrderBookResponse, error)
 }
 
 type httpClient struct {
@@ -32,9 +41,9 @@ type httpClient struct {
 
 // NewClient is the default implementation
 // of the Client using HTTP.
-func NewClient() Client {
+func NewClient(opts ...http.ClientOption) Client {
 	return &httpClient{
-		client: http.NewClient(),
+		client: http.NewClient(opts...),
 	}
 }
 
@@ -77,3 +86,24 @@ func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
 
 	return tickersResp, nil
 }
+
+// OrderBook returns the order book of an instrument on the Okx API.
+func (h *httpClient) OrderBook(ctx context.Context, instID string) (OrderBookResponse, error) {
+	endpoint := fmt.Sprintf("%s?instId=%s&sz=%d", EndpointOrderBook, url.QueryEscape(instID), orderBookDepth)
+	resp, err := h.client.GetWithContext(ctx, endpoint)
+	if err != nil {
+		return OrderBookResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var bookResp OrderBookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bookResp); err != nil {
+		return OrderBookResponse{}, err
+	}
+
+	if err := bookResp.Validate(); err != nil {
+		return OrderBookResponse{}, err
+	}
+
+	return bookResp, nil
+}
@@ -6,6 +6,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
@@ -16,36 +19,60 @@ const (
 	ProviderName = Name + types.ProviderNameSuffixWS
 )
 
-var _ ingesters.Ingester = &Ingester{}
+var _ ingesters.OrderBookIngester = &Ingester{}
 
 // Ingester is the okx implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client Client
 }
 
 // New creates a new okx Ingester.
-func New(logger *zap.Logger) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: NewClient(),
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(opts...),
 	}
 }
 
 // NewWithClient creates a new okx Ingester with the given Client.
-func NewWithClient(logger *zap.Logger, client Client) *Ingester {
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
-		logger: logger.With(zap.String("ingester", Name)),
-		client: client,
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the OKX ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
 	}
 }
 
@@ -78,7 +105,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 			return nil, fmt.Errorf("ticker %s not found in ticker map", data.InstID)
 		}
 
-		pm, err := data.toProviderMarket(ticker)
+		pm, err := data.toProviderMarket(ig.aliases, ticker)
 		if err != nil {
 			ig.logger.Warn("ignoring instrument because can not convert to provider market",
 				zap.String("exchange", Name),
@@ -100,3 +127,18 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 func (ig *Ingester) Name() string {
 	return Name
 }
+
+// OrderBook returns a snapshot of the order book of the given market.
+func (ig *Ingester) OrderBook(ctx context.Context, market provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	resp, err := ig.client.OrderBook(ctx, market.Create.OffChainTicker)
+	if err != nil {
+		return ingesters.OrderBook{}, err
+	}
+
+	if len(resp.Data) == 0 {
+		return ingesters.OrderBook{}, fmt.Errorf("empty order book response for %s", market.Create.OffChainTicker)
+	}
+
+	book := resp.Data[0]
+	return ingesters.ParseOrderBook(book.Bids, book.Asks)
+}
@@ -8,15 +8,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if okx ingester's products endpoint returns an error, the
 // GetProviderMarkets method should return an error.
 func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := okx.NewWithClient(zap.NewNop(), client)
+	ingester := okx.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(okx.InstrumentsResponse{}, fmt.Errorf("error"))
@@ -28,7 +30,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 // Test that the ingester only returns markets that are SPOT.
 func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := okx.NewWithClient(zap.NewNop(), client)
+	ingester := okx.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	// Mock the products endpoint to return a mix of trading-enabled and disabled
@@ -110,7 +112,7 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 
 func TestIngesterErrorsOnTickersError(t *testing.T) {
 	client := mocks.NewClient(t)
-	ingester := okx.NewWithClient(zap.NewNop(), client)
+	ingester := okx.NewWithClient(zap.NewNop(), nil, client)
 
 	ctx := context.Background()
 	client.On("Instruments", ctx).Return(okx.InstrumentsResponse{}, nil)
@@ -120,3 +122,57 @@ func TestIngesterErrorsOnTickersError(t *testing.T) {
 	_, err := ingester.GetProviderMarkets(ctx)
 	require.Error(t, err)
 }
+
+func TestIngesterOrderBook(t *testing.T) {
+	market := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{OffChainTicker: "BTC-USDT"},
+	}
+
+	tests := []struct {
+		name    string
+		resp    okx.OrderBookResponse
+		want    ingesters.OrderBook
+		wantErr string
+	}{
+		{
+			name: "order book",
+			resp: okx.OrderBookResponse{
+				Response: okx.Response{Code: "0"},
+				Data: []okx.OrderBookData{
+					{
+						Asks: [][]any{{"64001", "0.5", "0", "1"}},
+						Bids: [][]any{{"64000", "1.5", "0", "2"}},
+					},
+				},
+			},
+			want: ingesters.OrderBook{
+				Bids: []ingesters.OrderBookLevel{{Price: 64000, Size: 1.5}},
+				Asks: []ingesters.OrderBookLevel{{Price: 64001, Size: 0.5}},
+			},
+		},
+		{
+			name:    "empty data",
+			resp:    okx.OrderBookResponse{Response: okx.Response{Code: "0"}},
+			wantErr: "empty order book response for BTC-USDT",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := mocks.NewClient(t)
+			ingester := okx.NewWithClient(zap.NewNop(), nil, client)
+
+			ctx := context.Background()
+			client.On("OrderBook", ctx, "BTC-USDT").Return(tc.resp, nil)
+
+			book, err := ingester.OrderBook(ctx, market)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, book)
+		})
+	}
+}
@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	okx "github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -79,6 +78,63 @@ func (_c *Client_Instruments_Call) RunAndReturn(run func(context.Context) (okx.I
 	return _c
 }
 
+// OrderBook provides a mock function with given fields: ctx, instID
+func (_m *Client) OrderBook(ctx context.Context, instID string) (okx.OrderBookResponse, error) {
+	ret := _m.Called(ctx, instID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderBook")
+	}
+
+	var r0 okx.OrderBookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (okx.OrderBookResponse, error)); ok {
+		return rf(ctx, instID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) okx.OrderBookResponse); ok {
+		r0 = rf(ctx, instID)
+	} else {
+		r0 = ret.Get(0).(okx.OrderBookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, instID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_OrderBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderBook'
+type Client_OrderBook_Call struct {
+	*mock.Call
+}
+
+// OrderBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instID string
+func (_e *Client_Expecter) OrderBook(ctx interface{}, instID interface{}) *Client_OrderBook_Call {
+	return &Client_OrderBook_Call{Call: _e.mock.On("OrderBook", ctx, instID)}
+}
+
+func (_c *Client_OrderBook_Call) Run(run func(ctx context.Context, instID string)) *Client_OrderBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) Return(_a0 okx.OrderBookResponse, _a1 error) *Client_OrderBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_OrderBook_Call) RunAndReturn(run func(context.Context, string) (okx.OrderBookResponse, error)) *Client_OrderBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: _a0
 func (_m *Client) Tickers(_a0 context.Context) (okx.TickersResponse, error) {
 	ret := _m.Called(_a0)
@@ -86,7 +86,7 @@ type InstrumentData struct {
 	State    string `json:"state"`
 }
 
-func (ir *InstrumentData) toProviderMarket(td TickerData) (provider.CreateProviderMarket, error) {
+func (ir *InstrumentData) toProviderMarket(aliases *symbols.AliasRegistry, td TickerData) (provider.CreateProviderMarket, error) {
 	volume, err := strconv.ParseFloat(td.VolCcy24H, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -97,11 +97,11 @@ func (ir *InstrumentData) toProviderMarket(td TickerData) (provider.CreateProvid
 		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert Open24h: %w", err)
 	}
 
-	targetBase, err := symbols.ToTickerString(ir.BaseCcy)
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, ir.BaseCcy)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
-	targetQuote, err := symbols.ToTickerString(ir.QuoteCcy)
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, ir.QuoteCcy)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
 	}
@@ -201,3 +201,40 @@ func (tr *TickersResponse) toMap() map[string]TickerData {
 
 	return m
 }
+
+// OrderBookResponse is a response to the OrderBook
+// API.
+type OrderBookResponse struct {
+	Response
+	Data []OrderBookData `json:"data"`
+}
+
+// Validate checks if the code is valid from the response and that it contains an order book.
+func (or *OrderBookResponse) Validate() error {
+	if or.Code != "0" {
+		return fmt.Errorf("invalid order book response: %s", or.Msg)
+	}
+
+	if len(or.Data) != 1 {
+		return fmt.Errorf("expected 1 order book, got %d", len(or.Data))
+	}
+
+	return nil
+}
+
+// OrderBookData is the data payload included in a
+// OrderBookResponse. Each level is [price, size, deprecated, number of orders].
+//
+// Docs: https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-order-book
+//
+// Ex.
+//
+//	{
+//	  "asks": [["41006.8", "0.60038921", "0", "1"]],
+//	  "bids": [["41006.3", "0.30178218", "0", "2"]],
+//	  "ts": "1629966436396"
+//	}
+type OrderBookData struct {
+	Asks [][]any `json:"asks"`
+	Bids [][]any `json:"bids"`
+}
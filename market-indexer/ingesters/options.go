@@ -0,0 +1,96 @@
+package ingesters
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// HTTPClientOptions returns the options of the API clients of an ingester configured with opts.
+func HTTPClientOptions(opts config.IngesterOptions, httpOpts ...http.ClientOption) ([]http.ClientOption, error) {
+	clientOpts := append(make([]http.ClientOption, 0, len(httpOpts)), httpOpts...)
+
+	if opts.BaseURL != "" {
+		baseURL, err := url.Parse(opts.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base url %q: %w", opts.BaseURL, err)
+		}
+		clientOpts = append(clientOpts, http.WithBaseURL(baseURL))
+	}
+
+	if opts.RequestTimeout > 0 {
+		clientOpts = append(clientOpts, http.WithTimeout(opts.RequestTimeout))
+	}
+
+	if opts.RateLimit > 0 {
+		clientOpts = append(clientOpts, http.WithRateLimit(opts.RateLimit))
+	}
+
+	if opts.Burst > 0 {
+		clientOpts = append(clientOpts, http.WithBurst(opts.Burst))
+	}
+
+	if opts.MaxInFlight > 0 {
+		clientOpts = append(clientOpts, http.WithMaxInFlight(opts.MaxInFlight))
+	}
+
+	return clientOpts, nil
+}
+
+// FilterMarkets returns the markets that pass the quote allowlist and minimum volume of opts. The minimum
+// volume is in USD: markets without a USD volume are converted from their quote volume with the USD prices
+// of QuoteUSDPrices, and markets whose quote has no USD price are dropped, since their volume is unknown.
+func FilterMarkets(opts config.IngesterOptions, markets []provider.CreateProviderMarket) []provider.CreateProviderMarket {
+	if len(opts.QuoteAllowlist) == 0 && opts.MinVolume == 0 {
+		return markets
+	}
+
+	allowedQuotes := make(map[string]struct{}, len(opts.QuoteAllowlist))
+	for _, quote := range opts.QuoteAllowlist {
+		allowedQuotes[strings.ToUpper(quote)] = struct{}{}
+	}
+
+	var usdPrices map[string]float64
+	if opts.MinVolume > 0 {
+		usdPrices = QuoteUSDPrices(markets)
+	}
+
+	filtered := make([]provider.CreateProviderMarket, 0, len(markets))
+	for _, market := range markets {
+		if len(allowedQuotes) > 0 {
+			if _, found := allowedQuotes[strings.ToUpper(market.Create.TargetQuote)]; !found {
+				continue
+			}
+		}
+
+		if opts.MinVolume > 0 {
+			volume, found := usdVolume(market, usdPrices)
+			if !found || volume < opts.MinVolume {
+				continue
+			}
+		}
+
+		filtered = append(filtered, market)
+	}
+
+	return filtered
+}
+
+// usdVolume returns the USD volume of a market, converting its quote volume with the given USD prices of
+// quotes if it has none. It returns false if the quote of a market without a USD volume has no USD price.
+func usdVolume(market provider.CreateProviderMarket, usdPrices map[string]float64) (float64, bool) {
+	if market.Create.UsdVolume > 0 {
+		return market.Create.UsdVolume, true
+	}
+
+	usdPrice, found := usdPrices[strings.ToUpper(market.Create.TargetQuote)]
+	if !found {
+		return 0, false
+	}
+
+	return market.Create.QuoteVolume * usdPrice, true
+}
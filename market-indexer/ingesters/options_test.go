@@ -0,0 +1,67 @@
+package ingesters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+func TestFilterMarkets(t *testing.T) {
+	markets := []provider.CreateProviderMarket{
+		{Create: provider.CreateProviderMarketParams{TargetBase: "BTC", TargetQuote: "USDT", OffChainTicker: "BTCUSDT", ReferencePrice: 50000, QuoteVolume: 5000}},
+		{Create: provider.CreateProviderMarketParams{TargetBase: "USDT", TargetQuote: "KRW", OffChainTicker: "USDT-KRW", ReferencePrice: 1000, QuoteVolume: 10_000_000}},
+		// 500 USDT is below the minimum volume.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ETH", TargetQuote: "USDT", OffChainTicker: "ETHUSDT", ReferencePrice: 2000, QuoteVolume: 500}},
+		// 0.1 BTC is 5,000 USD.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ETH", TargetQuote: "BTC", OffChainTicker: "ETHBTC", ReferencePrice: 0.04, QuoteVolume: 0.1}},
+		// 500,000 KRW is 500 USD.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ETH", TargetQuote: "KRW", OffChainTicker: "ETH-KRW", ReferencePrice: 2_000_000, QuoteVolume: 500_000}},
+		// the USD volume is used as is.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "SOL", TargetQuote: "OSMO", OffChainTicker: "SOLOSMO", UsdVolume: 2000}},
+		// the volume of a quote without a USD price is unknown.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ATOM", TargetQuote: "OSMO", OffChainTicker: "ATOMOSMO", QuoteVolume: 1_000_000}},
+	}
+
+	tcs := []struct {
+		name    string
+		opts    config.IngesterOptions
+		tickers []string
+	}{
+		{
+			name:    "no filters",
+			opts:    config.IngesterOptions{},
+			tickers: []string{"BTCUSDT", "USDT-KRW", "ETHUSDT", "ETHBTC", "ETH-KRW", "SOLOSMO", "ATOMOSMO"},
+		},
+		{
+			name:    "quote allowlist",
+			opts:    config.IngesterOptions{QuoteAllowlist: []string{"usdt"}},
+			tickers: []string{"BTCUSDT", "ETHUSDT"},
+		},
+		{
+			name:    "min volume in usd",
+			opts:    config.IngesterOptions{MinVolume: 1000},
+			tickers: []string{"BTCUSDT", "USDT-KRW", "ETHBTC", "SOLOSMO"},
+		},
+		{
+			name:    "quote allowlist and min volume",
+			opts:    config.IngesterOptions{QuoteAllowlist: []string{"KRW"}, MinVolume: 1000},
+			tickers: []string{"USDT-KRW"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := ingesters.FilterMarkets(tc.opts, markets)
+
+			tickers := make([]string, len(filtered))
+			for i, market := range filtered {
+				tickers[i] = market.Create.OffChainTicker
+			}
+			require.Equal(t, tc.tickers, tickers)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package orca
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+)
+
+const (
+	EndpointWhirlpools = "https://api.mainnet.orca.so/v1/whirlpool/list"
+)
+
+var _ Client = &client{}
+
+// Client is a simple client for accessing the Orca API and Solana nodes.
+//
+//go:generate mockery --name Client --filename mock_orca_client.go
+type Client interface {
+	// Whirlpools fetches all whirlpools from the orca api.
+	Whirlpools(ctx context.Context) (WhirlpoolsResponse, error)
+	// GetMultipleAccounts gets multiple accounts from a solana node.
+	GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error)
+	// ValidateClientConfiguration ensures client is configured correctly.
+	ValidateClientConfiguration() error
+}
+
+type client struct {
+	httpClient     *http.Client
+	multiRPCClient *solanarpc.MultiRPC
+}
+
+func NewClient(logger *zap.Logger, cfg config.MarketConfig, opts ...http.ClientOption) Client {
+	return &client{
+		httpClient:     http.NewClient(opts...),
+		multiRPCClient: solanarpc.NewMultiRPC(logger, cfg.RaydiumNodes, http.TransportOf(opts...)),
+	}
+}
+
+func (h *client) Whirlpools(ctx context.Context) (WhirlpoolsResponse, error) {
+	resp, err := h.httpClient.GetWithContext(ctx, EndpointWhirlpools)
+	if err != nil {
+		return WhirlpoolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var whirlpools WhirlpoolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&whirlpools); err != nil {
+		return WhirlpoolsResponse{}, err
+	}
+
+	return whirlpools, nil
+}
+
+func (h *client) ValidateClientConfiguration() error {
+	return h.multiRPCClient.ValidateClientConfiguration()
+}
+
+func (h *client) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
+	return h.multiRPCClient.GetMultipleAccounts(ctx, accounts)
+}
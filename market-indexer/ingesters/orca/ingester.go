@@ -0,0 +1,234 @@
+package orca
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name         = "orca"
+	ProviderName = Name + types.ProviderNameSuffixAPI
+
+	// CMCExchangeSlug is the slug of Orca on CoinMarketCap.
+	CMCExchangeSlug = "orca"
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// Ingester is the Orca Whirlpool implementation of a market data Ingester.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	client Client
+}
+
+// New creates a new orca Ingester.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, cfg config.MarketConfig, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(logger, cfg, opts...),
+	}
+}
+
+// NewWithClient creates a new orca Ingester with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Orca ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:            Name,
+		ProviderName:    ProviderName,
+		CMCExchangeSlug: CMCExchangeSlug,
+		Factory: func(
+			logger *zap.Logger,
+			cfg config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, cfg, clientOpts...), nil
+		},
+	}
+}
+
+// Name returns the Ingester's human-readable name.
+func (ig *Ingester) Name() string {
+	return Name
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	ig.logger.Info("fetching data")
+
+	resp, err := ig.client.Whirlpools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch whirlpools: %w", err)
+	}
+
+	whirlpools := make([]WhirlpoolData, 0, len(resp.Whirlpools))
+	addresses := make([]solana.PublicKey, 0, len(resp.Whirlpools))
+	for _, whirlpool := range resp.Whirlpools {
+		address, err := solana.PublicKeyFromBase58(whirlpool.Address)
+		if err != nil {
+			ig.logger.Debug("invalid whirlpool address - skipping", zap.String("address", whirlpool.Address), zap.Error(err))
+			continue
+		}
+		whirlpools = append(whirlpools, whirlpool)
+		addresses = append(addresses, address)
+	}
+
+	ig.logger.Info("whirlpools", zap.Int("amount", len(whirlpools)))
+
+	accounts, err := solanarpc.ChunkedGetMultipleAccounts(ctx, ig.logger, ig.client, addresses, solanarpc.DefaultRequestChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	pms := make([]provider.CreateProviderMarket, 0, len(accounts))
+	for i, acct := range accounts {
+		whirlpool := whirlpools[i]
+		if acct == nil || acct.Data == nil || !acct.Owner.Equals(ProgramID) {
+			continue
+		}
+
+		state, err := DecodeWhirlpool(acct.Data.GetBinary())
+		if err != nil {
+			ig.logger.Debug("failed to decode whirlpool - skipping", zap.String("address", whirlpool.Address), zap.Error(err))
+			continue
+		}
+
+		pm, err := ig.toProviderMarket(whirlpool, state)
+		if err != nil {
+			ig.logger.Debug("failed to create provider market - skipping", zap.String("address", whirlpool.Address), zap.Error(err))
+			continue
+		}
+
+		pms = append(pms, pm)
+	}
+
+	ig.logger.Info("fetched data", zap.Int("markets", len(pms)))
+
+	return pms, nil
+}
+
+func (ig *Ingester) toProviderMarket(whirlpool WhirlpoolData, state Whirlpool) (provider.CreateProviderMarket, error) {
+	if state.TokenMintA.String() != whirlpool.TokenA.Mint || state.TokenMintB.String() != whirlpool.TokenB.Mint {
+		return provider.CreateProviderMarket{}, fmt.Errorf("whirlpool mints %s/%s do not match api mints %s/%s",
+			state.TokenMintA, state.TokenMintB, whirlpool.TokenA.Mint, whirlpool.TokenB.Mint)
+	}
+
+	if state.Liquidity.Sign() == 0 || state.SqrtPrice.Sign() == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("whirlpool has no liquidity")
+	}
+
+	refPrice := referencePrice(state.SqrtPrice, whirlpool.TokenA.Decimals, whirlpool.TokenB.Decimals)
+	if math.IsInf(refPrice, 0) || refPrice == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid reference price %f", refPrice)
+	}
+
+	meta := TickerMetadata{
+		BaseTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: state.TokenVaultA.String(),
+			TokenDecimals:     whirlpool.TokenA.Decimals,
+		},
+		QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: state.TokenVaultB.String(),
+			TokenDecimals:     whirlpool.TokenB.Decimals,
+		},
+		WhirlpoolAddress: whirlpool.Address,
+	}
+	if err := meta.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	bz, err := json.Marshal(meta)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to marshal provider market metadata: %w", err)
+	}
+
+	targetBase, err := symbols.ToCanonicalTickerString(ig.aliases, Name, whirlpool.TokenA.Symbol)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	targetQuote, err := symbols.ToCanonicalTickerString(ig.aliases, Name, whirlpool.TokenB.Symbol)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	targetBaseOffchain := strings.ToUpper(strings.Join([]string{targetBase, Name, whirlpool.TokenA.Mint},
+		types.DefiTickerDelimiter))
+	targetQuoteOffchain := strings.ToUpper(strings.Join([]string{targetQuote, Name, whirlpool.TokenB.Mint},
+		types.DefiTickerDelimiter))
+
+	cp := connecttypes.NewCurrencyPair(targetBaseOffchain, targetQuoteOffchain)
+	if err := cp.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:       targetBase,
+			TargetQuote:      targetQuote,
+			OffChainTicker:   strings.Join([]string{targetBaseOffchain, targetQuoteOffchain}, types.TickerSeparator),
+			ProviderName:     ProviderName,
+			QuoteVolume:      whirlpool.VolumeDenominatedB.Day,
+			UsdVolume:        whirlpool.Volume.Day,
+			MetadataJSON:     bz,
+			ReferencePrice:   refPrice,
+			PositiveDepthTwo: whirlpool.TVL / 2,
+			NegativeDepthTwo: whirlpool.TVL / 2,
+		},
+		BaseAddress:  whirlpool.TokenA.Mint,
+		QuoteAddress: whirlpool.TokenB.Mint,
+	}
+
+	if err := pm.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid provider market: %w: %v", err, pm)
+	}
+
+	return pm, nil
+}
+
+// referencePrice returns the price of token A in token B from a Q64.64 square root price.
+func referencePrice(sqrtPrice *big.Int, decimalsA, decimalsB uint64) float64 {
+	sqrt := new(big.Float).Quo(new(big.Float).SetInt(sqrtPrice), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)))
+	price, _ := new(big.Float).Mul(sqrt, sqrt).Float64()
+	return price * math.Pow10(int(decimalsA)-int(decimalsB))
+}
@@ -0,0 +1,142 @@
+package orca_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/orca"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/orca/mocks"
+)
+
+var (
+	solMint  = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	usdcMint = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	vaultA   = solana.MustPublicKeyFromBase58("EUuUbDcafPrmVTD5M6qoJAoyyNbihBhugADAxRMn5he9")
+	vaultB   = solana.MustPublicKeyFromBase58("2WLWEuKDgkDUccTpbwYp1GToYktiSB1cXvreHUwiSUVP")
+
+	solUsdcPool   = "Czfq3xZZDmsdGdUyrNLtRhGc47cXcZtLG4crryfu44zE"
+	foreignPool   = "HJPjoWUrhoZzkNfRpHuieeFk9WcZWjwy6PBjZ81ngndJ"
+	mismatchPool  = "83v8iPyZihDEjDdY8RdZddyZNyUtXngz69Lgo9Kt5d6d"
+	solUsdcPoolPk = solana.MustPublicKeyFromBase58(solUsdcPool)
+)
+
+// whirlpoolAccount encodes a whirlpool account with the given price of token A in token B, in base units.
+func whirlpoolAccount(mintA, mintB solana.PublicKey, price float64, liquidity uint64) []byte {
+	data := make([]byte, 653)
+	discriminator := sha256.Sum256([]byte("account:Whirlpool"))
+	copy(data, discriminator[:8])
+
+	sqrtPrice := new(big.Float).SetPrec(256).Sqrt(big.NewFloat(price))
+	sqrtPrice.Mul(sqrtPrice, new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)))
+	sqrtPriceInt, _ := sqrtPrice.Int(nil)
+
+	copy(data[49:], uint128(new(big.Int).SetUint64(liquidity)))
+	copy(data[65:], uint128(sqrtPriceInt))
+	copy(data[101:], mintA[:])
+	copy(data[133:], vaultA[:])
+	copy(data[181:], mintB[:])
+	copy(data[213:], vaultB[:])
+
+	return data
+}
+
+// uint128 encodes i as a little endian u128.
+func uint128(i *big.Int) []byte {
+	bz := i.FillBytes(make([]byte, 16))
+	slices.Reverse(bz)
+	return bz
+}
+
+func whirlpoolData(address string) orca.WhirlpoolData {
+	return orca.WhirlpoolData{
+		Address:            address,
+		TokenA:             orca.TokenData{Mint: solMint.String(), Symbol: "SOL", Decimals: 9},
+		TokenB:             orca.TokenData{Mint: usdcMint.String(), Symbol: "USDC", Decimals: 6},
+		TVL:                1000000,
+		Volume:             orca.VolumeData{Day: 500000},
+		VolumeDenominatedB: orca.VolumeData{Day: 499000},
+	}
+}
+
+func TestIngester(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("Whirlpools", mock.Anything).Return(orca.WhirlpoolsResponse{
+		Whirlpools: []orca.WhirlpoolData{
+			whirlpoolData(solUsdcPool),
+			whirlpoolData(foreignPool),
+			whirlpoolData(mismatchPool),
+			whirlpoolData("not-an-address"),
+		},
+	}, nil)
+	client.On("ValidateClientConfiguration").Return(nil)
+	client.On("GetMultipleAccounts", mock.Anything, []solana.PublicKey{
+		solUsdcPoolPk,
+		solana.MustPublicKeyFromBase58(foreignPool),
+		solana.MustPublicKeyFromBase58(mismatchPool),
+	}).Return([]*rpc.Account{
+		// 1 SOL = 150 USDC, which is 0.15 in base units.
+		{Owner: orca.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(whirlpoolAccount(solMint, usdcMint, 0.15, 1e12))},
+		{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(whirlpoolAccount(solMint, usdcMint, 0.15, 1e12))},
+		{Owner: orca.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(whirlpoolAccount(usdcMint, solMint, 0.15, 1e12))},
+	}, nil)
+
+	ig := orca.NewWithClient(zap.NewNop(), nil, client)
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+
+	market := markets[0]
+	require.Equal(t, "SOL", market.Create.TargetBase)
+	require.Equal(t, "USDC", market.Create.TargetQuote)
+	require.Equal(t, orca.ProviderName, market.Create.ProviderName)
+	require.Equal(t, "SOL,ORCA,SO11111111111111111111111111111111111111112/USDC,ORCA,EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V",
+		market.Create.OffChainTicker)
+	require.InDelta(t, 150, market.Create.ReferencePrice, 1e-9)
+	require.Equal(t, 499000.0, market.Create.QuoteVolume)
+	require.Equal(t, 500000.0, market.Create.UsdVolume)
+	require.Equal(t, 500000.0, market.Create.PositiveDepthTwo)
+	require.Equal(t, solMint.String(), market.BaseAddress)
+	require.Equal(t, usdcMint.String(), market.QuoteAddress)
+
+	var meta orca.TickerMetadata
+	require.NoError(t, json.Unmarshal(market.Create.MetadataJSON, &meta))
+	require.Equal(t, orca.TickerMetadata{
+		BaseTokenVault:   connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vaultA.String(), TokenDecimals: 9},
+		QuoteTokenVault:  connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vaultB.String(), TokenDecimals: 6},
+		WhirlpoolAddress: solUsdcPool,
+	}, meta)
+}
+
+func TestDecodeWhirlpool(t *testing.T) {
+	data := whirlpoolAccount(solMint, usdcMint, 0.15, 42)
+
+	whirlpool, err := orca.DecodeWhirlpool(data)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), whirlpool.Liquidity.Int64())
+	require.Equal(t, solMint, whirlpool.TokenMintA)
+	require.Equal(t, vaultA, whirlpool.TokenVaultA)
+	require.Equal(t, usdcMint, whirlpool.TokenMintB)
+	require.Equal(t, vaultB, whirlpool.TokenVaultB)
+
+	sqrtPrice, _ := new(big.Float).SetInt(whirlpool.SqrtPrice).Float64()
+	require.InDelta(t, math.Sqrt(0.15)*math.Pow(2, 64), sqrtPrice, 1e6)
+
+	_, err = orca.DecodeWhirlpool(data[:100])
+	require.Error(t, err)
+
+	data[0]++
+	_, err = orca.DecodeWhirlpool(data)
+	require.ErrorContains(t, err, "not a whirlpool")
+}
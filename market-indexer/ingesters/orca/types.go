@@ -0,0 +1,145 @@
+package orca
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
+)
+
+// WhirlpoolsResponse is the response from the whirlpool list API.
+//
+// https://api.mainnet.orca.so/v1/whirlpool/list
+//
+// Ex:
+//
+//	{
+//	  "whirlpools": [
+//	    {
+//	      "address": "Czfq3xZZDmsdGdUyrNLtRhGc47cXcZtLG4crryfu44zE",
+//	      "tokenA": {
+//	        "mint": "So11111111111111111111111111111111111111112",
+//	        "symbol": "SOL",
+//	        "decimals": 9
+//	      },
+//	      "tokenB": {
+//	        "mint": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+//	        "symbol": "USDC",
+//	        "decimals": 6
+//	      },
+//	      "whitelisted": true,
+//	      "price": 143.25,
+//	      "tvl": 33017611.52,
+//	      "volume": {"day": 131066584.51},
+//	      "volumeDenominatedB": {"day": 131066584.51}
+//	    }
+//	  ]
+//	}
+type WhirlpoolsResponse struct {
+	Whirlpools []WhirlpoolData `json:"whirlpools"`
+}
+
+// WhirlpoolData is a single whirlpool returned by the whirlpool list API.
+type WhirlpoolData struct {
+	Address     string    `json:"address"`
+	TokenA      TokenData `json:"tokenA"`
+	TokenB      TokenData `json:"tokenB"`
+	Whitelisted bool      `json:"whitelisted"`
+	Price       float64   `json:"price"`
+	TVL         float64   `json:"tvl"`
+	// Volume is the volume of the whirlpool in USD.
+	Volume VolumeData `json:"volume"`
+	// VolumeDenominatedB is the volume of the whirlpool in token B.
+	VolumeDenominatedB VolumeData `json:"volumeDenominatedB"`
+}
+
+type TokenData struct {
+	Mint     string `json:"mint"`
+	Symbol   string `json:"symbol"`
+	Decimals uint64 `json:"decimals"`
+}
+
+type VolumeData struct {
+	Day float64 `json:"day"`
+}
+
+// ProgramID is the address of the Orca Whirlpool program.
+var ProgramID = solana.MustPublicKeyFromBase58("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+
+var whirlpoolDiscriminator = solanarpc.AccountDiscriminator("Whirlpool")
+
+// whirlpool account field offsets, see
+// https://github.com/orca-so/whirlpools/blob/main/programs/whirlpool/src/state/whirlpool.rs
+const (
+	offsetLiquidity   = 49
+	offsetSqrtPrice   = 65
+	offsetTokenMintA  = 101
+	offsetTokenVaultA = 133
+	offsetTokenMintB  = 181
+	offsetTokenVaultB = 213
+	whirlpoolMinSize  = 245
+)
+
+// Whirlpool is the decoded state of a whirlpool account that is relevant to pricing.
+type Whirlpool struct {
+	// Liquidity is the liquidity of the whirlpool's current tick range.
+	Liquidity *big.Int
+	// SqrtPrice is the square root of the price of token A in token B, as a Q64.64 value.
+	SqrtPrice   *big.Int
+	TokenMintA  solana.PublicKey
+	TokenVaultA solana.PublicKey
+	TokenMintB  solana.PublicKey
+	TokenVaultB solana.PublicKey
+}
+
+// DecodeWhirlpool decodes the data of a whirlpool account.
+func DecodeWhirlpool(data []byte) (Whirlpool, error) {
+	if len(data) < whirlpoolMinSize {
+		return Whirlpool{}, fmt.Errorf("invalid whirlpool account data length %d", len(data))
+	}
+
+	if !bytes.Equal(data[:8], whirlpoolDiscriminator) {
+		return Whirlpool{}, fmt.Errorf("account is not a whirlpool")
+	}
+
+	return Whirlpool{
+		Liquidity:   solanarpc.Uint128(data[offsetLiquidity:]),
+		SqrtPrice:   solanarpc.Uint128(data[offsetSqrtPrice:]),
+		TokenMintA:  solana.PublicKeyFromBytes(data[offsetTokenMintA : offsetTokenMintA+solana.PublicKeyLength]),
+		TokenVaultA: solana.PublicKeyFromBytes(data[offsetTokenVaultA : offsetTokenVaultA+solana.PublicKeyLength]),
+		TokenMintB:  solana.PublicKeyFromBytes(data[offsetTokenMintB : offsetTokenMintB+solana.PublicKeyLength]),
+		TokenVaultB: solana.PublicKeyFromBytes(data[offsetTokenVaultB : offsetTokenVaultB+solana.PublicKeyLength]),
+	}, nil
+}
+
+// TickerMetadata is the metadata of a whirlpool market. It follows the vault/decimals shape of Connect's
+// raydium metadata, with the whirlpool account in place of the raydium AMM accounts.
+type TickerMetadata struct {
+	// BaseTokenVault is the metadata associated with the base token's token vault.
+	BaseTokenVault connectraydium.AMMTokenVaultMetadata `json:"base_token_vault"`
+
+	// QuoteTokenVault is the metadata associated with the quote token's token vault.
+	QuoteTokenVault connectraydium.AMMTokenVaultMetadata `json:"quote_token_vault"`
+
+	// WhirlpoolAddress is the address of the whirlpool account.
+	WhirlpoolAddress string `json:"whirlpool_address"`
+}
+
+// ValidateBasic checks that the solana addresses are valid.
+func (metadata TickerMetadata) ValidateBasic() error {
+	for _, address := range []string{
+		metadata.BaseTokenVault.TokenVaultAddress,
+		metadata.QuoteTokenVault.TokenVaultAddress,
+		metadata.WhirlpoolAddress,
+	} {
+		if _, err := solana.PublicKeyFromBase58(address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
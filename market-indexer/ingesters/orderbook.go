@@ -0,0 +1,225 @@
+package ingesters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// depthRange is the range around the mid price that depth is measured in, i.e. ±2%.
+const depthRange = 0.02
+
+// usdQuotes are the quotes whose markets are priced in USD.
+var usdQuotes = map[string]struct{}{
+	"USD":  {},
+	"USDT": {},
+	"USDC": {},
+}
+
+// IsUSDQuote returns true if markets quoted in the given asset are priced in USD.
+func IsUSDQuote(quote string) bool {
+	_, found := usdQuotes[strings.ToUpper(quote)]
+	return found
+}
+
+// OrderBookIngester is an Ingester that can fetch order book snapshots of its markets.
+type OrderBookIngester interface {
+	Ingester
+
+	// OrderBook returns a snapshot of the order book of the given market.
+	OrderBook(ctx context.Context, market provider.CreateProviderMarket) (OrderBook, error)
+}
+
+// OrderBookLevel is a single price level of an order book.
+type OrderBookLevel struct {
+	Price float64
+	Size  float64
+}
+
+// OrderBook is a snapshot of an order book. Bids are ordered by descending price and asks by ascending price.
+type OrderBook struct {
+	Bids []OrderBookLevel
+	Asks []OrderBookLevel
+}
+
+// DepthTwo returns the notional of the bids within 2% below the mid price and of the asks within 2%
+// above it, in the quote asset.
+func (b OrderBook) DepthTwo() (negative, positive float64, err error) {
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return 0, 0, fmt.Errorf("order book has no bids or no asks")
+	}
+
+	mid := (b.Bids[0].Price + b.Asks[0].Price) / 2
+	if mid <= 0 {
+		return 0, 0, fmt.Errorf("invalid mid price %f", mid)
+	}
+
+	for _, bid := range b.Bids {
+		if bid.Price < mid*(1-depthRange) {
+			break
+		}
+		negative += bid.Price * bid.Size
+	}
+
+	for _, ask := range b.Asks {
+		if ask.Price > mid*(1+depthRange) {
+			break
+		}
+		positive += ask.Price * ask.Size
+	}
+
+	return negative, positive, nil
+}
+
+// ParseOrderBook parses an order book from its bids and asks, given as in ParseOrderBookLevels.
+func ParseOrderBook(bids, asks [][]any) (OrderBook, error) {
+	parsedBids, err := ParseOrderBookLevels(bids)
+	if err != nil {
+		return OrderBook{}, fmt.Errorf("invalid bids: %w", err)
+	}
+
+	parsedAsks, err := ParseOrderBookLevels(asks)
+	if err != nil {
+		return OrderBook{}, fmt.Errorf("invalid asks: %w", err)
+	}
+
+	return OrderBook{Bids: parsedBids, Asks: parsedAsks}, nil
+}
+
+// ParseOrderBookLevels parses levels given as [price, size, ...] arrays, where price and size are
+// decimal strings. Any further elements of a level are ignored.
+func ParseOrderBookLevels(levels [][]any) ([]OrderBookLevel, error) {
+	parsed := make([]OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("invalid order book level %v", level)
+		}
+
+		price, err := parseLevelValue(level[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid price of order book level %v: %w", level, err)
+		}
+
+		size, err := parseLevelValue(level[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of order book level %v: %w", level, err)
+		}
+
+		parsed = append(parsed, OrderBookLevel{Price: price, Size: size})
+	}
+
+	return parsed, nil
+}
+
+func parseLevelValue(v any) (float64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected a string, got %T", v)
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+// AddOrderBookDepth sets the ±2% depth of markets that have no depth from the order books of the ingester.
+// Order books are fetched at most rateLimit times per second. Depth is converted to USD using the reference
+// prices of the ingester's USD markets, so the depth of markets whose quote has no USD market is not set.
+// Markets whose order book cannot be fetched keep their depth.
+func AddOrderBookDepth(
+	ctx context.Context,
+	logger *zap.Logger,
+	ig OrderBookIngester,
+	markets []provider.CreateProviderMarket,
+	rateLimit float64,
+) ([]provider.CreateProviderMarket, error) {
+	usdPrices := QuoteUSDPrices(markets)
+	limiter := rate.NewLimiter(rate.Limit(rateLimit), 1)
+
+	count := 0
+	for i, market := range markets {
+		if market.Create.NegativeDepthTwo != 0 || market.Create.PositiveDepthTwo != 0 {
+			continue
+		}
+
+		usdPrice, found := usdPrices[strings.ToUpper(market.Create.TargetQuote)]
+		if !found {
+			logger.Debug("no usd price for quote - skipping order book",
+				zap.String("market", market.Create.OffChainTicker))
+			continue
+		}
+
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		book, err := ig.OrderBook(ctx, market)
+		if err != nil {
+			logger.Debug("failed to fetch order book - skipping", zap.String("market", market.Create.OffChainTicker), zap.Error(err))
+			continue
+		}
+
+		negative, positive, err := book.DepthTwo()
+		if err != nil {
+			logger.Debug("invalid order book - skipping", zap.String("market", market.Create.OffChainTicker), zap.Error(err))
+			continue
+		}
+
+		markets[i].Create.NegativeDepthTwo = negative * usdPrice
+		markets[i].Create.PositiveDepthTwo = positive * usdPrice
+		count++
+	}
+
+	logger.Info("added order book depth", zap.Int("markets", count))
+
+	return markets, nil
+}
+
+// QuoteUSDPrices returns the USD price of every quote of the given markets that is either a USD quote, the
+// base of a market with a USD quote, or the quote of a market with a USD base, e.g. KRW from USDT/KRW. If
+// several markets price an asset, the one with the most volume is used, and markets with a USD quote are
+// preferred.
+func QuoteUSDPrices(markets []provider.CreateProviderMarket) map[string]float64 {
+	prices := make(map[string]float64, len(usdQuotes))
+	for quote := range usdQuotes {
+		prices[quote] = 1
+	}
+
+	// the volume of these markets is in USD, whether it is reported in the quote or in USD.
+	volumes := make(map[string]float64)
+	for _, market := range markets {
+		base := strings.ToUpper(market.Create.TargetBase)
+		if !IsUSDQuote(market.Create.TargetQuote) || IsUSDQuote(base) || market.Create.ReferencePrice <= 0 {
+			continue
+		}
+		volume := max(market.Create.QuoteVolume, market.Create.UsdVolume)
+		if _, found := prices[base]; found && volume <= volumes[base] {
+			continue
+		}
+		prices[base] = market.Create.ReferencePrice
+		volumes[base] = volume
+	}
+
+	// the volume of these markets is in their quote, so it is only compared between markets of the same quote.
+	inverted := make(map[string]float64)
+	for _, market := range markets {
+		quote := strings.ToUpper(market.Create.TargetQuote)
+		if !IsUSDQuote(market.Create.TargetBase) || IsUSDQuote(quote) || market.Create.ReferencePrice <= 0 {
+			continue
+		}
+		if _, found := volumes[quote]; found {
+			continue
+		}
+		if _, found := inverted[quote]; found && market.Create.QuoteVolume <= inverted[quote] {
+			continue
+		}
+		prices[quote] = 1 / market.Create.ReferencePrice
+		inverted[quote] = market.Create.QuoteVolume
+	}
+
+	return prices
+}
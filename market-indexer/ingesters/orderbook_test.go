@@ -0,0 +1,153 @@
+package ingesters_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+var _ ingesters.OrderBookIngester = &staticOrderBookIngester{}
+
+// staticOrderBookIngester returns the order books of its markets by off-chain ticker.
+type staticOrderBookIngester struct {
+	books   map[string]ingesters.OrderBook
+	fetched []string
+}
+
+func (s *staticOrderBookIngester) GetProviderMarkets(_ context.Context) ([]provider.CreateProviderMarket, error) {
+	return nil, nil
+}
+
+func (s *staticOrderBookIngester) Name() string {
+	return "static"
+}
+
+func (s *staticOrderBookIngester) OrderBook(_ context.Context, market provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	s.fetched = append(s.fetched, market.Create.OffChainTicker)
+
+	book, found := s.books[market.Create.OffChainTicker]
+	if !found {
+		return ingesters.OrderBook{}, fmt.Errorf("no order book")
+	}
+	return book, nil
+}
+
+func TestOrderBookDepthTwo(t *testing.T) {
+	tcs := []struct {
+		name     string
+		book     ingesters.OrderBook
+		negative float64
+		positive float64
+		err      bool
+	}{
+		{
+			name: "levels outside of 2% are excluded",
+			book: ingesters.OrderBook{
+				Bids: []ingesters.OrderBookLevel{{Price: 99, Size: 1}, {Price: 98.5, Size: 2}, {Price: 97, Size: 100}},
+				Asks: []ingesters.OrderBookLevel{{Price: 101, Size: 1}, {Price: 102, Size: 2}, {Price: 103, Size: 100}},
+			},
+			negative: 99 + 197,
+			positive: 101 + 204,
+		},
+		{
+			name: "empty side",
+			book: ingesters.OrderBook{
+				Bids: []ingesters.OrderBookLevel{{Price: 99, Size: 1}},
+			},
+			err: true,
+		},
+		{
+			name: "zero mid price",
+			book: ingesters.OrderBook{
+				Bids: []ingesters.OrderBookLevel{{Price: 0, Size: 1}},
+				Asks: []ingesters.OrderBookLevel{{Price: 0, Size: 1}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			negative, positive, err := tc.book.DepthTwo()
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tc.negative, negative, 1e-9)
+			require.InDelta(t, tc.positive, positive, 1e-9)
+		})
+	}
+}
+
+func TestParseOrderBookLevels(t *testing.T) {
+	levels, err := ingesters.ParseOrderBookLevels([][]any{{"1.5", "2"}, {"1.25", "10", float64(1688671659)}})
+	require.NoError(t, err)
+	require.Equal(t, []ingesters.OrderBookLevel{{Price: 1.5, Size: 2}, {Price: 1.25, Size: 10}}, levels)
+
+	_, err = ingesters.ParseOrderBookLevels([][]any{{"1.5"}})
+	require.Error(t, err)
+
+	_, err = ingesters.ParseOrderBookLevels([][]any{{1.5, "2"}})
+	require.Error(t, err)
+
+	_, err = ingesters.ParseOrderBookLevels([][]any{{"1.5", "x"}})
+	require.Error(t, err)
+}
+
+func TestAddOrderBookDepth(t *testing.T) {
+	book := ingesters.OrderBook{
+		Bids: []ingesters.OrderBookLevel{{Price: 99, Size: 10}},
+		Asks: []ingesters.OrderBookLevel{{Price: 101, Size: 10}},
+	}
+	ig := &staticOrderBookIngester{
+		books: map[string]ingesters.OrderBook{
+			"ETHUSDT": book,
+			"BTCETH":  book,
+		},
+	}
+
+	markets := []provider.CreateProviderMarket{
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ETH", TargetQuote: "USDT", OffChainTicker: "ETHUSDT", ReferencePrice: 2000}},
+		// quoted in ETH, so its depth is converted with the ETH/USDT price.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "BTC", TargetQuote: "ETH", OffChainTicker: "BTCETH", ReferencePrice: 20}},
+		// already has depth.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "SOL", TargetQuote: "USDT", OffChainTicker: "SOLUSDT", NegativeDepthTwo: 1, PositiveDepthTwo: 2}},
+		// has no USD price for its quote.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ATOM", TargetQuote: "OSMO", OffChainTicker: "ATOMOSMO"}},
+		// has no order book.
+		{Create: provider.CreateProviderMarketParams{TargetBase: "DOGE", TargetQuote: "USD", OffChainTicker: "DOGEUSD"}},
+	}
+
+	markets, err := ingesters.AddOrderBookDepth(context.Background(), zap.NewNop(), ig, markets, 1000)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ETHUSDT", "BTCETH", "DOGEUSD"}, ig.fetched)
+
+	require.InDelta(t, 990.0, markets[0].Create.NegativeDepthTwo, 1e-9)
+	require.InDelta(t, 1010.0, markets[0].Create.PositiveDepthTwo, 1e-9)
+	require.InDelta(t, 990.0*2000, markets[1].Create.NegativeDepthTwo, 1e-6)
+	require.InDelta(t, 1010.0*2000, markets[1].Create.PositiveDepthTwo, 1e-6)
+	require.Equal(t, 1.0, markets[2].Create.NegativeDepthTwo)
+	require.Equal(t, 2.0, markets[2].Create.PositiveDepthTwo)
+	require.Zero(t, markets[3].Create.NegativeDepthTwo)
+	require.Zero(t, markets[4].Create.NegativeDepthTwo)
+}
+
+func TestAddOrderBookDepthCancelled(t *testing.T) {
+	ig := &staticOrderBookIngester{}
+	markets := []provider.CreateProviderMarket{
+		{Create: provider.CreateProviderMarketParams{TargetBase: "ETH", TargetQuote: "USDT", OffChainTicker: "ETHUSDT"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ingesters.AddOrderBookDepth(ctx, zap.NewNop(), ig, markets, 1)
+	require.Error(t, err)
+}
@@ -3,21 +3,19 @@ package raydium
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"math/rand"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"go.uber.org/zap"
 
-	"github.com/skip-mev/connect-mmu/cmd/mmu/consts"
 	"github.com/skip-mev/connect-mmu/config"
-	"github.com/skip-mev/connect-mmu/lib/aws"
 	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
 )
 
 const (
-	EndpointPairs = "https://api.raydium.io/v2/main/pairs"
+	EndpointPairs     = "https://api.raydium.io/v2/main/pairs"
+	EndpointClmmPools = "https://api.raydium.io/v2/ammV3/ammPools"
 	//nolint:gosec
 	EndpointTokenMetadata = "https://token-list-api.solana.cloud/v1/list"
 )
@@ -30,6 +28,8 @@ var _ Client = &client{}
 type Client interface {
 	// Pairs fetches all pairs from the raydium api.
 	Pairs(ctx context.Context) (Pairs, error)
+	// ClmmPools fetches all concentrated liquidity pools from the raydium api.
+	ClmmPools(ctx context.Context) (ClmmPoolsResponse, error)
 	// TokenMetadata gets all token metadata from a solana node.
 	TokenMetadata(ctx context.Context) (TokenMetadataResponse, error)
 	// GetMultipleAccounts gets multiple accounts from a solana node.
@@ -40,65 +40,13 @@ type Client interface {
 
 type client struct {
 	httpClient     *http.Client
-	multiRPCClient multiRPC
+	multiRPCClient *solanarpc.MultiRPC
 }
 
-type multiRPC struct {
-	logger *zap.Logger
-
-	rpcs []*rpc.Client
-}
-
-func newMultiRPC(logger *zap.Logger, cfg config.MarketConfig) multiRPC {
-	mRPC := multiRPC{
-		logger: logger.Named("multi-rpc"),
-		rpcs:   make([]*rpc.Client, len(cfg.RaydiumNodes)),
-	}
-
-	if aws.IsLambda() {
-		mRPC.logger.Info("instantiating multi-rpc client for raydium with AWS keys")
-
-		apiKeySecretsMap, err := consts.GetOracleAPIKeySecretNames()
-		if err != nil {
-			mRPC.logger.Error("error getting oracle keys", zap.Error(err))
-			return mRPC
-		}
-
-		for i, node := range cfg.RaydiumNodes {
-			awsKey, ok := apiKeySecretsMap[node.Endpoint]
-			if !ok {
-				mRPC.logger.Error("oracle config does not contain key for endpoint", zap.String("endpoint", node.Endpoint))
-				continue
-			}
-
-			secret, err := aws.GetSecret(context.Background(), awsKey)
-			if err != nil {
-				mRPC.logger.Error("unable to find api-key - skipping raydium node", zap.String("endpoint", node.Endpoint), zap.Error(err))
-				continue
-			}
-
-			mRPC.logger.Info("successfully instantiated raydium node", zap.String("endpoint", node.Endpoint))
-			mRPC.rpcs[i] = rpc.NewWithHeaders(node.Endpoint, map[string]string{
-				"x-api-key": secret,
-			})
-		}
-
-		return mRPC
-	}
-
-	for i, node := range cfg.RaydiumNodes {
-		mRPC.rpcs[i] = rpc.NewWithHeaders(node.Endpoint, map[string]string{
-			"x-api-key": node.NodeKey,
-		})
-	}
-
-	return mRPC
-}
-
-func NewClient(logger *zap.Logger, cfg config.MarketConfig) Client {
+func NewClient(logger *zap.Logger, cfg config.MarketConfig, opts ...http.ClientOption) Client {
 	return &client{
-		httpClient:     http.NewClient(),
-		multiRPCClient: newMultiRPC(logger, cfg),
+		httpClient:     http.NewClient(opts...),
+		multiRPCClient: solanarpc.NewMultiRPC(logger, cfg.RaydiumNodes, http.TransportOf(opts...)),
 	}
 }
 
@@ -117,6 +65,21 @@ func (h *client) Pairs(ctx context.Context) (Pairs, error) {
 	return pairs, nil
 }
 
+func (h *client) ClmmPools(ctx context.Context) (ClmmPoolsResponse, error) {
+	resp, err := h.httpClient.GetWithContext(ctx, EndpointClmmPools)
+	if err != nil {
+		return ClmmPoolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var pools ClmmPoolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pools); err != nil {
+		return ClmmPoolsResponse{}, err
+	}
+
+	return pools, nil
+}
+
 func (h *client) TokenMetadata(ctx context.Context) (TokenMetadataResponse, error) {
 	resp, err := h.httpClient.GetWithContext(ctx, EndpointTokenMetadata)
 	if err != nil {
@@ -133,42 +96,9 @@ func (h *client) TokenMetadata(ctx context.Context) (TokenMetadataResponse, erro
 }
 
 func (h *client) ValidateClientConfiguration() error {
-	if len(h.multiRPCClient.rpcs) == 0 {
-		return fmt.Errorf("no raydium RPC nodes configured")
-	}
-
-	return nil
+	return h.multiRPCClient.ValidateClientConfiguration()
 }
 
 func (h *client) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
-	// choose random endpoint to use. pre-validate raydium client configuration with ValidateClientConfiguration.
-	cycleValue := len(h.multiRPCClient.rpcs)
-	i := rand.Intn(cycleValue)
-
-	for i < i+cycleValue {
-		rpcClient := h.multiRPCClient.rpcs[i%cycleValue]
-		accountsResp, err := rpcClient.GetMultipleAccountsWithOpts(ctx, accounts, &rpc.GetMultipleAccountsOpts{
-			Commitment: rpc.CommitmentProcessed,
-		})
-		if err != nil {
-			i++
-			continue
-		}
-
-		if accountsResp == nil || accountsResp.Value == nil {
-			i++
-			h.multiRPCClient.logger.Error("error getting multiple accounts", zap.String("error", "nil response"))
-			continue
-		}
-
-		if len(accountsResp.Value) != len(accounts) {
-			i++
-			h.multiRPCClient.logger.Error("error getting multiple accounts", zap.String("error", "invalid account number"))
-			continue
-		}
-
-		return accountsResp.Value, nil
-	}
-
-	return nil, fmt.Errorf("all rpc attempts failed %v", accounts)
+	return h.multiRPCClient.GetMultipleAccounts(ctx, accounts)
 }
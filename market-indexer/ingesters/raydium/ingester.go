@@ -4,8 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
-	"sync"
 
 	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
 	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
@@ -15,10 +16,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
 	"github.com/skip-mev/connect-mmu/lib/symbols"
 	"github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
 	raydium "github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium/generated/raydium_amm"
+	clmm "github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium/generated/raydium_clmm"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/solanarpc"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
@@ -27,34 +31,97 @@ const (
 	Name         = "raydium"
 	ProviderName = Name + types.ProviderNameSuffixAPI
 
+	// NameCLMM is the venue name of markets on Raydium concentrated liquidity pools.
+	NameCLMM         = "raydium_clmm"
+	ProviderNameCLMM = NameCLMM + types.ProviderNameSuffixAPI
+
 	// defaultRequestChunk is the size of the request that can be made to a solana node.
-	defaultRequestChunk = 100
+	defaultRequestChunk = solanarpc.DefaultRequestChunk
 
 	CMC_DEX_ID     = 1342 // raydium
 	CMC_NETWORK_ID = 16   // Solana
+)
 
+// reasons a pool is skipped while creating provider markets.
+const (
+	skipReasonNoAccount          = "account not found"
+	skipReasonUnsupportedProgram = "unsupported program"
+	skipReasonDecodeFailed       = "failed to decode account"
+	skipReasonInvalidMarket      = "invalid market"
 )
 
-var _ ingesters.Ingester = &Ingester{}
+var (
+	_ ingesters.Ingester     = &Ingester{}
+	_ ingesters.SkipReporter = &Ingester{}
+)
 
 // Ingester is the binance implementation of a market data Ingester.
 type Ingester struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
 
 	client    Client
 	cmcClient coinmarketcap.Client
+
+	// skipped counts the pools skipped by the last GetProviderMarkets call per reason.
+	skipped map[string]int
 }
 
 // New creates a new raydium Ingester.
-func New(logger *zap.Logger, cfg config.MarketConfig, cmcAPIKey string) *Ingester {
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, cfg config.MarketConfig, cmcAPIKey string, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	cmcConfig := cfg.CoinMarketCapConfig
+	cmcConfig.APIKey = cmcAPIKey
+
+	return &Ingester{
+		logger:    logger.With(zap.String("ingester", Name)),
+		aliases:   aliases,
+		client:    NewClient(logger, cfg, opts...),
+		cmcClient: coinmarketcap.NewClient(cmcConfig, http.WithTransport(http.TransportOf(opts...))),
+	}
+}
+
+// NewWithClient creates a new raydium Ingester with the given clients.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client, cmcClient coinmarketcap.Client) *Ingester {
 	if logger == nil {
 		panic("cannot set nil logger")
 	}
 
 	return &Ingester{
 		logger:    logger.With(zap.String("ingester", Name)),
-		client:    NewClient(logger, cfg),
-		cmcClient: coinmarketcap.NewHTTPClient(cmcAPIKey),
+		aliases:   aliases,
+		client:    client,
+		cmcClient: cmcClient,
+	}
+}
+
+// Registration returns the registration of the Raydium ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			cfg config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, cfg, cfg.CoinMarketCapConfig.APIKey, clientOpts...), nil
+		},
+		Venues: func(config.MarketConfig) []ingesters.Venue {
+			return []ingesters.Venue{
+				{Name: Name, ProviderName: ProviderName, CMCExchangeSlug: Name},
+				{Name: NameCLMM, ProviderName: ProviderNameCLMM, CMCExchangeSlug: Name},
+			}
+		},
 	}
 }
 
@@ -82,6 +149,14 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 		return nil, fmt.Errorf("could not fetch pairs: %w", err)
 	}
 
+	// clmm pools are listed by a separate endpoint, which must not take the AMM v4 markets down with it.
+	clmmPools, err := ig.client.ClmmPools(ctx)
+	if err != nil {
+		ig.logger.Error("could not fetch clmm pools - indexing AMM v4 pools only", zap.Bool("mmu_datadog", true), zap.Error(err))
+	} else {
+		pairs = appendClmmPools(pairs, clmmPools.Data)
+	}
+
 	ig.logger.Info("pairs", zap.Int("amount", len(pairs)))
 
 	respAccounts, err := ig.chunkedRequests(ctx, pairs, defaultRequestChunk)
@@ -92,94 +167,241 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 	pms := make([]provider.CreateProviderMarket, 0, len(respAccounts))
 
+	// skipped counts the pools that were skipped per reason.
+	skipped := make(map[string]int)
+	skip := func(pair PairData, reason string, err error) {
+		skipped[reason]++
+		ig.logger.Debug("skipping pool", zap.String("address", pair.AmmID), zap.String("name", pair.Name),
+			zap.String("reason", reason), zap.Error(err))
+	}
+
 	ig.logger.Info("creating db entries")
 	for i, acct := range respAccounts {
 		pair := pairs[i]
-		if acct == nil {
+		if acct == nil || acct.Data == nil {
+			skip(pair, skipReasonNoAccount, nil)
 			continue
 		}
 
-		targetBase, targetQuote, err := getTargets(pair, symbolMap)
-		if err != nil {
-			return nil, fmt.Errorf("unable to fetch target base and quote: %w", err)
-		}
-
-		var ammAccountData raydium.AmmInfo
-		if err := bin.NewBinDecoder(acct.Data.GetBinary()).Decode(&ammAccountData); err != nil {
-			continue
-		}
+		var pm provider.CreateProviderMarket
+		switch {
+		case acct.Owner.Equals(AmmV4ProgramID):
+			var ammInfo raydium.AmmInfo
+			if err := bin.NewBinDecoder(acct.Data.GetBinary()).Decode(&ammInfo); err != nil {
+				skip(pair, skipReasonDecodeFailed, err)
+				continue
+			}
 
-		// construct connect metadata and perform basic validation
-		meta := connectraydium.TickerMetadata{
-			BaseTokenVault: connectraydium.AMMTokenVaultMetadata{
-				TokenVaultAddress: ammAccountData.TokenCoin.String(),
-				TokenDecimals:     ammAccountData.CoinDecimals,
-			},
-			QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{
-				TokenVaultAddress: ammAccountData.TokenPc.String(),
-				TokenDecimals:     ammAccountData.PcDecimals,
-			},
-			AMMInfoAddress:    pair.AmmID,
-			OpenOrdersAddress: ammAccountData.OpenOrders.String(),
-		}
+			pm, err = ig.ammProviderMarket(pair, ammInfo, symbolMap)
+		case acct.Owner.Equals(ClmmProgramID):
+			var poolState clmm.PoolState
+			if err := bin.NewBinDecoder(acct.Data.GetBinary()).Decode(&poolState); err != nil {
+				skip(pair, skipReasonDecodeFailed, err)
+				continue
+			}
 
-		if err := meta.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("invalid token metadata: %w", err)
+			pm, err = ig.clmmProviderMarket(pair, poolState, symbolMap)
+		default:
+			skip(pair, skipReasonUnsupportedProgram, fmt.Errorf("account is owned by %s", acct.Owner))
+			continue
 		}
-
-		bz, err := json.Marshal(meta)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal provider market metadata: %w", err)
+			skip(pair, skipReasonInvalidMarket, err)
+			continue
 		}
 
-		ig.logger.Debug("pairs", zap.Any("meta", meta))
+		pms = append(pms, pm)
+	}
 
-		targetBase, err = symbols.ToTickerString(targetBase)
-		if err != nil {
-			ig.logger.Debug("failed to convert target base to ticker string - skipping", zap.Error(err))
-			continue
-		}
+	if len(skipped) > 0 {
+		ig.logger.Info("skipped pools", zap.Any("reasons", skipped))
+	}
+	ig.skipped = skipped
 
-		targetQuote, err = symbols.ToTickerString(targetQuote)
-		if err != nil {
-			ig.logger.Debug("failed to convert target quote to ticker string - skipping", zap.Error(err))
+	// the clmm api only reports volume in USD, so the quote volume, which the generator filters by, is derived
+	// from the USD prices of the quotes.
+	usdPrices := ingesters.QuoteUSDPrices(pms)
+	for i, pm := range pms {
+		if pm.Create.QuoteVolume != 0 || pm.Create.UsdVolume == 0 {
 			continue
 		}
-		targetBaseOffchain := strings.ToUpper(strings.Join([]string{targetBase, Name, pair.BaseMint},
-			types.DefiTickerDelimiter))
-		targetQuoteOffchain := strings.ToUpper(strings.Join([]string{targetQuote, Name, pair.QuoteMint},
-			types.DefiTickerDelimiter))
-
-		cp := connecttypes.NewCurrencyPair(targetBaseOffchain, targetQuoteOffchain)
-		if err := cp.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("invalid token metadata: %w", err)
+		usdPrice, found := usdPrices[strings.ToUpper(pm.Create.TargetQuote)]
+		if !found {
+			ig.logger.Debug("no usd price for quote - quote volume is unknown", zap.String("market", pm.Create.OffChainTicker))
+			continue
 		}
+		pms[i].Create.QuoteVolume = pm.Create.UsdVolume / usdPrice
+	}
 
-		pm := provider.CreateProviderMarket{
-			Create: provider.CreateProviderMarketParams{
-				TargetBase:       targetBase,
-				TargetQuote:      targetQuote,
-				OffChainTicker:   strings.Join([]string{targetBaseOffchain, targetQuoteOffchain}, types.TickerSeparator),
-				ProviderName:     ProviderName,
-				QuoteVolume:      pair.Volume24HQuote,
-				UsdVolume:        pair.Volume24H,
-				MetadataJSON:     bz,
-				ReferencePrice:   pair.Price,
-				PositiveDepthTwo: pair.Liquidity / 2,
-				NegativeDepthTwo: pair.Liquidity / 2,
-			},
-			BaseAddress:  pair.BaseMint,
-			QuoteAddress: pair.QuoteMint,
-		}
+	ig.logger.Info("fetched data", zap.Int("markets", len(pms)))
 
-		if err := pm.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("invalid provider market: %w: %v", err, pm)
-		}
+	return pms, nil
+}
 
-		pms = append(pms, pm)
+// ammProviderMarket creates the provider market of an AMM v4 pool.
+func (ig *Ingester) ammProviderMarket(
+	pair PairData,
+	ammInfo raydium.AmmInfo,
+	symbolMap map[string]string,
+) (provider.CreateProviderMarket, error) {
+	// construct connect metadata and perform basic validation
+	meta := connectraydium.TickerMetadata{
+		BaseTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: ammInfo.TokenCoin.String(),
+			TokenDecimals:     ammInfo.CoinDecimals,
+		},
+		QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: ammInfo.TokenPc.String(),
+			TokenDecimals:     ammInfo.PcDecimals,
+		},
+		AMMInfoAddress:    pair.AmmID,
+		OpenOrdersAddress: ammInfo.OpenOrders.String(),
 	}
 
-	return pms, nil
+	if err := meta.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	bz, err := json.Marshal(meta)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to marshal provider market metadata: %w", err)
+	}
+
+	ig.logger.Debug("pairs", zap.Any("meta", meta))
+
+	return ig.providerMarket(pair, Name, ProviderName, pair.Price, bz, symbolMap)
+}
+
+// clmmProviderMarket creates the provider market of a concentrated liquidity pool.
+func (ig *Ingester) clmmProviderMarket(
+	pair PairData,
+	poolState clmm.PoolState,
+	symbolMap map[string]string,
+) (provider.CreateProviderMarket, error) {
+	if poolState.TokenMint0.String() != pair.BaseMint || poolState.TokenMint1.String() != pair.QuoteMint {
+		return provider.CreateProviderMarket{}, fmt.Errorf("pool mints %s/%s do not match api mints %s/%s",
+			poolState.TokenMint0, poolState.TokenMint1, pair.BaseMint, pair.QuoteMint)
+	}
+
+	sqrtPrice := poolState.SqrtPriceX64.BigInt()
+	if poolState.Liquidity.BigInt().Sign() == 0 || sqrtPrice.Sign() == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("pool has no liquidity")
+	}
+
+	refPrice := clmmReferencePrice(sqrtPrice, uint64(poolState.MintDecimals0), uint64(poolState.MintDecimals1))
+	if math.IsInf(refPrice, 0) || refPrice == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid reference price %f", refPrice)
+	}
+
+	meta := ClmmTickerMetadata{
+		BaseTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: poolState.TokenVault0.String(),
+			TokenDecimals:     uint64(poolState.MintDecimals0),
+		},
+		QuoteTokenVault: connectraydium.AMMTokenVaultMetadata{
+			TokenVaultAddress: poolState.TokenVault1.String(),
+			TokenDecimals:     uint64(poolState.MintDecimals1),
+		},
+		PoolStateAddress: pair.AmmID,
+	}
+
+	if err := meta.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	bz, err := json.Marshal(meta)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to marshal provider market metadata: %w", err)
+	}
+
+	return ig.providerMarket(pair, NameCLMM, ProviderNameCLMM, refPrice, bz, symbolMap)
+}
+
+// providerMarket creates the provider market of a pair on the given venue.
+func (ig *Ingester) providerMarket(
+	pair PairData,
+	venue, providerName string,
+	refPrice float64,
+	metadata []byte,
+	symbolMap map[string]string,
+) (provider.CreateProviderMarket, error) {
+	targetBase, targetQuote, err := getTargets(pair, symbolMap)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("unable to fetch target base and quote: %w", err)
+	}
+
+	targetBase, err = symbols.ToCanonicalTickerString(ig.aliases, Name, targetBase)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert target base to ticker string: %w", err)
+	}
+
+	targetQuote, err = symbols.ToCanonicalTickerString(ig.aliases, Name, targetQuote)
+	if err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("failed to convert target quote to ticker string: %w", err)
+	}
+
+	targetBaseOffchain := strings.ToUpper(strings.Join([]string{targetBase, venue, pair.BaseMint},
+		types.DefiTickerDelimiter))
+	targetQuoteOffchain := strings.ToUpper(strings.Join([]string{targetQuote, venue, pair.QuoteMint},
+		types.DefiTickerDelimiter))
+
+	cp := connecttypes.NewCurrencyPair(targetBaseOffchain, targetQuoteOffchain)
+	if err := cp.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid token metadata: %w", err)
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:       targetBase,
+			TargetQuote:      targetQuote,
+			OffChainTicker:   strings.Join([]string{targetBaseOffchain, targetQuoteOffchain}, types.TickerSeparator),
+			ProviderName:     providerName,
+			QuoteVolume:      pair.Volume24HQuote,
+			UsdVolume:        pair.Volume24H,
+			MetadataJSON:     metadata,
+			ReferencePrice:   refPrice,
+			PositiveDepthTwo: pair.Liquidity / 2,
+			NegativeDepthTwo: pair.Liquidity / 2,
+		},
+		BaseAddress:  pair.BaseMint,
+		QuoteAddress: pair.QuoteMint,
+	}
+
+	if err := pm.ValidateBasic(); err != nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid provider market: %w: %v", err, pm)
+	}
+
+	return pm, nil
+}
+
+// clmmReferencePrice returns the price of token 0 in token 1 from a Q64.64 square root price.
+func clmmReferencePrice(sqrtPrice *big.Int, decimals0, decimals1 uint64) float64 {
+	sqrt := new(big.Float).Quo(new(big.Float).SetInt(sqrtPrice), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)))
+	price, _ := new(big.Float).Mul(sqrt, sqrt).Float64()
+	return price * math.Pow10(int(decimals0)-int(decimals1))
+}
+
+// appendClmmPools appends the concentrated liquidity pools that are not already listed in pairs.
+func appendClmmPools(pairs Pairs, pools []ClmmPoolData) Pairs {
+	listed := make(map[string]struct{}, len(pairs))
+	for _, pair := range pairs {
+		listed[pair.AmmID] = struct{}{}
+	}
+
+	for _, pool := range pools {
+		if _, found := listed[pool.ID]; found {
+			continue
+		}
+		listed[pool.ID] = struct{}{}
+		pairs = append(pairs, pool.PairData())
+	}
+
+	return pairs
+}
+
+// SkippedMarkets returns the number of pools skipped by the last GetProviderMarkets call, by reason.
+func (ig *Ingester) SkippedMarkets() map[string]int {
+	return ig.skipped
 }
 
 // Name returns the Ingester's human-readable name.
@@ -242,48 +464,14 @@ func getTargets(pair PairData, symbolMap map[string]string) (base, quote string,
 	return base, quote, nil
 }
 
-// chunkedRequests runs GetMultipleAccounts requests chunked and in parallel.  One GetMultipleAccounts request
-// is limited to the chunkSize.
+// chunkedRequests fetches the pool account of each pair.
 func (ig *Ingester) chunkedRequests(ctx context.Context, pairs Pairs, chunkSize int) ([]*rpc.Account, error) {
-	if err := ig.client.ValidateClientConfiguration(); err != nil {
-		return nil, fmt.Errorf("error validating raydium client: %w", err)
-	}
-
-	totalAccounts := len(pairs)
-	respAccounts := make([]*rpc.Account, totalAccounts)
-
-	var wg sync.WaitGroup
-
-	for i := 0; i < totalAccounts; i += chunkSize {
-		wg.Add(1)
-		go func(start int) {
-			defer wg.Done()
-
-			end := start + chunkSize
-			if end > totalAccounts {
-				end = totalAccounts
-			}
-
-			reqAccounts := make([]solana.PublicKey, end-start)
-			for j := range reqAccounts {
-				pair := pairs[start+j]
-				reqAccounts[j] = solana.MustPublicKeyFromBase58(pair.AmmID)
-			}
-
-			accountsResp, err := ig.client.GetMultipleAccounts(ctx, reqAccounts)
-			if err != nil {
-				ig.logger.Error("failed to query accounts", zap.Error(err))
-				return
-			}
-
-			for j, account := range accountsResp {
-				respAccounts[start+j] = account
-			}
-		}(i)
+	accounts := make([]solana.PublicKey, len(pairs))
+	for i, pair := range pairs {
+		accounts[i] = solana.MustPublicKeyFromBase58(pair.AmmID)
 	}
-	wg.Wait()
 
-	return respAccounts, nil
+	return solanarpc.ChunkedGetMultipleAccounts(ctx, ig.logger, ig.client, accounts, chunkSize)
 }
 
 var knownQuotes = []string{
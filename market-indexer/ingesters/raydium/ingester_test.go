@@ -0,0 +1,259 @@
+package raydium_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	connecttypes "github.com/dydxprotocol/slinky/pkg/types"
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	mmtypes "github.com/dydxprotocol/slinky/x/marketmap/types"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	generatortypes "github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap"
+	cmcmocks "github.com/skip-mev/connect-mmu/market-indexer/api/coinmarketcap/mocks"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium/generated/raydium_clmm"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium/mocks"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
+)
+
+var (
+	solMint    = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	usdcMint   = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	vault0     = solana.MustPublicKeyFromBase58("EUuUbDcafPrmVTD5M6qoJAoyyNbihBhugADAxRMn5he9")
+	vault1     = solana.MustPublicKeyFromBase58("2WLWEuKDgkDUccTpbwYp1GToYktiSB1cXvreHUwiSUVP")
+	openOrders = solana.MustPublicKeyFromBase58("HmiHHzq4Fym9e1D4qzLS6LDDM3tNsCTBPDWHTLZ763jY")
+
+	ammPool      = solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
+	clmmPool     = solana.MustPublicKeyFromBase58("2QdhepnKRTLjjSqPL1PtKNwqrUkoLee5Gqs8bvZhRdMv")
+	missingPool  = solana.MustPublicKeyFromBase58("HJPjoWUrhoZzkNfRpHuieeFk9WcZWjwy6PBjZ81ngndJ")
+	foreignPool  = solana.MustPublicKeyFromBase58("83v8iPyZihDEjDdY8RdZddyZNyUtXngz69Lgo9Kt5d6d")
+	corruptPool  = solana.MustPublicKeyFromBase58("5rCf1DM8LjKTw4YqhnoLcngyZYeNnQqztScTogYHAS6")
+	mismatchPool = solana.MustPublicKeyFromBase58("BGm1tav58oGcsQJehL9WXBFXF7D27vZsKefj4xJKD5Y")
+)
+
+func encode(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(v))
+	return buf.Bytes()
+}
+
+// ammInfoAccount encodes an amm v4 account. AMM v4 is not an anchor program, so its
+// accounts have no discriminator.
+func ammInfoAccount() []byte {
+	data := make([]byte, 752)
+	binary.LittleEndian.PutUint64(data[32:], 9)
+	binary.LittleEndian.PutUint64(data[40:], 6)
+	copy(data[336:], vault0[:])
+	copy(data[368:], vault1[:])
+	copy(data[496:], openOrders[:])
+
+	return data
+}
+
+// poolStateAccount encodes a clmm pool state with the given price of token 0 in token 1, in base units.
+func poolStateAccount(t *testing.T, mint0, mint1 solana.PublicKey, price float64) []byte {
+	t.Helper()
+
+	return encode(t, raydium_clmm.PoolState{
+		TokenMint0:    mint0,
+		TokenMint1:    mint1,
+		TokenVault0:   vault0,
+		TokenVault1:   vault1,
+		MintDecimals0: 9,
+		MintDecimals1: 6,
+		Liquidity:     bin.Uint128{Lo: 1e12},
+		SqrtPriceX64:  bin.Uint128{Lo: uint64(math.Sqrt(price) * math.Pow(2, 64))},
+	})
+}
+
+func pairData(address solana.PublicKey) raydium.PairData {
+	return raydium.PairData{
+		Name:           "SOL-USDC",
+		AmmID:          address.String(),
+		BaseMint:       solMint.String(),
+		QuoteMint:      usdcMint.String(),
+		Liquidity:      1000000,
+		Volume24H:      500000,
+		Volume24HQuote: 499000,
+		Price:          150,
+	}
+}
+
+func newCMCClient(t *testing.T) *cmcmocks.Client {
+	t.Helper()
+
+	cmcClient := cmcmocks.NewClient(t)
+	cmcClient.On("DexMarkets", mock.Anything, raydium.CMC_NETWORK_ID, raydium.CMC_DEX_ID).Return(coinmarketcap.DexMarketsResponse{
+		Data: []coinmarketcap.DexMarketsData{
+			{
+				BaseAssetSymbol:           "SOL",
+				BaseAssetContractAddress:  solMint.String(),
+				QuoteAssetSymbol:          "USDC",
+				QuoteAssetContractAddress: usdcMint.String(),
+			},
+		},
+	}, nil)
+
+	return cmcClient
+}
+
+func TestIngester(t *testing.T) {
+	cmcClient := newCMCClient(t)
+
+	client := mocks.NewClient(t)
+	client.On("Pairs", mock.Anything).Return(raydium.Pairs{
+		pairData(ammPool),
+		pairData(missingPool),
+		pairData(foreignPool),
+		pairData(corruptPool),
+	}, nil)
+	client.On("ClmmPools", mock.Anything).Return(raydium.ClmmPoolsResponse{
+		Data: []raydium.ClmmPoolData{
+			{
+				ID:    clmmPool.String(),
+				MintA: solMint.String(),
+				MintB: usdcMint.String(),
+				TVL:   2000000,
+				Day:   raydium.ClmmPeriodData{Volume: 800000},
+			},
+			{
+				ID:    mismatchPool.String(),
+				MintA: usdcMint.String(),
+				MintB: solMint.String(),
+				TVL:   2000000,
+			},
+			// listed pairs are not indexed twice.
+			{ID: ammPool.String()},
+		},
+	}, nil)
+	client.On("ValidateClientConfiguration").Return(nil)
+	client.On("GetMultipleAccounts", mock.Anything, []solana.PublicKey{
+		ammPool, missingPool, foreignPool, corruptPool, clmmPool, mismatchPool,
+	}).Return([]*rpc.Account{
+		{Owner: raydium.AmmV4ProgramID, Data: rpc.DataBytesOrJSONFromBytes(ammInfoAccount())},
+		nil,
+		{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(make([]byte, 752))},
+		{Owner: raydium.ClmmProgramID, Data: rpc.DataBytesOrJSONFromBytes(make([]byte, 100))},
+		// 1 SOL = 150 USDC, which is 0.15 in base units.
+		{Owner: raydium.ClmmProgramID, Data: rpc.DataBytesOrJSONFromBytes(poolStateAccount(t, solMint, usdcMint, 0.15))},
+		{Owner: raydium.ClmmProgramID, Data: rpc.DataBytesOrJSONFromBytes(poolStateAccount(t, solMint, usdcMint, 0.15))},
+	}, nil)
+
+	ig := raydium.NewWithClient(zap.NewNop(), nil, client, cmcClient)
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 2)
+
+	amm := markets[0]
+	require.Equal(t, raydium.ProviderName, amm.Create.ProviderName)
+	require.Equal(t, "SOL,RAYDIUM,SO11111111111111111111111111111111111111112/USDC,RAYDIUM,EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V",
+		amm.Create.OffChainTicker)
+	require.Equal(t, 150.0, amm.Create.ReferencePrice)
+	require.Equal(t, 499000.0, amm.Create.QuoteVolume)
+
+	var ammMeta connectraydium.TickerMetadata
+	require.NoError(t, json.Unmarshal(amm.Create.MetadataJSON, &ammMeta))
+	require.Equal(t, connectraydium.TickerMetadata{
+		BaseTokenVault:    connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vault0.String(), TokenDecimals: 9},
+		QuoteTokenVault:   connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vault1.String(), TokenDecimals: 6},
+		AMMInfoAddress:    ammPool.String(),
+		OpenOrdersAddress: openOrders.String(),
+	}, ammMeta)
+
+	clmm := markets[1]
+	require.Equal(t, "SOL", clmm.Create.TargetBase)
+	require.Equal(t, "USDC", clmm.Create.TargetQuote)
+	require.Equal(t, raydium.ProviderNameCLMM, clmm.Create.ProviderName)
+	require.Equal(t, "SOL,RAYDIUM_CLMM,SO11111111111111111111111111111111111111112/USDC,RAYDIUM_CLMM,EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V",
+		clmm.Create.OffChainTicker)
+	require.InDelta(t, 150, clmm.Create.ReferencePrice, 1e-6)
+	require.Equal(t, 800000.0, clmm.Create.UsdVolume)
+	// the api only reports the USD volume, which is the same in the USDC quote.
+	require.InDelta(t, 800000.0, clmm.Create.QuoteVolume, 1e-6)
+	require.Equal(t, 1000000.0, clmm.Create.PositiveDepthTwo)
+
+	var clmmMeta raydium.ClmmTickerMetadata
+	require.NoError(t, json.Unmarshal(clmm.Create.MetadataJSON, &clmmMeta))
+	require.Equal(t, raydium.ClmmTickerMetadata{
+		BaseTokenVault:   connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vault0.String(), TokenDecimals: 9},
+		QuoteTokenVault:  connectraydium.AMMTokenVaultMetadata{TokenVaultAddress: vault1.String(), TokenDecimals: 6},
+		PoolStateAddress: clmmPool.String(),
+	}, clmmMeta)
+
+	require.Equal(t, map[string]int{
+		"account not found":        1,
+		"unsupported program":      1,
+		"failed to decode account": 1,
+		"invalid market":           1,
+	}, ig.SkippedMarkets())
+
+	// the clmm market passes the generator's quote volume filter.
+	cfg := config.GenerateConfig{
+		Quotes: map[string]config.QuoteConfig{
+			"USDC": {MinProviderVolume: 100000},
+		},
+	}
+	ticker := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair(clmm.Create.TargetBase, clmm.Create.TargetQuote)}
+	feeds := generatortypes.Feeds{
+		generatortypes.NewFeed(ticker, mmtypes.ProviderConfig{Name: clmm.Create.ProviderName},
+			clmm.Create.QuoteVolume, clmm.Create.UsdVolume, clmm.Create.ReferencePrice, mmutypes.LiquidityInfo{},
+			mmutypes.CoinMarketCapInfo{}),
+	}
+
+	transformed, dropped, err := transformer.PruneByQuoteVolume()(context.Background(), zap.NewNop(), cfg, feeds, mmtypes.MarketMap{})
+	require.NoError(t, err)
+	require.Empty(t, dropped)
+	require.Len(t, transformed, 1)
+}
+
+func TestIngesterWithoutClmmPools(t *testing.T) {
+	cmcClient := newCMCClient(t)
+
+	client := mocks.NewClient(t)
+	client.On("Pairs", mock.Anything).Return(raydium.Pairs{pairData(ammPool)}, nil)
+	client.On("ClmmPools", mock.Anything).Return(raydium.ClmmPoolsResponse{}, errors.New("503 service unavailable"))
+	client.On("ValidateClientConfiguration").Return(nil)
+	client.On("GetMultipleAccounts", mock.Anything, []solana.PublicKey{ammPool}).Return([]*rpc.Account{
+		{Owner: raydium.AmmV4ProgramID, Data: rpc.DataBytesOrJSONFromBytes(ammInfoAccount())},
+	}, nil)
+
+	// the AMM v4 markets are still indexed.
+	ig := raydium.NewWithClient(zap.NewNop(), nil, client, cmcClient)
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	require.Equal(t, raydium.ProviderName, markets[0].Create.ProviderName)
+	require.Empty(t, ig.SkippedMarkets())
+}
+
+func TestDecodePoolState(t *testing.T) {
+	data := poolStateAccount(t, solMint, usdcMint, 0.15)
+	// pool state accounts are 1544 bytes on chain.
+	require.Len(t, data, 1544)
+
+	var poolState raydium_clmm.PoolState
+	require.NoError(t, bin.NewBinDecoder(data).Decode(&poolState))
+	require.Equal(t, solMint, poolState.TokenMint0)
+	require.Equal(t, usdcMint, poolState.TokenMint1)
+	require.Equal(t, vault0, poolState.TokenVault0)
+	require.Equal(t, uint8(9), poolState.MintDecimals0)
+
+	data[0]++
+	require.ErrorContains(t, bin.NewBinDecoder(data).Decode(&poolState), "wrong discriminator")
+}
@@ -27,6 +27,62 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// ClmmPools provides a mock function with given fields: ctx
+func (_m *Client) ClmmPools(ctx context.Context) (raydium.ClmmPoolsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClmmPools")
+	}
+
+	var r0 raydium.ClmmPoolsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (raydium.ClmmPoolsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) raydium.ClmmPoolsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(raydium.ClmmPoolsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ClmmPools_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClmmPools'
+type Client_ClmmPools_Call struct {
+	*mock.Call
+}
+
+// ClmmPools is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) ClmmPools(ctx interface{}) *Client_ClmmPools_Call {
+	return &Client_ClmmPools_Call{Call: _e.mock.On("ClmmPools", ctx)}
+}
+
+func (_c *Client_ClmmPools_Call) Run(run func(ctx context.Context)) *Client_ClmmPools_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_ClmmPools_Call) Return(_a0 raydium.ClmmPoolsResponse, _a1 error) *Client_ClmmPools_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ClmmPools_Call) RunAndReturn(run func(context.Context) (raydium.ClmmPoolsResponse, error)) *Client_ClmmPools_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMultipleAccounts provides a mock function with given fields: ctx, accounts
 func (_m *Client) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
 	ret := _m.Called(ctx, accounts)
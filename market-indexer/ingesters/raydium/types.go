@@ -1,5 +1,17 @@
 package raydium
 
+import (
+	connectraydium "github.com/dydxprotocol/slinky/providers/apis/defi/raydium"
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	// AmmV4ProgramID is the address of the Raydium AMM v4 program.
+	AmmV4ProgramID = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wGSUt1Mp8")
+	// ClmmProgramID is the address of the Raydium concentrated liquidity (CLMM) program.
+	ClmmProgramID = solana.MustPublicKeyFromBase58("CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK")
+)
+
 // Pairs is an alias for an array of PairData objects.
 type Pairs []PairData
 
@@ -68,6 +80,92 @@ type PairData struct {
 	Apr30D          float64 `json:"apr30d"`
 }
 
+// ClmmPoolsResponse is the type returned from the /ammV3/ammPools API on Raydium v2.
+type ClmmPoolsResponse struct {
+	Data []ClmmPoolData `json:"data"`
+}
+
+// ClmmPoolData is a single concentrated liquidity pool returned by the Raydium v2 API.
+//
+// https://api.raydium.io/v2/ammV3/ammPools
+//
+// Ex:
+//
+//	{
+//	  "id": "2QdhepnKRTLjjSqPL1PtKNwqrUkoLee5Gqs8bvZhRdMv",
+//	  "mintA": "So11111111111111111111111111111111111111112",
+//	  "mintB": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+//	  "mintDecimalsA": 9,
+//	  "mintDecimalsB": 6,
+//	  "tvl": 7384153.84,
+//	  "day": {
+//	    "volume": 41529372.51,
+//	    "volumeFee": 16611.74,
+//	    "priceMin": 141.02,
+//	    "priceMax": 146.93
+//	  }
+//	}
+type ClmmPoolData struct {
+	ID            string         `json:"id"`
+	MintA         string         `json:"mintA"`
+	MintB         string         `json:"mintB"`
+	VaultA        string         `json:"vaultA"`
+	VaultB        string         `json:"vaultB"`
+	MintDecimalsA uint64         `json:"mintDecimalsA"`
+	MintDecimalsB uint64         `json:"mintDecimalsB"`
+	TVL           float64        `json:"tvl"`
+	Day           ClmmPeriodData `json:"day"`
+}
+
+// ClmmPeriodData is the trading data of a concentrated liquidity pool over a period.
+type ClmmPeriodData struct {
+	// Volume is the volume of the pool over the period in USD.
+	Volume    float64 `json:"volume"`
+	VolumeFee float64 `json:"volumeFee"`
+	PriceMin  float64 `json:"priceMin"`
+	PriceMax  float64 `json:"priceMax"`
+}
+
+// PairData converts the pool to the PairData shape of AMM pairs. The pool's name is unknown,
+// so its symbols are resolved from its mints.
+func (p ClmmPoolData) PairData() PairData {
+	return PairData{
+		AmmID:     p.ID,
+		BaseMint:  p.MintA,
+		QuoteMint: p.MintB,
+		Liquidity: p.TVL,
+		Volume24H: p.Day.Volume,
+	}
+}
+
+// ClmmTickerMetadata is the metadata of a Raydium CLMM market. It follows the vault/decimals shape of
+// Connect's raydium metadata, with the pool state account in place of the AMM accounts.
+type ClmmTickerMetadata struct {
+	// BaseTokenVault is the metadata associated with the base token's vault.
+	BaseTokenVault connectraydium.AMMTokenVaultMetadata `json:"base_token_vault"`
+
+	// QuoteTokenVault is the metadata associated with the quote token's vault.
+	QuoteTokenVault connectraydium.AMMTokenVaultMetadata `json:"quote_token_vault"`
+
+	// PoolStateAddress is the address of the pool state account.
+	PoolStateAddress string `json:"pool_state_address"`
+}
+
+// ValidateBasic checks that the solana addresses are valid.
+func (metadata ClmmTickerMetadata) ValidateBasic() error {
+	for _, address := range []string{
+		metadata.BaseTokenVault.TokenVaultAddress,
+		metadata.QuoteTokenVault.TokenVaultAddress,
+		metadata.PoolStateAddress,
+	} {
+		if _, err := solana.PublicKeyFromBase58(address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type TokenMetadataResponse struct {
 	Content []Content `json:"content"`
 }
@@ -0,0 +1,165 @@
+package ingesters
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+)
+
+// ProviderNameUnknown is returned for ingesters that are not registered.
+const ProviderNameUnknown = "UNKNOWN"
+
+// Factory creates an Ingester from the market config and the ingester's own options. The ingester resolves
+// the symbols of its markets with aliases. httpOpts are applied to every http client of the ingester before its
+// own options, e.g. the transport of a cassette.
+type Factory func(
+	logger *zap.Logger,
+	cfg config.MarketConfig,
+	opts config.IngesterOptions,
+	aliases *symbols.AliasRegistry,
+	httpOpts ...http.ClientOption,
+) (Ingester, error)
+
+// Venue is a single market venue indexed by an ingester.
+type Venue struct {
+	// Name is the name the venue is referenced by.
+	Name string
+	// ProviderName is the connect provider name of markets on this venue.
+	ProviderName string
+	// CMCExchangeSlug is the slug of the venue's exchange on CoinMarketCap.
+	CMCExchangeSlug string
+}
+
+// Registration describes an ingester that can be created by a Registry.
+type Registration struct {
+	// Name is the name used to reference the ingester in the market config.
+	Name string
+	// ProviderName is the connect provider name of markets produced by the ingester.
+	ProviderName string
+	// CMCExchangeSlug is the slug of the ingester's exchange on CoinMarketCap. If empty, Name is used.
+	CMCExchangeSlug string
+	// Factory creates the ingester.
+	Factory Factory
+	// Venues optionally returns the venues indexed by the ingester for the given config, for ingesters
+	// that index several venues (e.g. one per DEX). If nil, the ingester indexes a single venue.
+	Venues func(cfg config.MarketConfig) []Venue
+}
+
+// GetVenues returns the venues indexed by the registered ingester for the given config.
+func (r Registration) GetVenues(cfg config.MarketConfig) []Venue {
+	if r.Venues != nil {
+		return r.Venues(cfg)
+	}
+
+	slug := r.CMCExchangeSlug
+	if slug == "" {
+		slug = r.Name
+	}
+
+	return []Venue{
+		{
+			Name:            r.Name,
+			ProviderName:    r.ProviderName,
+			CMCExchangeSlug: slug,
+		},
+	}
+}
+
+// Registry manages Ingester registrations.
+type Registry struct {
+	mu        sync.RWMutex
+	ingesters map[string]Registration
+}
+
+// NewRegistry creates a new Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		ingesters: make(map[string]Registration),
+	}
+}
+
+func (r *Registry) RegisterIngester(registration Registration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registration.Name == "" {
+		return errors.New("ingester name cannot be empty")
+	}
+
+	if registration.Factory == nil {
+		return errors.New("ingester factory cannot be nil: " + registration.Name)
+	}
+
+	if _, exists := r.ingesters[registration.Name]; exists {
+		return errors.New("ingester already registered: " + registration.Name)
+	}
+
+	r.ingesters[registration.Name] = registration
+	return nil
+}
+
+// Get returns the registration for the ingester with the given name.
+func (r *Registry) Get(name string) (Registration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registration, exists := r.ingesters[name]
+	return registration, exists
+}
+
+// CreateIngester creates the ingester with the given name, with the options it is configured with in cfg, the
+// given aliases, and the given http client options.
+func (r *Registry) CreateIngester(
+	logger *zap.Logger,
+	name string,
+	cfg config.MarketConfig,
+	aliases *symbols.AliasRegistry,
+	httpOpts ...http.ClientOption,
+) (Ingester, error) {
+	registration, exists := r.Get(name)
+	if !exists {
+		return nil, errors.New("unknown ingester: " + name)
+	}
+
+	opts, err := cfg.IngesterOptions(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for ingester %s: %w", name, err)
+	}
+
+	return registration.Factory(logger, cfg, opts, aliases, httpOpts...)
+}
+
+// ProviderName returns the provider name of the ingester with the given name, or
+// ProviderNameUnknown if no such ingester is registered.
+func (r *Registry) ProviderName(name string) string {
+	registration, exists := r.Get(name)
+	if !exists {
+		return ProviderNameUnknown
+	}
+
+	return registration.ProviderName
+}
+
+// Registrations returns all registrations sorted by name.
+func (r *Registry) Registrations() []Registration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registrations := make([]Registration, 0, len(r.ingesters))
+	for _, registration := range r.ingesters {
+		registrations = append(registrations, registration)
+	}
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+
+	return registrations
+}
@@ -0,0 +1,87 @@
+package ingesters_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+type testIngester struct{}
+
+func (testIngester) GetProviderMarkets(context.Context) ([]provider.CreateProviderMarket, error) {
+	return nil, nil
+}
+
+func (testIngester) Name() string {
+	return "test"
+}
+
+func testRegistration(name string) ingesters.Registration {
+	return ingesters.Registration{
+		Name:         name,
+		ProviderName: name + "_ws",
+		Factory: func(*zap.Logger, config.MarketConfig, config.IngesterOptions, *symbols.AliasRegistry, ...http.ClientOption) (ingesters.Ingester, error) {
+			return testIngester{}, nil
+		},
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := ingesters.NewRegistry()
+
+	require.NoError(t, r.RegisterIngester(testRegistration("b")))
+	require.NoError(t, r.RegisterIngester(testRegistration("a")))
+
+	t.Run("duplicate registration fails", func(t *testing.T) {
+		require.Error(t, r.RegisterIngester(testRegistration("a")))
+	})
+
+	t.Run("registration without a factory fails", func(t *testing.T) {
+		require.Error(t, r.RegisterIngester(ingesters.Registration{Name: "c"}))
+	})
+
+	t.Run("create registered ingester", func(t *testing.T) {
+		ig, err := r.CreateIngester(zap.NewNop(), "a", config.MarketConfig{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "test", ig.Name())
+	})
+
+	t.Run("create unknown ingester fails", func(t *testing.T) {
+		_, err := r.CreateIngester(zap.NewNop(), "unknown", config.MarketConfig{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("provider names", func(t *testing.T) {
+		require.Equal(t, "a_ws", r.ProviderName("a"))
+		require.Equal(t, ingesters.ProviderNameUnknown, r.ProviderName("unknown"))
+	})
+
+	t.Run("registrations are sorted by name", func(t *testing.T) {
+		registrations := r.Registrations()
+		require.Len(t, registrations, 2)
+		require.Equal(t, "a", registrations[0].Name)
+		require.Equal(t, "b", registrations[1].Name)
+	})
+}
+
+func TestRegistrationGetVenues(t *testing.T) {
+	t.Run("single venue defaults slug to name", func(t *testing.T) {
+		venues := testRegistration("a").GetVenues(config.MarketConfig{})
+		require.Equal(t, []ingesters.Venue{{Name: "a", ProviderName: "a_ws", CMCExchangeSlug: "a"}}, venues)
+	})
+
+	t.Run("single venue with slug", func(t *testing.T) {
+		registration := testRegistration("a")
+		registration.CMCExchangeSlug = "a-exchange"
+		venues := registration.GetVenues(config.MarketConfig{})
+		require.Equal(t, []ingesters.Venue{{Name: "a", ProviderName: "a_ws", CMCExchangeSlug: "a-exchange"}}, venues)
+	})
+}
@@ -0,0 +1,217 @@
+package solanarpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"math/rand"
+	nethttp "net/http"
+	"slices"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/cmd/mmu/consts"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/aws"
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+// DefaultRequestChunk is the number of accounts that can be requested from a solana node at once.
+const DefaultRequestChunk = 100
+
+// AccountsClient is a client that can fetch accounts from solana nodes.
+type AccountsClient interface {
+	// GetMultipleAccounts gets multiple accounts from a solana node.
+	GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error)
+	// ValidateClientConfiguration ensures client is configured correctly.
+	ValidateClientConfiguration() error
+}
+
+var _ AccountsClient = &MultiRPC{}
+
+// MultiRPC fetches accounts from one of several solana nodes, falling back to the others on failure.
+type MultiRPC struct {
+	logger *zap.Logger
+
+	rpcs []*rpc.Client
+}
+
+// NewMultiRPC creates a MultiRPC for the given nodes. When running in AWS Lambda, node API keys are read
+// from AWS secrets instead of the config. If transport is not nil (e.g. for recording or replaying requests),
+// the nodes are requested with it instead of solana-go's transport.
+func NewMultiRPC(logger *zap.Logger, nodes []config.RaydiumNodeConfig, transport nethttp.RoundTripper) *MultiRPC {
+	mRPC := &MultiRPC{
+		logger: logger.Named("multi-rpc"),
+		rpcs:   make([]*rpc.Client, 0, len(nodes)),
+	}
+
+	if aws.IsLambda() {
+		mRPC.logger.Info("instantiating multi-rpc client with AWS keys")
+
+		apiKeySecretsMap, err := consts.GetOracleAPIKeySecretNames()
+		if err != nil {
+			mRPC.logger.Error("error getting oracle keys", zap.Error(err))
+			return mRPC
+		}
+
+		for _, node := range nodes {
+			awsKey, ok := apiKeySecretsMap[node.Endpoint]
+			if !ok {
+				mRPC.logger.Error("oracle config does not contain key for endpoint", zap.String("endpoint", node.Endpoint))
+				continue
+			}
+
+			secret, err := aws.GetSecret(context.Background(), awsKey)
+			if err != nil {
+				mRPC.logger.Error("unable to find api-key - skipping solana node", zap.String("endpoint", node.Endpoint), zap.Error(err))
+				continue
+			}
+
+			mRPC.logger.Info("successfully instantiated solana node", zap.String("endpoint", node.Endpoint))
+			mRPC.rpcs = append(mRPC.rpcs, newRPCClient(node.Endpoint, map[string]string{
+				"x-api-key": secret,
+			}, transport))
+		}
+
+		return mRPC
+	}
+
+	for _, node := range nodes {
+		mRPC.rpcs = append(mRPC.rpcs, newRPCClient(node.Endpoint, map[string]string{
+			"x-api-key": node.NodeKey,
+		}, transport))
+	}
+
+	return mRPC
+}
+
+// newRPCClient creates a solana RPC client. If transport is not nil, the client uses it instead of
+// solana-go's transport.
+func newRPCClient(endpoint string, headers map[string]string, transport nethttp.RoundTripper) *rpc.Client {
+	if transport == nil {
+		return rpc.NewWithHeaders(endpoint, headers)
+	}
+
+	return rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(endpoint, &jsonrpc.RPCClientOpts{
+		HTTPClient:    http.StandardClient(http.WithTransport(transport)),
+		CustomHeaders: headers,
+	}))
+}
+
+// ValidateClientConfiguration returns an error if no solana nodes are configured.
+func (m *MultiRPC) ValidateClientConfiguration() error {
+	if len(m.rpcs) == 0 {
+		return fmt.Errorf("no solana RPC nodes configured")
+	}
+
+	return nil
+}
+
+// GetMultipleAccounts gets the accounts from a random node, trying every other node if the request fails.
+func (m *MultiRPC) GetMultipleAccounts(ctx context.Context, accounts []solana.PublicKey) ([]*rpc.Account, error) {
+	// choose random endpoint to use. pre-validate the client configuration with ValidateClientConfiguration.
+	cycleValue := len(m.rpcs)
+	start := rand.Intn(cycleValue)
+
+	for i := start; i < start+cycleValue; i++ {
+		rpcClient := m.rpcs[i%cycleValue]
+		accountsResp, err := rpcClient.GetMultipleAccountsWithOpts(ctx, accounts, &rpc.GetMultipleAccountsOpts{
+			Commitment: rpc.CommitmentProcessed,
+		})
+		if err != nil {
+			continue
+		}
+
+		if accountsResp == nil || accountsResp.Value == nil {
+			m.logger.Error("error getting multiple accounts", zap.String("error", "nil response"))
+			continue
+		}
+
+		if len(accountsResp.Value) != len(accounts) {
+			m.logger.Error("error getting multiple accounts", zap.String("error", "invalid account number"))
+			continue
+		}
+
+		return accountsResp.Value, nil
+	}
+
+	return nil, fmt.Errorf("all rpc attempts failed %v", accounts)
+}
+
+// ChunkedGetMultipleAccounts runs GetMultipleAccounts requests chunked and in parallel. One GetMultipleAccounts
+// request is limited to the chunkSize. Accounts are returned in the order they were requested, and accounts
+// that could not be fetched are nil.
+func ChunkedGetMultipleAccounts(
+	ctx context.Context,
+	logger *zap.Logger,
+	client AccountsClient,
+	accounts []solana.PublicKey,
+	chunkSize int,
+) ([]*rpc.Account, error) {
+	if err := client.ValidateClientConfiguration(); err != nil {
+		return nil, fmt.Errorf("error validating solana client: %w", err)
+	}
+
+	totalAccounts := len(accounts)
+	respAccounts := make([]*rpc.Account, totalAccounts)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < totalAccounts; i += chunkSize {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+
+			end := min(start+chunkSize, totalAccounts)
+
+			accountsResp, err := client.GetMultipleAccounts(ctx, accounts[start:end])
+			if err != nil {
+				logger.Error("failed to query accounts", zap.Error(err))
+				return
+			}
+
+			for j, account := range accountsResp {
+				respAccounts[start+j] = account
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return respAccounts, nil
+}
+
+// mintDecimalsOffset is the offset of the decimals in an SPL token mint account, after the
+// mint authority (COption<Pubkey>) and the supply (u64).
+const mintDecimalsOffset = 44
+
+// MintDecimals decodes the decimals of an SPL token mint account.
+func MintDecimals(account *rpc.Account) (uint64, error) {
+	if account == nil || account.Data == nil {
+		return 0, fmt.Errorf("mint account not found")
+	}
+
+	data := account.Data.GetBinary()
+	if len(data) <= mintDecimalsOffset {
+		return 0, fmt.Errorf("invalid mint account data length %d", len(data))
+	}
+
+	return uint64(data[mintDecimalsOffset]), nil
+}
+
+// AccountDiscriminator returns the 8 byte discriminator that prefixes the data of an Anchor account.
+func AccountDiscriminator(name string) []byte {
+	h := sha256.Sum256([]byte("account:" + name))
+	return h[:8]
+}
+
+// Uint128 decodes a little endian u128.
+func Uint128(bz []byte) *big.Int {
+	be := slices.Clone(bz[:16])
+	slices.Reverse(be)
+	return new(big.Int).SetBytes(be)
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
+)
+
+// UnscaleProviderMarket converts a market whose base is a fixed multiple of an underlying asset
+// (e.g. 1000SATS/USDT) into a market on the underlying asset (SATS/USDT). The reference price is
+// converted to the price of one unit of the underlying asset, and the multiplier is recorded in the
+// market's metadata JSON. Markets without a scaled base are returned unchanged.
+func UnscaleProviderMarket(pm provider.CreateProviderMarket) (provider.CreateProviderMarket, error) {
+	multiplier, base, ok := mmutypes.ParseScaledBase(pm.Create.TargetBase)
+	if !ok {
+		return pm, nil
+	}
+
+	bz, err := json.Marshal(mmutypes.ScaledMarketMetadata{
+		Multiplier: multiplier,
+		ScaledBase: pm.Create.TargetBase,
+	})
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	pm.Create.TargetBase = base
+	pm.Create.ReferencePrice /= float64(multiplier)
+	pm.Create.MetadataJSON = bz
+
+	return pm, pm.ValidateBasic()
+}
@@ -0,0 +1,268 @@
+package uniswapv3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+const (
+	// batchSize is the maximum number of calls sent in a single JSON-RPC batch request, and of addresses
+	// whose logs are queried by a single eth_getLogs request.
+	batchSize = 100
+	// maxDecimals is the largest number of decimals of an ERC20 token, whose decimals() returns a uint8.
+	maxDecimals = 255
+)
+
+var (
+	// poolCreatedTopic is the topic of the factory's
+	// PoolCreated(address indexed token0, address indexed token1, uint24 indexed fee, int24 tickSpacing, address pool) event.
+	poolCreatedTopic = crypto.Keccak256Hash([]byte("PoolCreated(address,address,uint24,int24,address)"))
+	// swapTopic is the topic of a pool's
+	// Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick) event.
+	swapTopic = crypto.Keccak256Hash([]byte("Swap(address,address,int256,int256,uint160,uint128,int24)"))
+
+	selectorSlot0     = selector("slot0()")
+	selectorLiquidity = selector("liquidity()")
+	selectorSymbol    = selector("symbol()")
+	selectorDecimals  = selector("decimals()")
+)
+
+var _ Client = &client{}
+
+// Client is a client for reading Uniswap v3 factory and pool state from an EVM JSON-RPC node.
+//
+//go:generate mockery --name Client --filename mock_uniswapv3_client.go
+type Client interface {
+	// BlockNumber returns the latest block number.
+	BlockNumber(ctx context.Context) (uint64, error)
+	// PoolCreatedEvents returns the PoolCreated events emitted by the factory in the inclusive block range.
+	PoolCreatedEvents(ctx context.Context, factory string, fromBlock, toBlock uint64) ([]PoolCreatedEvent, error)
+	// SwapEvents returns the Swap events emitted by the given pools in the inclusive block range.
+	SwapEvents(ctx context.Context, pools []string, fromBlock, toBlock uint64) ([]SwapEvent, error)
+	// PoolStates returns the current state of each pool, in the order of the given addresses. Pools whose
+	// state cannot be read are returned with a nil SqrtPriceX96 and Liquidity.
+	PoolStates(ctx context.Context, pools []string) ([]PoolState, error)
+	// Tokens returns the ERC20 metadata of each token, in the order of the given addresses. Tokens whose
+	// metadata cannot be read, or whose decimals are not a uint8, are returned with an empty symbol.
+	Tokens(ctx context.Context, tokens []string) ([]Token, error)
+}
+
+type client struct {
+	endpoint string
+	opts     []http.ClientOption
+}
+
+// NewClient returns a Client for the given JSON-RPC endpoint.
+func NewClient(endpoint string, opts ...http.ClientOption) Client {
+	return &client{
+		endpoint: endpoint,
+		opts:     opts,
+	}
+}
+
+func (c *client) dial(ctx context.Context) (*rpc.Client, error) {
+	rpcClient, err := rpc.DialOptions(ctx, c.endpoint, rpc.WithHTTPClient(http.StandardClient(c.opts...)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", c.endpoint, err)
+	}
+	return rpcClient, nil
+}
+
+func (c *client) BlockNumber(ctx context.Context) (uint64, error) {
+	rpcClient, err := c.dial(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rpcClient.Close()
+
+	var blockNumber hexutil.Uint64
+	if err := rpcClient.CallContext(ctx, &blockNumber, "eth_blockNumber"); err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	return uint64(blockNumber), nil
+}
+
+func (c *client) PoolCreatedEvents(ctx context.Context, factory string, fromBlock, toBlock uint64) ([]PoolCreatedEvent, error) {
+	rpcClient, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.Close()
+
+	filter := map[string]interface{}{
+		"address":   common.HexToAddress(factory),
+		"fromBlock": hexutil.Uint64(fromBlock),
+		"toBlock":   hexutil.Uint64(toBlock),
+		"topics":    []interface{}{poolCreatedTopic},
+	}
+
+	var logs []Log
+	if err := rpcClient.CallContext(ctx, &logs, "eth_getLogs", filter); err != nil {
+		return nil, fmt.Errorf("failed to get logs for blocks %d-%d: %w", fromBlock, toBlock, err)
+	}
+
+	events := make([]PoolCreatedEvent, 0, len(logs))
+	for _, log := range logs {
+		event, err := log.PoolCreatedEvent()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (c *client) SwapEvents(ctx context.Context, pools []string, fromBlock, toBlock uint64) ([]SwapEvent, error) {
+	rpcClient, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.Close()
+
+	events := make([]SwapEvent, 0)
+	for start := 0; start < len(pools); start += batchSize {
+		end := min(start+batchSize, len(pools))
+		addresses := make([]common.Address, 0, end-start)
+		for _, pool := range pools[start:end] {
+			addresses = append(addresses, common.HexToAddress(pool))
+		}
+
+		filter := map[string]interface{}{
+			"address":   addresses,
+			"fromBlock": hexutil.Uint64(fromBlock),
+			"toBlock":   hexutil.Uint64(toBlock),
+			"topics":    []interface{}{swapTopic},
+		}
+
+		var logs []Log
+		if err := rpcClient.CallContext(ctx, &logs, "eth_getLogs", filter); err != nil {
+			return nil, fmt.Errorf("failed to get swap logs for blocks %d-%d: %w", fromBlock, toBlock, err)
+		}
+
+		for _, log := range logs {
+			event, err := log.SwapEvent()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *client) PoolStates(ctx context.Context, pools []string) ([]PoolState, error) {
+	results, err := c.batchCall(ctx, pools, [][]byte{selectorSlot0, selectorLiquidity})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]PoolState, len(pools))
+	for i, pool := range pools {
+		slot0, liquidity := results[i][0], results[i][1]
+		if len(slot0) < 32 || len(liquidity) < 32 {
+			// one pool that cannot be read must not fail the others.
+			states[i] = PoolState{Address: pool}
+			continue
+		}
+
+		states[i] = PoolState{
+			Address:      pool,
+			SqrtPriceX96: new(big.Int).SetBytes(slot0[:32]),
+			Liquidity:    new(big.Int).SetBytes(liquidity[:32]),
+		}
+	}
+
+	return states, nil
+}
+
+func (c *client) Tokens(ctx context.Context, tokens []string) ([]Token, error) {
+	results, err := c.batchCall(ctx, tokens, [][]byte{selectorSymbol, selectorDecimals})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]Token, len(tokens))
+	for i, token := range tokens {
+		symbol, decimals := results[i][0], results[i][1]
+		if len(decimals) < 32 {
+			metadata[i] = Token{Address: token}
+			continue
+		}
+
+		// decimals are used as an exponent, so bogus values of a hostile token must not reach it.
+		decimalsInt := new(big.Int).SetBytes(decimals[:32])
+		if !decimalsInt.IsUint64() || decimalsInt.Uint64() > maxDecimals {
+			metadata[i] = Token{Address: token}
+			continue
+		}
+
+		metadata[i] = Token{
+			Address:  token,
+			Symbol:   decodeSymbol(symbol),
+			Decimals: decimalsInt.Int64(),
+		}
+	}
+
+	return metadata, nil
+}
+
+// batchCall performs an eth_call of each selector on each contract, batching the calls into JSON-RPC
+// batch requests. Results are indexed by contract, then selector. Calls that fail, e.g. because they revert,
+// return empty results.
+func (c *client) batchCall(ctx context.Context, contracts []string, selectors [][]byte) ([][]hexutil.Bytes, error) {
+	rpcClient, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.Close()
+
+	results := make([][]hexutil.Bytes, len(contracts))
+	elems := make([]rpc.BatchElem, 0, len(contracts)*len(selectors))
+	for i, contract := range contracts {
+		results[i] = make([]hexutil.Bytes, len(selectors))
+		for j, sel := range selectors {
+			elems = append(elems, rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{
+						"to":   common.HexToAddress(contract),
+						"data": hexutil.Bytes(sel),
+					},
+					"latest",
+				},
+				Result: &results[i][j],
+			})
+		}
+	}
+
+	for start := 0; start < len(elems); start += batchSize {
+		end := min(start+batchSize, len(elems))
+		if err := rpcClient.BatchCallContext(ctx, elems[start:end]); err != nil {
+			return nil, fmt.Errorf("failed to call contracts: %w", err)
+		}
+	}
+
+	for i, elem := range elems {
+		if elem.Error != nil {
+			results[i/len(selectors)][i%len(selectors)] = nil
+		}
+	}
+
+	return results, nil
+}
+
+// selector returns the 4 byte function selector of the given function signature.
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
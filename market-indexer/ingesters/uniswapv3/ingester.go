@@ -0,0 +1,473 @@
+package uniswapv3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	connectuniswapv3 "github.com/dydxprotocol/slinky/providers/apis/defi/uniswapv3"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name = "uniswapv3"
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// network is a configured network and the client used to read its state.
+type network struct {
+	config config.UniswapV3NetworkConfig
+	client Client
+}
+
+// Ingester is the Uniswap v3 implementation of a market data Ingester. It reads pools directly from the
+// factory and pool contracts of each configured network.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	networks []network
+}
+
+// New creates a new Uniswap v3 Ingester that reads each network from its configured endpoint.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, networks []config.UniswapV3NetworkConfig, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	ig := &Ingester{
+		logger:   logger.With(zap.String("ingester", Name)),
+		aliases:  aliases,
+		networks: make([]network, 0, len(networks)),
+	}
+	for _, cfg := range networks {
+		ig.networks = append(ig.networks, network{config: cfg, client: NewClient(cfg.Endpoint, opts...)})
+	}
+
+	return ig
+}
+
+// NewWithClient creates a new Uniswap v3 Ingester that reads every network with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, networks []config.UniswapV3NetworkConfig, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	ig := &Ingester{
+		logger:   logger.With(zap.String("ingester", Name)),
+		aliases:  aliases,
+		networks: make([]network, 0, len(networks)),
+	}
+	for _, cfg := range networks {
+		ig.networks = append(ig.networks, network{config: cfg, client: client})
+	}
+
+	return ig
+}
+
+// Registration returns the registration of the Uniswap v3 ingester for an ingesters.Registry.
+// The ingester indexes one venue for each configured network.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name: Name,
+		Factory: func(
+			logger *zap.Logger,
+			cfg config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			if len(cfg.UniswapV3Networks) == 0 {
+				return nil, fmt.Errorf("no uniswapv3 networks configured")
+			}
+			for _, network := range cfg.UniswapV3Networks {
+				if err := network.Validate(); err != nil {
+					return nil, fmt.Errorf("invalid uniswapv3 network: %w", err)
+				}
+			}
+			return New(logger, aliases, cfg.UniswapV3Networks, clientOpts...), nil
+		},
+		Venues: func(cfg config.MarketConfig) []ingesters.Venue {
+			venues := make([]ingesters.Venue, 0, len(cfg.UniswapV3Networks))
+			for _, network := range cfg.UniswapV3Networks {
+				venues = append(venues, ingesters.Venue{
+					Name:            network.Chain,
+					ProviderName:    network.ProviderName(),
+					CMCExchangeSlug: network.CMCExchangeSlug,
+				})
+			}
+			return venues
+		},
+	}
+}
+
+func (ig *Ingester) Name() string {
+	return Name
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	ig.logger.Info("fetching data")
+
+	providerMarkets := make([]provider.CreateProviderMarket, 0)
+	for _, network := range ig.networks {
+		markets, err := ig.networkProviderMarkets(ctx, network)
+		if err != nil {
+			return nil, fmt.Errorf("failed to index %s: %w", network.config.Chain, err)
+		}
+		providerMarkets = append(providerMarkets, markets...)
+	}
+
+	ig.logger.Info("fetched data", zap.Int("markets", len(providerMarkets)))
+
+	return providerMarkets, nil
+}
+
+func (ig *Ingester) networkProviderMarkets(ctx context.Context, network network) ([]provider.CreateProviderMarket, error) {
+	latest, err := network.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := ig.poolCreatedEvents(ctx, network, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	ig.logger.Info("fetched pools", zap.String("chain", network.config.Chain), zap.Int("pools", len(events)))
+
+	pools := make([]string, 0, len(events))
+	tokenSet := make(map[string]struct{})
+	tokenAddresses := make([]string, 0)
+	for _, event := range events {
+		pools = append(pools, event.Pool)
+		for _, token := range []string{event.Token0, event.Token1} {
+			if _, found := tokenSet[token]; !found {
+				tokenSet[token] = struct{}{}
+				tokenAddresses = append(tokenAddresses, token)
+			}
+		}
+	}
+
+	states, err := network.client.PoolStates(ctx, pools)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenList, err := network.client.Tokens(ctx, tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]Token, len(tokenList))
+	for _, token := range tokenList {
+		tokens[token.Address] = token
+	}
+
+	quoteTokens := make(map[string]struct{}, len(network.config.QuoteTokens))
+	for _, token := range network.config.QuoteTokens {
+		quoteTokens[strings.ToLower(token)] = struct{}{}
+	}
+
+	// several pools (one per fee tier) may exist for the same tokens. only the deepest pool is kept,
+	// since they all share the same off-chain ticker.
+	marketIndex := make(map[string]int)
+	providerMarkets := make([]provider.CreateProviderMarket, 0, len(events))
+	marketPools := make([]PoolCreatedEvent, 0, len(events))
+	for i, event := range events {
+		market, err := ig.toProviderMarket(network.config, event, states[i], tokens, quoteTokens)
+		if err != nil {
+			ig.logger.Debug("skipping pool", zap.String("pool", event.Pool), zap.Error(err))
+			continue
+		}
+
+		if j, found := marketIndex[market.Create.OffChainTicker]; found {
+			if depth(market) > depth(providerMarkets[j]) {
+				providerMarkets[j] = market
+				marketPools[j] = event
+			}
+			continue
+		}
+
+		marketIndex[market.Create.OffChainTicker] = len(providerMarkets)
+		providerMarkets = append(providerMarkets, market)
+		marketPools = append(marketPools, event)
+	}
+
+	// on-chain state does not include volume, so the quote volume of each market is the amount of its quote
+	// token swapped in its pool over the last day of blocks.
+	volumes, err := ig.swapVolumes(ctx, network, latest, marketPools)
+	if err != nil {
+		return nil, err
+	}
+	for i, market := range providerMarkets {
+		volume, found := volumes[marketPools[i].Pool]
+		if !found {
+			continue
+		}
+
+		quoteVolume := volume[1]
+		if market.QuoteAddress == marketPools[i].Token0 {
+			quoteVolume = volume[0]
+		}
+		quoteVolumeF64, _ := new(big.Float).Mul(new(big.Float).SetInt(quoteVolume), pow10(-tokens[market.QuoteAddress].Decimals)).Float64()
+		providerMarkets[i].Create.QuoteVolume = quoteVolumeF64
+	}
+
+	// depth and volume are converted from quote tokens to USD, the unit of the generator's liquidity thresholds.
+	// The depth and USD volume of markets whose quote token has no USD price are unknown, and left for
+	// CoinMarketCap to fill in.
+	usdPrices := quoteUSDPrices(providerMarkets, quoteTokens)
+	for i, market := range providerMarkets {
+		usdPrice, found := usdPrices[market.QuoteAddress]
+		if !found {
+			ig.logger.Debug("no usd price for quote token - depth is unknown", zap.String("market", market.Create.OffChainTicker))
+		}
+		providerMarkets[i].Create.NegativeDepthTwo *= usdPrice
+		providerMarkets[i].Create.PositiveDepthTwo *= usdPrice
+		providerMarkets[i].Create.UsdVolume = market.Create.QuoteVolume * usdPrice
+	}
+
+	return providerMarkets, nil
+}
+
+// quoteUSDPrices returns the USD prices of quote tokens by address. Configured quote tokens that are USD quotes
+// are priced at 1, and other tokens at the reference price of their deepest market against one of them.
+func quoteUSDPrices(markets []provider.CreateProviderMarket, quoteTokens map[string]struct{}) map[string]float64 {
+	prices := make(map[string]float64)
+	for _, market := range markets {
+		if _, found := quoteTokens[market.QuoteAddress]; found && ingesters.IsUSDQuote(market.Create.TargetQuote) {
+			prices[market.QuoteAddress] = 1
+		}
+	}
+
+	// the depth of these markets is in USD quote tokens, so the depth of markets of different USD quotes is
+	// comparable.
+	deepest := make(map[string]float64)
+	for _, market := range markets {
+		if prices[market.QuoteAddress] != 1 || prices[market.BaseAddress] == 1 {
+			continue
+		}
+		if d := depth(market); d > deepest[market.BaseAddress] {
+			deepest[market.BaseAddress] = d
+			prices[market.BaseAddress] = market.Create.ReferencePrice
+		}
+	}
+
+	return prices
+}
+
+// poolCreatedEvents reads all PoolCreated events of the network's factory up to the latest block, in chunks of the
+// configured block range.
+func (ig *Ingester) poolCreatedEvents(ctx context.Context, network network, latest uint64) ([]PoolCreatedEvent, error) {
+	blockRange := logBlockRange(network.config)
+
+	events := make([]PoolCreatedEvent, 0)
+	for from := network.config.FromBlock; from <= latest; from += blockRange {
+		to := min(from+blockRange-1, latest)
+		chunk, err := network.client.PoolCreatedEvents(ctx, network.config.FactoryAddress, from, to)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, chunk...)
+	}
+
+	return events, nil
+}
+
+// swapVolumes reads the Swap events of the given pools over the network's last day of blocks, in chunks of the
+// configured block range. It returns the absolute amounts of token0 and token1 swapped in each pool that had a
+// swap, in base units of the tokens.
+func (ig *Ingester) swapVolumes(ctx context.Context, network network, latest uint64, pools []PoolCreatedEvent) (map[string][2]*big.Int, error) {
+	volumes := make(map[string][2]*big.Int)
+	if len(pools) == 0 {
+		return volumes, nil
+	}
+
+	addresses := make([]string, len(pools))
+	for i, pool := range pools {
+		addresses[i] = pool.Pool
+	}
+
+	var first uint64
+	if dayBlocks := network.config.DayBlocks(); latest >= dayBlocks {
+		first = latest - dayBlocks + 1
+	}
+
+	blockRange := logBlockRange(network.config)
+	for from := first; from <= latest; from += blockRange {
+		to := min(from+blockRange-1, latest)
+		events, err := network.client.SwapEvents(ctx, addresses, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			volume, found := volumes[event.Pool]
+			if !found {
+				volume = [2]*big.Int{new(big.Int), new(big.Int)}
+				volumes[event.Pool] = volume
+			}
+			volume[0].Add(volume[0], new(big.Int).Abs(event.Amount0))
+			volume[1].Add(volume[1], new(big.Int).Abs(event.Amount1))
+		}
+	}
+
+	return volumes, nil
+}
+
+// logBlockRange returns the number of blocks queried per eth_getLogs request of the network.
+func logBlockRange(cfg config.UniswapV3NetworkConfig) uint64 {
+	if cfg.BlockRange == 0 {
+		return config.DefaultUniswapV3BlockRange
+	}
+	return cfg.BlockRange
+}
+
+func (ig *Ingester) toProviderMarket(
+	cfg config.UniswapV3NetworkConfig,
+	event PoolCreatedEvent,
+	state PoolState,
+	tokens map[string]Token,
+	quoteTokens map[string]struct{},
+) (provider.CreateProviderMarket, error) {
+	if state.SqrtPriceX96 == nil || state.Liquidity == nil {
+		return provider.CreateProviderMarket{}, fmt.Errorf("pool state could not be read")
+	}
+	if state.SqrtPriceX96.Sign() == 0 || state.Liquidity.Sign() == 0 {
+		return provider.CreateProviderMarket{}, fmt.Errorf("pool has no liquidity")
+	}
+
+	// token1 is the quote, unless only token0 is a preferred quote token.
+	base, quote := tokens[event.Token0], tokens[event.Token1]
+	_, token0IsQuote := quoteTokens[event.Token0]
+	_, token1IsQuote := quoteTokens[event.Token1]
+	baseIsToken0 := !token0IsQuote || token1IsQuote
+	if !baseIsToken0 {
+		base, quote = quote, base
+	}
+
+	targetBase, err := ig.tickerString(base)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+	targetQuote, err := ig.tickerString(quote)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	// sqrtPrice is the square root of the price of token0 in token1, in base units of the tokens.
+	sqrtPrice := new(big.Float).Quo(new(big.Float).SetInt(state.SqrtPriceX96), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96)))
+	if !baseIsToken0 {
+		sqrtPrice.Quo(big.NewFloat(1), sqrtPrice)
+	}
+
+	// price = sqrtPrice^2 * 10^(baseDecimals - quoteDecimals).
+	price := new(big.Float).Mul(sqrtPrice, sqrtPrice)
+	price.Mul(price, pow10(base.Decimals-quote.Decimals))
+	refPrice, _ := price.Float64()
+	if !isValidFloat64(refPrice) {
+		return provider.CreateProviderMarket{}, fmt.Errorf("invalid reference price %s", price.String())
+	}
+
+	// within the current tick range, moving the price from p to p' takes liquidity * (sqrt(p') - sqrt(p))
+	// quote tokens, so the +/-2% depth in quote tokens is derived from the in range liquidity. It is converted
+	// to USD once the prices of the quote tokens are known.
+	quoteLiquidity := new(big.Float).Mul(new(big.Float).SetInt(state.Liquidity), sqrtPrice)
+	quoteLiquidity.Mul(quoteLiquidity, pow10(-quote.Decimals))
+	quoteLiquidityF64, _ := quoteLiquidity.Float64()
+	positiveDepthTwo := quoteLiquidityF64 * (math.Sqrt(1.02) - 1)
+	negativeDepthTwo := quoteLiquidityF64 * (1 - math.Sqrt(0.98))
+
+	// connect prices pools as token1/token0 and expects invert to be set if the base is token1. see the
+	// gecko ingester for a description of connect's handling of this flag.
+	metaData := connectuniswapv3.PoolConfig{
+		Address:       event.Pool,
+		BaseDecimals:  base.Decimals,
+		QuoteDecimals: quote.Decimals,
+		Invert:        !baseIsToken0,
+	}
+	metaDataBz, err := json.Marshal(metaData)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	market := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:       targetBase,
+			TargetQuote:      targetQuote,
+			OffChainTicker:   offChainTicker(targetBase, targetQuote, base.Address, quote.Address, cfg.TickerVenue),
+			ProviderName:     cfg.ProviderName(),
+			MetadataJSON:     metaDataBz,
+			ReferencePrice:   refPrice,
+			NegativeDepthTwo: negativeDepthTwo,
+			PositiveDepthTwo: positiveDepthTwo,
+		},
+		BaseAddress:  base.Address,
+		QuoteAddress: quote.Address,
+	}
+
+	return market, market.ValidateBasic()
+}
+
+// offChainTicker returns the off-chain ticker of a pool, in the same format as the gecko ingester.
+func offChainTicker(base, quote, baseAddress, quoteAddress, tickerVenue string) string {
+	return strings.ToUpper(strings.Join([]string{
+		strings.Join([]string{base, tickerVenue, baseAddress}, types.DefiTickerDelimiter),
+		strings.Join([]string{quote, tickerVenue, quoteAddress}, types.DefiTickerDelimiter),
+	}, types.TickerSeparator))
+}
+
+func (ig *Ingester) tickerString(token Token) (string, error) {
+	if token.Symbol == "" {
+		return "", fmt.Errorf("no symbol for token %s", token.Address)
+	}
+
+	s, err := symbols.ToCanonicalTickerString(ig.aliases, Name, token.Symbol)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(s, types.TickerSeparator) {
+		return "", fmt.Errorf("invalid symbol %q for token %s", token.Symbol, token.Address)
+	}
+
+	return s, nil
+}
+
+func depth(market provider.CreateProviderMarket) float64 {
+	return market.Create.NegativeDepthTwo + market.Create.PositiveDepthTwo
+}
+
+// pow10 returns 10^n as a big.Float.
+func pow10(n int64) *big.Float {
+	p := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(n)), nil))
+	if n < 0 {
+		return p.Quo(big.NewFloat(1), p)
+	}
+	return p
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func isValidFloat64(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f) && f != 0
+}
@@ -0,0 +1,376 @@
+package uniswapv3_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	connectuniswapv3 "github.com/dydxprotocol/slinky/providers/apis/defi/uniswapv3"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/uniswapv3"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/uniswapv3/mocks"
+)
+
+const (
+	factory = "0x1f98431c8ad98523631ae4a59f267346ea31f984"
+
+	usdc = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	weth = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	mkr  = "0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2"
+	// bogus reports more decimals than fit in a uint8.
+	bogus = "0x00000000000000000000000000000000000000b0"
+
+	usdcWethPool     = "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+	usdcWethPoolLow  = "0x8ad599c3a0ff1de082011efddc58f1908eb6e6d8"
+	mkrWethPool      = "0xe8c6c9227491c0a8156a0106a0204d881bb7e531"
+	emptyPool        = "0x0000000000000000000000000000000000000001"
+	revertingPool    = "0x0000000000000000000000000000000000000002"
+	bogusPool        = "0x0000000000000000000000000000000000000003"
+	latestBlock      = 250
+	sqrtPriceX96Bits = 96
+)
+
+// testNode is a local stand-in for an EVM JSON-RPC node serving a single Uniswap v3 factory.
+type testNode struct {
+	t *testing.T
+
+	logs   []testLog
+	swaps  []testSwap
+	calls  map[string]string
+	ranges [][2]uint64
+
+	swapRanges    [][2]uint64
+	swapAddresses []string
+}
+
+type testLog struct {
+	block  uint64
+	token0 string
+	token1 string
+	fee    uint64
+	pool   string
+}
+
+type testSwap struct {
+	block   uint64
+	pool    string
+	amount0 *big.Int
+	amount1 *big.Int
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func newTestNode(t *testing.T) *testNode {
+	t.Helper()
+
+	node := &testNode{
+		t:     t,
+		calls: make(map[string]string),
+	}
+
+	node.addToken(usdc, abiString("USDC"), 6)
+	node.addToken(weth, abiString("WETH"), 18)
+	// MKR returns its symbol as a bytes32.
+	node.addToken(mkr, hex.EncodeToString(common.RightPadBytes([]byte("MKR"), 32)), 18)
+
+	// 1 WETH = 3000 USDC. USDC is token0, so the pool price is WETH per USDC.
+	node.addPool(120, usdc, weth, 3000, usdcWethPool, sqrtPriceX96(1e12/3000.0), big.NewInt(1e18))
+	node.addPool(130, usdc, weth, 500, usdcWethPoolLow, sqrtPriceX96(1e12/3000.0), big.NewInt(1e15))
+	// 1 MKR = 0.5 WETH.
+	node.addPool(210, mkr, weth, 3000, mkrWethPool, sqrtPriceX96(0.5), big.NewInt(1e18))
+	node.addPool(220, mkr, usdc, 3000, emptyPool, big.NewInt(0), big.NewInt(0))
+
+	// the state of a pool whose calls revert is unavailable, which only skips that pool.
+	node.logs = append(node.logs, testLog{block: 225, token0: mkr, token1: usdc, fee: 500, pool: revertingPool})
+
+	node.calls[callKey(bogus, "symbol()")] = abiString("BOGUS")
+	node.calls[callKey(bogus, "decimals()")] = abiUint(new(big.Int).Lsh(big.NewInt(1), 70))
+	node.addPool(230, bogus, usdc, 3000, bogusPool, sqrtPriceX96(1), big.NewInt(1e18))
+
+	// 3000 USDC are swapped for 1 WETH, and 0.5 WETH back for 1500 USDC, in the last day of blocks.
+	node.addSwap(160, usdcWethPool, big.NewInt(3000e6), big.NewInt(-1e18))
+	node.addSwap(200, usdcWethPool, big.NewInt(-1500e6), big.NewInt(5e17))
+	// swaps before the last day of blocks, or in pools that are not kept, are not part of the volume.
+	node.addSwap(140, usdcWethPool, big.NewInt(1e12), big.NewInt(-1e18))
+	node.addSwap(170, usdcWethPoolLow, big.NewInt(1e12), big.NewInt(-1e18))
+	// 2 MKR are swapped for 1 WETH.
+	node.addSwap(240, mkrWethPool, big.NewInt(2e18), big.NewInt(-1e18))
+
+	return node
+}
+
+func (n *testNode) addToken(address, symbol string, decimals int64) {
+	n.calls[callKey(address, "symbol()")] = symbol
+	n.calls[callKey(address, "decimals()")] = abiUint(big.NewInt(decimals))
+}
+
+func (n *testNode) addPool(block uint64, token0, token1 string, fee uint64, pool string, sqrtPrice, liquidity *big.Int) {
+	n.logs = append(n.logs, testLog{block: block, token0: token0, token1: token1, fee: fee, pool: pool})
+	// slot0 returns (sqrtPriceX96, tick, ...), only the first word is read.
+	n.calls[callKey(pool, "slot0()")] = abiUint(sqrtPrice) + abiUint(big.NewInt(0))
+	n.calls[callKey(pool, "liquidity()")] = abiUint(liquidity)
+}
+
+func (n *testNode) addSwap(block uint64, pool string, amount0, amount1 *big.Int) {
+	n.swaps = append(n.swaps, testSwap{block: block, pool: pool, amount0: amount0, amount1: amount1})
+}
+
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&body))
+
+	w.Header().Set("Content-Type", "application/json")
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var reqs []rpcRequest
+		require.NoError(n.t, json.Unmarshal(body, &reqs))
+
+		resps := make([]rpcResponse, 0, len(reqs))
+		for _, req := range reqs {
+			resps = append(resps, n.handle(req))
+		}
+		require.NoError(n.t, json.NewEncoder(w).Encode(resps))
+		return
+	}
+
+	var req rpcRequest
+	require.NoError(n.t, json.Unmarshal(body, &req))
+	require.NoError(n.t, json.NewEncoder(w).Encode(n.handle(req)))
+}
+
+func (n *testNode) handle(req rpcRequest) rpcResponse {
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+
+	switch req.Method {
+	case "eth_blockNumber":
+		resp.Result = hexutil.Uint64(latestBlock)
+	case "eth_getLogs":
+		var filter struct {
+			Address   json.RawMessage `json:"address"`
+			FromBlock hexutil.Uint64  `json:"fromBlock"`
+			ToBlock   hexutil.Uint64  `json:"toBlock"`
+			Topics    []common.Hash   `json:"topics"`
+		}
+		require.NoError(n.t, json.Unmarshal(req.Params[0], &filter))
+		require.Len(n.t, filter.Topics, 1)
+		if filter.Topics[0] == swapTopic {
+			resp.Result = n.swapLogs(filter.Address, uint64(filter.FromBlock), uint64(filter.ToBlock))
+			break
+		}
+
+		var address string
+		require.NoError(n.t, json.Unmarshal(filter.Address, &address))
+		require.Equal(n.t, factory, strings.ToLower(address))
+		n.ranges = append(n.ranges, [2]uint64{uint64(filter.FromBlock), uint64(filter.ToBlock)})
+
+		logs := make([]map[string]interface{}, 0)
+		for _, log := range n.logs {
+			if log.block < uint64(filter.FromBlock) || log.block > uint64(filter.ToBlock) {
+				continue
+			}
+			logs = append(logs, map[string]interface{}{
+				"address": factory,
+				"topics": []string{
+					crypto.Keccak256Hash([]byte("PoolCreated(address,address,uint24,int24,address)")).Hex(),
+					common.BytesToHash(common.HexToAddress(log.token0).Bytes()).Hex(),
+					common.BytesToHash(common.HexToAddress(log.token1).Bytes()).Hex(),
+					common.BigToHash(new(big.Int).SetUint64(log.fee)).Hex(),
+				},
+				"data":        "0x" + abiUint(big.NewInt(60)) + hex.EncodeToString(common.LeftPadBytes(common.HexToAddress(log.pool).Bytes(), 32)),
+				"blockNumber": hexutil.Uint64(log.block),
+			})
+		}
+		resp.Result = logs
+	case "eth_call":
+		var call struct {
+			To   string        `json:"to"`
+			Data hexutil.Bytes `json:"data"`
+		}
+		require.NoError(n.t, json.Unmarshal(req.Params[0], &call))
+
+		result, found := n.calls[strings.ToLower(call.To)+hex.EncodeToString(call.Data)]
+		if !found {
+			resp.Error = &rpcError{Code: 3, Message: "execution reverted"}
+			break
+		}
+		resp.Result = "0x" + result
+	default:
+		resp.Error = &rpcError{Code: -32601, Message: "method not found"}
+	}
+
+	return resp
+}
+
+func (n *testNode) swapLogs(addressesJSON json.RawMessage, from, to uint64) []map[string]interface{} {
+	var addresses []string
+	require.NoError(n.t, json.Unmarshal(addressesJSON, &addresses))
+	n.swapRanges = append(n.swapRanges, [2]uint64{from, to})
+
+	pools := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		pools[strings.ToLower(address)] = struct{}{}
+		n.swapAddresses = append(n.swapAddresses, strings.ToLower(address))
+	}
+
+	logs := make([]map[string]interface{}, 0)
+	for _, swap := range n.swaps {
+		if _, found := pools[swap.pool]; !found || swap.block < from || swap.block > to {
+			continue
+		}
+		logs = append(logs, map[string]interface{}{
+			"address": swap.pool,
+			"topics": []string{
+				swapTopic.Hex(),
+				common.Hash{}.Hex(),
+				common.Hash{}.Hex(),
+			},
+			"data": "0x" + abiInt(swap.amount0) + abiInt(swap.amount1) +
+				abiUint(big.NewInt(0)) + abiUint(big.NewInt(0)) + abiUint(big.NewInt(0)),
+			"blockNumber": hexutil.Uint64(swap.block),
+		})
+	}
+
+	return logs
+}
+
+var swapTopic = crypto.Keccak256Hash([]byte("Swap(address,address,int256,int256,uint160,uint128,int24)"))
+
+func callKey(address, signature string) string {
+	return address + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func abiUint(i *big.Int) string {
+	return hex.EncodeToString(common.LeftPadBytes(i.Bytes(), 32))
+}
+
+// abiInt returns the two's complement abi encoding of i.
+func abiInt(i *big.Int) string {
+	if i.Sign() < 0 {
+		return abiUint(new(big.Int).Add(i, new(big.Int).Lsh(big.NewInt(1), 256)))
+	}
+	return abiUint(i)
+}
+
+func abiString(s string) string {
+	return abiUint(big.NewInt(32)) + abiUint(big.NewInt(int64(len(s)))) + hex.EncodeToString(common.RightPadBytes([]byte(s), 32))
+}
+
+// sqrtPriceX96 returns sqrt(price) as a Q64.96 value.
+func sqrtPriceX96(price float64) *big.Int {
+	sqrtPrice := new(big.Float).SetPrec(256).Sqrt(big.NewFloat(price))
+	sqrtPrice.Mul(sqrtPrice, new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), sqrtPriceX96Bits)))
+	i, _ := sqrtPrice.Int(nil)
+	return i
+}
+
+func testNetwork(endpoint string) config.UniswapV3NetworkConfig {
+	return config.UniswapV3NetworkConfig{
+		Chain:           "ethereum",
+		Endpoint:        endpoint,
+		FactoryAddress:  factory,
+		FromBlock:       100,
+		BlockRange:      100,
+		BlocksPerDay:    100,
+		TickerVenue:     "UNISWAP_V3",
+		CMCExchangeSlug: "uniswap-v3",
+		QuoteTokens:     []string{usdc},
+	}
+}
+
+func TestIngester(t *testing.T) {
+	node := newTestNode(t)
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	ig := uniswapv3.New(zap.NewNop(), nil, []config.UniswapV3NetworkConfig{testNetwork(server.URL)})
+
+	markets, err := ig.GetProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{100, 199}, {200, 250}}, node.ranges)
+	// swaps are only read from the kept pools, over the last 100 blocks.
+	require.Equal(t, [][2]uint64{{151, 250}}, node.swapRanges)
+	require.Equal(t, []string{usdcWethPool, mkrWethPool}, node.swapAddresses)
+	require.Len(t, markets, 2)
+
+	// the USDC/WETH pool with the most liquidity is kept. WETH is the base as USDC is a quote token.
+	wethUsdc := markets[0]
+	require.Equal(t, "WETH", wethUsdc.Create.TargetBase)
+	require.Equal(t, "USDC", wethUsdc.Create.TargetQuote)
+	require.Equal(t, "WETH,UNISWAP_V3,"+strings.ToUpper(weth)+"/USDC,UNISWAP_V3,"+strings.ToUpper(usdc), wethUsdc.Create.OffChainTicker)
+	require.Equal(t, "uniswapv3_api-ethereum", wethUsdc.Create.ProviderName)
+	require.InDelta(t, 3000, wethUsdc.Create.ReferencePrice, 1e-6)
+	require.Greater(t, wethUsdc.Create.PositiveDepthTwo, 0.0)
+	require.Greater(t, wethUsdc.Create.NegativeDepthTwo, wethUsdc.Create.PositiveDepthTwo)
+	require.InDelta(t, 4500, wethUsdc.Create.QuoteVolume, 1e-6)
+	require.InDelta(t, 4500, wethUsdc.Create.UsdVolume, 1e-6)
+	require.Equal(t, weth, wethUsdc.BaseAddress)
+	require.Equal(t, usdc, wethUsdc.QuoteAddress)
+
+	var metaData connectuniswapv3.PoolConfig
+	require.NoError(t, json.Unmarshal(wethUsdc.Create.MetadataJSON, &metaData))
+	require.Equal(t, connectuniswapv3.PoolConfig{
+		Address:       usdcWethPool,
+		BaseDecimals:  18,
+		QuoteDecimals: 6,
+		Invert:        true,
+	}, metaData)
+
+	mkrWeth := markets[1]
+	require.Equal(t, "MKR", mkrWeth.Create.TargetBase)
+	require.Equal(t, "WETH", mkrWeth.Create.TargetQuote)
+	require.InDelta(t, 0.5, mkrWeth.Create.ReferencePrice, 1e-12)
+	// volume is in WETH, and converted to USD at 3000 USDC per WETH.
+	require.InDelta(t, 1, mkrWeth.Create.QuoteVolume, 1e-12)
+	require.InDelta(t, 3000, mkrWeth.Create.UsdVolume, 1e-6)
+	// depth is in USD: the pool holds liquidity * sqrt(0.5) WETH in range, at 3000 USDC per WETH.
+	require.InDelta(t, math.Sqrt(0.5)*(math.Sqrt(1.02)-1)*3000, mkrWeth.Create.PositiveDepthTwo, 1e-6)
+	require.InDelta(t, math.Sqrt(0.5)*(1-math.Sqrt(0.98))*3000, mkrWeth.Create.NegativeDepthTwo, 1e-6)
+
+	require.NoError(t, json.Unmarshal(mkrWeth.Create.MetadataJSON, &metaData))
+	require.Equal(t, connectuniswapv3.PoolConfig{
+		Address:       mkrWethPool,
+		BaseDecimals:  18,
+		QuoteDecimals: 18,
+		Invert:        false,
+	}, metaData)
+}
+
+func TestIngesterClientError(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("BlockNumber", mock.Anything).Return(uint64(0), context.DeadlineExceeded)
+
+	ig := uniswapv3.NewWithClient(zap.NewNop(), nil, []config.UniswapV3NetworkConfig{testNetwork("")}, client)
+
+	_, err := ig.GetProviderMarkets(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "ethereum")
+}
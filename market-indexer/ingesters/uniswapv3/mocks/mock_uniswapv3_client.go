@@ -0,0 +1,333 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uniswapv3 "github.com/skip-mev/connect-mmu/market-indexer/ingesters/uniswapv3"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Client is an autogenerated mock type for the Client type
+type Client struct {
+	mock.Mock
+}
+
+type Client_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Client) EXPECT() *Client_Expecter {
+	return &Client_Expecter{mock: &_m.Mock}
+}
+
+// BlockNumber provides a mock function with given fields: ctx
+func (_m *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockNumber'
+type Client_BlockNumber_Call struct {
+	*mock.Call
+}
+
+// BlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) BlockNumber(ctx interface{}) *Client_BlockNumber_Call {
+	return &Client_BlockNumber_Call{Call: _e.mock.On("BlockNumber", ctx)}
+}
+
+func (_c *Client_BlockNumber_Call) Run(run func(ctx context.Context)) *Client_BlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_BlockNumber_Call) Return(_a0 uint64, _a1 error) *Client_BlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_BlockNumber_Call) RunAndReturn(run func(context.Context) (uint64, error)) *Client_BlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PoolCreatedEvents provides a mock function with given fields: ctx, factory, fromBlock, toBlock
+func (_m *Client) PoolCreatedEvents(ctx context.Context, factory string, fromBlock uint64, toBlock uint64) ([]uniswapv3.PoolCreatedEvent, error) {
+	ret := _m.Called(ctx, factory, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PoolCreatedEvents")
+	}
+
+	var r0 []uniswapv3.PoolCreatedEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]uniswapv3.PoolCreatedEvent, error)); ok {
+		return rf(ctx, factory, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []uniswapv3.PoolCreatedEvent); ok {
+		r0 = rf(ctx, factory, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uniswapv3.PoolCreatedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = rf(ctx, factory, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_PoolCreatedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PoolCreatedEvents'
+type Client_PoolCreatedEvents_Call struct {
+	*mock.Call
+}
+
+// PoolCreatedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - factory string
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Client_Expecter) PoolCreatedEvents(ctx interface{}, factory interface{}, fromBlock interface{}, toBlock interface{}) *Client_PoolCreatedEvents_Call {
+	return &Client_PoolCreatedEvents_Call{Call: _e.mock.On("PoolCreatedEvents", ctx, factory, fromBlock, toBlock)}
+}
+
+func (_c *Client_PoolCreatedEvents_Call) Run(run func(ctx context.Context, factory string, fromBlock uint64, toBlock uint64)) *Client_PoolCreatedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *Client_PoolCreatedEvents_Call) Return(_a0 []uniswapv3.PoolCreatedEvent, _a1 error) *Client_PoolCreatedEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_PoolCreatedEvents_Call) RunAndReturn(run func(context.Context, string, uint64, uint64) ([]uniswapv3.PoolCreatedEvent, error)) *Client_PoolCreatedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PoolStates provides a mock function with given fields: ctx, pools
+func (_m *Client) PoolStates(ctx context.Context, pools []string) ([]uniswapv3.PoolState, error) {
+	ret := _m.Called(ctx, pools)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PoolStates")
+	}
+
+	var r0 []uniswapv3.PoolState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]uniswapv3.PoolState, error)); ok {
+		return rf(ctx, pools)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []uniswapv3.PoolState); ok {
+		r0 = rf(ctx, pools)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uniswapv3.PoolState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, pools)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_PoolStates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PoolStates'
+type Client_PoolStates_Call struct {
+	*mock.Call
+}
+
+// PoolStates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pools []string
+func (_e *Client_Expecter) PoolStates(ctx interface{}, pools interface{}) *Client_PoolStates_Call {
+	return &Client_PoolStates_Call{Call: _e.mock.On("PoolStates", ctx, pools)}
+}
+
+func (_c *Client_PoolStates_Call) Run(run func(ctx context.Context, pools []string)) *Client_PoolStates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Client_PoolStates_Call) Return(_a0 []uniswapv3.PoolState, _a1 error) *Client_PoolStates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_PoolStates_Call) RunAndReturn(run func(context.Context, []string) ([]uniswapv3.PoolState, error)) *Client_PoolStates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SwapEvents provides a mock function with given fields: ctx, pools, fromBlock, toBlock
+func (_m *Client) SwapEvents(ctx context.Context, pools []string, fromBlock uint64, toBlock uint64) ([]uniswapv3.SwapEvent, error) {
+	ret := _m.Called(ctx, pools, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapEvents")
+	}
+
+	var r0 []uniswapv3.SwapEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64, uint64) ([]uniswapv3.SwapEvent, error)); ok {
+		return rf(ctx, pools, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64, uint64) []uniswapv3.SwapEvent); ok {
+		r0 = rf(ctx, pools, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uniswapv3.SwapEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, uint64, uint64) error); ok {
+		r1 = rf(ctx, pools, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_SwapEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwapEvents'
+type Client_SwapEvents_Call struct {
+	*mock.Call
+}
+
+// SwapEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pools []string
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Client_Expecter) SwapEvents(ctx interface{}, pools interface{}, fromBlock interface{}, toBlock interface{}) *Client_SwapEvents_Call {
+	return &Client_SwapEvents_Call{Call: _e.mock.On("SwapEvents", ctx, pools, fromBlock, toBlock)}
+}
+
+func (_c *Client_SwapEvents_Call) Run(run func(ctx context.Context, pools []string, fromBlock uint64, toBlock uint64)) *Client_SwapEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *Client_SwapEvents_Call) Return(_a0 []uniswapv3.SwapEvent, _a1 error) *Client_SwapEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_SwapEvents_Call) RunAndReturn(run func(context.Context, []string, uint64, uint64) ([]uniswapv3.SwapEvent, error)) *Client_SwapEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tokens provides a mock function with given fields: ctx, tokens
+func (_m *Client) Tokens(ctx context.Context, tokens []string) ([]uniswapv3.Token, error) {
+	ret := _m.Called(ctx, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tokens")
+	}
+
+	var r0 []uniswapv3.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]uniswapv3.Token, error)); ok {
+		return rf(ctx, tokens)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []uniswapv3.Token); ok {
+		r0 = rf(ctx, tokens)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uniswapv3.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, tokens)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Tokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tokens'
+type Client_Tokens_Call struct {
+	*mock.Call
+}
+
+// Tokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokens []string
+func (_e *Client_Expecter) Tokens(ctx interface{}, tokens interface{}) *Client_Tokens_Call {
+	return &Client_Tokens_Call{Call: _e.mock.On("Tokens", ctx, tokens)}
+}
+
+func (_c *Client_Tokens_Call) Run(run func(ctx context.Context, tokens []string)) *Client_Tokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Client_Tokens_Call) Return(_a0 []uniswapv3.Token, _a1 error) *Client_Tokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Tokens_Call) RunAndReturn(run func(context.Context, []string) ([]uniswapv3.Token, error)) *Client_Tokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
+	mock := &Client{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
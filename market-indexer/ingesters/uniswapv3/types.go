@@ -0,0 +1,134 @@
+package uniswapv3
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Log is an EVM log as returned by eth_getLogs.
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// PoolCreatedEvent is a PoolCreated event emitted by a Uniswap v3 factory.
+type PoolCreatedEvent struct {
+	Token0 string
+	Token1 string
+	Fee    uint32
+	Pool   string
+}
+
+// PoolCreatedEvent decodes the log as a PoolCreated event.
+func (l Log) PoolCreatedEvent() (PoolCreatedEvent, error) {
+	if len(l.Topics) != 4 || l.Topics[0] != poolCreatedTopic {
+		return PoolCreatedEvent{}, fmt.Errorf("log is not a PoolCreated event: %v", l.Topics)
+	}
+
+	// data is the abi encoding of (int24 tickSpacing, address pool).
+	if len(l.Data) != 64 {
+		return PoolCreatedEvent{}, fmt.Errorf("invalid PoolCreated event data: %s", l.Data)
+	}
+
+	return PoolCreatedEvent{
+		Token0: strings.ToLower(common.BytesToAddress(l.Topics[1].Bytes()).Hex()),
+		Token1: strings.ToLower(common.BytesToAddress(l.Topics[2].Bytes()).Hex()),
+		Fee:    uint32(new(big.Int).SetBytes(l.Topics[3].Bytes()).Uint64()),
+		Pool:   strings.ToLower(common.BytesToAddress(l.Data[32:]).Hex()),
+	}, nil
+}
+
+// SwapEvent is a Swap event emitted by a Uniswap v3 pool.
+type SwapEvent struct {
+	Pool string
+	// Amount0 and Amount1 are the changes of the pool's balances of token0 and token1, in base units of the
+	// tokens. The token paid into the pool has a positive amount, and the token paid out a negative one.
+	Amount0 *big.Int
+	Amount1 *big.Int
+}
+
+// SwapEvent decodes the log as a Swap event.
+func (l Log) SwapEvent() (SwapEvent, error) {
+	if len(l.Topics) != 3 || l.Topics[0] != swapTopic {
+		return SwapEvent{}, fmt.Errorf("log is not a Swap event: %v", l.Topics)
+	}
+
+	// data is the abi encoding of (int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick).
+	if len(l.Data) != 160 {
+		return SwapEvent{}, fmt.Errorf("invalid Swap event data: %s", l.Data)
+	}
+
+	return SwapEvent{
+		Pool:    strings.ToLower(l.Address.Hex()),
+		Amount0: decodeInt256(l.Data[:32]),
+		Amount1: decodeInt256(l.Data[32:64]),
+	}, nil
+}
+
+// decodeInt256 decodes an abi encoded int256, which is a two's complement 32 byte word.
+func decodeInt256(word []byte) *big.Int {
+	i := new(big.Int).SetBytes(word)
+	if word[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return i
+}
+
+// PoolState is the current state of a Uniswap v3 pool.
+type PoolState struct {
+	Address string
+	// SqrtPriceX96 is the square root of the price of token0 in token1, as a Q64.96 value.
+	SqrtPriceX96 *big.Int
+	// Liquidity is the liquidity of the pool's current tick range.
+	Liquidity *big.Int
+}
+
+// Token is the ERC20 metadata of a token.
+type Token struct {
+	Address  string
+	Symbol   string
+	Decimals int64
+}
+
+// decodeSymbol decodes the result of an ERC20 symbol() call. Most tokens return an abi encoded string,
+// however some older tokens (e.g. MKR) return a bytes32.
+func decodeSymbol(bz []byte) string {
+	if len(bz) == 32 {
+		return validSymbol(string(bytes.TrimRight(bz, "\x00")))
+	}
+
+	// abi encoded string: offset, length, data.
+	if len(bz) < 64 {
+		return ""
+	}
+
+	// the comparisons subtract from the length of bz instead of adding to the decoded values, which a hostile
+	// token could choose to overflow.
+	size := uint64(len(bz))
+	offset := new(big.Int).SetBytes(bz[:32])
+	if !offset.IsUint64() || offset.Uint64() > size-32 {
+		return ""
+	}
+
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(bz[start-32 : start])
+	if !length.IsUint64() || length.Uint64() > size-start {
+		return ""
+	}
+
+	return validSymbol(string(bz[start : start+length.Uint64()]))
+}
+
+func validSymbol(s string) string {
+	if !utf8.ValidString(s) {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package uniswapv3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func abiWord(i *big.Int) []byte {
+	return common.LeftPadBytes(i.Bytes(), 32)
+}
+
+func TestDecodeSymbol(t *testing.T) {
+	maxUint64 := new(big.Int).SetUint64(^uint64(0))
+
+	tests := []struct {
+		name string
+		bz   []byte
+		want string
+	}{
+		{
+			name: "abi encoded string",
+			bz:   append(append(abiWord(big.NewInt(32)), abiWord(big.NewInt(4))...), common.RightPadBytes([]byte("USDC"), 32)...),
+			want: "USDC",
+		},
+		{
+			name: "bytes32",
+			bz:   common.RightPadBytes([]byte("MKR"), 32),
+			want: "MKR",
+		},
+		{
+			name: "too short",
+			bz:   []byte{1, 2, 3},
+		},
+		{
+			name: "offset out of range",
+			bz:   append(abiWord(big.NewInt(64)), abiWord(big.NewInt(4))...),
+		},
+		{
+			name: "offset overflows",
+			bz:   append(abiWord(new(big.Int).Sub(maxUint64, big.NewInt(15))), abiWord(big.NewInt(4))...),
+		},
+		{
+			name: "length out of range",
+			bz:   append(abiWord(big.NewInt(32)), abiWord(big.NewInt(33))...),
+		},
+		{
+			name: "length overflows",
+			bz:   append(abiWord(big.NewInt(32)), abiWord(new(big.Int).Sub(maxUint64, big.NewInt(31)))...),
+		},
+		{
+			name: "offset is not a uint64",
+			bz:   append(abiWord(new(big.Int).Lsh(big.NewInt(1), 64)), abiWord(big.NewInt(4))...),
+		},
+		{
+			name: "invalid utf8",
+			bz:   append(append(abiWord(big.NewInt(32)), abiWord(big.NewInt(1))...), common.RightPadBytes([]byte{0xff}, 32)...),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, decodeSymbol(tc.bz))
+		})
+	}
+}
+
+func FuzzDecodeSymbol(f *testing.F) {
+	f.Add(append(abiWord(new(big.Int).SetUint64(^uint64(0)-15)), abiWord(big.NewInt(4))...))
+	f.Add(append(abiWord(big.NewInt(32)), abiWord(new(big.Int).SetUint64(^uint64(0)-31))...))
+
+	f.Fuzz(func(_ *testing.T, bz []byte) {
+		decodeSymbol(bz)
+	})
+}
+
+func TestDecodeInt256(t *testing.T) {
+	tests := []struct {
+		name string
+		word []byte
+		want *big.Int
+	}{
+		{
+			name: "positive",
+			word: abiWord(big.NewInt(3000e6)),
+			want: big.NewInt(3000e6),
+		},
+		{
+			name: "negative",
+			word: abiWord(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1e18))),
+			want: big.NewInt(-1e18),
+		},
+		{
+			name: "zero",
+			word: make([]byte, 32),
+			want: big.NewInt(0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, 0, tc.want.Cmp(decodeInt256(tc.word)))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package upbit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/skip-mev/connect-mmu/lib/http"
+)
+
+const (
+	EndpointMarkets = "https://api.upbit.com/v1/market/all?isDetails=true"
+	EndpointTickers = "https://api.upbit.com/v1/ticker/all?quote_currencies=" + QuoteKRW
+)
+
+var _ Client = &httpClient{}
+
+// Client is an interface for a client that can interact with
+// the Upbit api
+//
+//go:generate mockery --name Client --filename mock_upbit_client.go
+type Client interface {
+	// Markets returns all markets on upbit.
+	Markets(context.Context) ([]MarketData, error)
+
+	// Tickers returns all KRW tickers on upbit.
+	Tickers(context.Context) ([]TickerData, error)
+}
+
+type httpClient struct {
+	client *http.Client
+}
+
+// NewClient is the default implementation
+// of the Client using HTTP.
+func NewClient(opts ...http.ClientOption) Client {
+	return &httpClient{
+		client: http.NewClient(opts...),
+	}
+}
+
+// Markets returns all markets on the Upbit API.
+func (h *httpClient) Markets(ctx context.Context) ([]MarketData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointMarkets)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var markets []MarketData
+	if err := json.NewDecoder(resp.Body).Decode(&markets); err != nil {
+		return nil, err
+	}
+
+	return markets, nil
+}
+
+// Tickers returns all KRW tickers on the Upbit API.
+func (h *httpClient) Tickers(ctx context.Context) ([]TickerData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointTickers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tickers []TickerData
+	if err := json.NewDecoder(resp.Body).Decode(&tickers); err != nil {
+		return nil, err
+	}
+
+	return tickers, nil
+}
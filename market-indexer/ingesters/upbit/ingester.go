@@ -0,0 +1,136 @@
+package upbit
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	Name         = "upbit"
+	ProviderName = Name + types.ProviderNameSuffixWS
+)
+
+var _ ingesters.Ingester = &Ingester{}
+
+// Ingester is the upbit implementation of a market data Ingester.
+// Only KRW markets are indexed.
+type Ingester struct {
+	logger  *zap.Logger
+	aliases *symbols.AliasRegistry
+
+	client Client
+}
+
+// New creates a new upbit Ingester.
+func New(logger *zap.Logger, aliases *symbols.AliasRegistry, opts ...http.ClientOption) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  NewClient(opts...),
+	}
+}
+
+// NewWithClient creates a new upbit Ingester with the given Client.
+func NewWithClient(logger *zap.Logger, aliases *symbols.AliasRegistry, client Client) *Ingester {
+	if logger == nil {
+		panic("cannot set nil logger")
+	}
+
+	return &Ingester{
+		logger:  logger.With(zap.String("ingester", Name)),
+		aliases: aliases,
+		client:  client,
+	}
+}
+
+// Registration returns the registration of the Upbit ingester for an ingesters.Registry.
+func Registration() ingesters.Registration {
+	return ingesters.Registration{
+		Name:         Name,
+		ProviderName: ProviderName,
+		Factory: func(
+			logger *zap.Logger,
+			_ config.MarketConfig,
+			opts config.IngesterOptions,
+			aliases *symbols.AliasRegistry,
+			httpOpts ...http.ClientOption,
+		) (ingesters.Ingester, error) {
+			clientOpts, err := ingesters.HTTPClientOptions(opts, httpOpts...)
+			if err != nil {
+				return nil, err
+			}
+			return New(logger, aliases, clientOpts...), nil
+		},
+	}
+}
+
+func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	ig.logger.Info("fetching data")
+
+	markets, err := ig.client.Markets(ctx)
+	if err != nil {
+		ig.logger.Error("failed to fetch markets", zap.Error(err))
+		return nil, err
+	}
+
+	tickers, err := ig.client.Tickers(ctx)
+	if err != nil {
+		ig.logger.Error("failed to fetch tickers", zap.Error(err))
+		return nil, err
+	}
+
+	ig.logger.Info("fetched data", zap.Int("count", len(tickers)))
+
+	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
+	tickerMap := tickersToMap(tickers)
+	for _, market := range markets {
+		if !strings.HasPrefix(market.Market, QuoteKRW+delimiter) {
+			continue
+		}
+
+		if market.MarketWarning == MarketWarningCaution {
+			ig.logger.Debug("ignoring market under caution", zap.String("market", market.Market))
+			continue
+		}
+
+		ticker, found := tickerMap[market.Market]
+		if !found {
+			ig.logger.Debug("ignoring market without ticker", zap.String("market", market.Market))
+			continue
+		}
+
+		pm, err := market.toProviderMarket(ig.aliases, ticker)
+		if err != nil {
+			ig.logger.Warn("ignoring market because can not convert to provider market",
+				zap.String("exchange", Name),
+				zap.Any("market", market),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		pms = append(pms, pm)
+	}
+
+	ig.logger.Info("creates", zap.Int("count", len(pms)))
+
+	return pms, nil
+}
+
+// Name returns the Ingester's human-readable name.
+func (ig *Ingester) Name() string {
+	return Name
+}
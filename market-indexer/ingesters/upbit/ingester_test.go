@@ -0,0 +1,72 @@
+package upbit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/upbit"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/upbit/mocks"
+)
+
+func TestIngesterReturnsErrorOnMarketsError(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := upbit.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Markets", ctx).Return(nil, fmt.Errorf("error"))
+
+	_, err := ingester.GetProviderMarkets(ctx)
+	require.Error(t, err)
+}
+
+func TestIngesterErrorsOnTickersError(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := upbit.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Markets", ctx).Return([]upbit.MarketData{}, nil)
+	client.On("Tickers", ctx).Return(nil, fmt.Errorf("error"))
+
+	_, err := ingester.GetProviderMarkets(ctx)
+	require.Error(t, err)
+}
+
+// Test that the ingester only returns KRW markets that are not under caution.
+func TestIngesterOnlyReturnsKRWMarkets(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := upbit.NewWithClient(zap.NewNop(), nil, client)
+
+	ctx := context.Background()
+	client.On("Markets", ctx).Return([]upbit.MarketData{
+		{Market: "KRW-BTC", EnglishName: "Bitcoin", MarketWarning: "NONE"},
+		{Market: "BTC-ETH", EnglishName: "Ethereum", MarketWarning: "NONE"},
+		{Market: "KRW-ETH", EnglishName: "Ethereum", MarketWarning: "NONE"},
+		{Market: "KRW-LOOM", EnglishName: "Loom Network", MarketWarning: upbit.MarketWarningCaution},
+		{Market: "USDT-BTC", EnglishName: "Bitcoin", MarketWarning: "NONE"},
+	}, nil)
+
+	client.On("Tickers", ctx).Return([]upbit.TickerData{
+		{Market: "KRW-BTC", TradePrice: 91500000, AccTradePrice24H: 120384958234.12},
+		{Market: "KRW-ETH", TradePrice: 3500000, AccTradePrice24H: 50000000000},
+		{Market: "KRW-LOOM", TradePrice: 100, AccTradePrice24H: 1000000},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, markets, 2)
+	require.Equal(t, "BTC", markets[0].Create.TargetBase)
+	require.Equal(t, "KRW", markets[0].Create.TargetQuote)
+	require.Equal(t, "KRW-BTC", markets[0].Create.OffChainTicker)
+	require.Equal(t, upbit.ProviderName, markets[0].Create.ProviderName)
+	require.Equal(t, 120384958234.12, markets[0].Create.QuoteVolume)
+	require.Equal(t, float64(91500000), markets[0].Create.ReferencePrice)
+
+	require.Equal(t, "ETH", markets[1].Create.TargetBase)
+	require.Equal(t, "KRW", markets[1].Create.TargetQuote)
+	require.Equal(t, "KRW-ETH", markets[1].Create.OffChainTicker)
+}
@@ -0,0 +1,119 @@
+package upbit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	// QuoteKRW is the only quote currency indexed from upbit.
+	QuoteKRW = "KRW"
+
+	// MarketWarningCaution is the warning set on markets that upbit has flagged for delisting review.
+	MarketWarningCaution = "CAUTION"
+
+	delimiter = "-"
+)
+
+// MarketData is a single market returned from the upbit
+// markets API.
+//
+// Docs: https://global-docs.upbit.com/reference/listing-market-list
+//
+// Ex.
+//
+//	[
+//	  {
+//	    "market": "KRW-BTC",
+//	    "korean_name": "비트코인",
+//	    "english_name": "Bitcoin",
+//	    "market_warning": "NONE"
+//	  }
+//	]
+type MarketData struct {
+	Market        string `json:"market"`
+	EnglishName   string `json:"english_name"`
+	MarketWarning string `json:"market_warning"`
+}
+
+// baseQuote splits an upbit market code (QUOTE-BASE) into its base and quote.
+func (md *MarketData) baseQuote() (string, string, error) {
+	split := strings.Split(md.Market, delimiter)
+	if len(split) != 2 {
+		return "", "", fmt.Errorf("invalid upbit market %q", md.Market)
+	}
+
+	return split[1], split[0], nil
+}
+
+func (md *MarketData) toProviderMarket(aliases *symbols.AliasRegistry, td TickerData) (provider.CreateProviderMarket, error) {
+	base, quote, err := md.baseQuote()
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	targetBase, err := symbols.ToCanonicalTickerString(aliases, Name, base)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+	targetQuote, err := symbols.ToCanonicalTickerString(aliases, Name, quote)
+	if err != nil {
+		return provider.CreateProviderMarket{}, err
+	}
+
+	pm := provider.CreateProviderMarket{
+		Create: provider.CreateProviderMarketParams{
+			TargetBase:     targetBase,
+			TargetQuote:    targetQuote,
+			OffChainTicker: md.Market,
+			ProviderName:   ProviderName,
+			QuoteVolume:    td.AccTradePrice24H,
+			ReferencePrice: td.TradePrice,
+		},
+	}
+
+	return pm, pm.ValidateBasic()
+}
+
+// TickerData is a single ticker returned from the upbit
+// tickers API.
+//
+// Docs: https://global-docs.upbit.com/reference/list-quote-tickers
+//
+// Ex.
+//
+//	[
+//	  {
+//	    "market": "KRW-BTC",
+//	    "trade_date": "20241016",
+//	    "trade_time": "041232",
+//	    "opening_price": 91000000,
+//	    "high_price": 92000000,
+//	    "low_price": 90500000,
+//	    "trade_price": 91500000,
+//	    "prev_closing_price": 91000000,
+//	    "acc_trade_price_24h": 120384958234.12,
+//	    "acc_trade_volume_24h": 1320.5,
+//	    "timestamp": 1729051952000
+//	  }
+//	]
+type TickerData struct {
+	Market     string  `json:"market"`
+	TradePrice float64 `json:"trade_price"`
+	// AccTradePrice24H is the 24hr volume in terms of the quote currency.
+	AccTradePrice24H  float64 `json:"acc_trade_price_24h"`
+	AccTradeVolume24H float64 `json:"acc_trade_volume_24h"`
+}
+
+func tickersToMap(tickers []TickerData) map[string]TickerData {
+	m := make(map[string]TickerData, len(tickers))
+
+	for _, t := range tickers {
+		m[t.Market] = t
+	}
+
+	return m
+}
@@ -0,0 +1,60 @@
+package indexer
+
+import (
+	"errors"
+
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitfinex"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitget"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bithumb"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitstamp"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bybit"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/coinbase"
+	crypto_com "github.com/skip-mev/connect-mmu/market-indexer/ingesters/crypto.com"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gecko"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kucoin"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/meteora"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/mexc"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/orca"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/uniswapv3"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/upbit"
+)
+
+// NewDefaultIngesterRegistry returns an ingesters.Registry with all ingesters in this
+// repository registered. Additional ingesters may be registered on the returned registry.
+func NewDefaultIngesterRegistry() (*ingesters.Registry, error) {
+	r := ingesters.NewRegistry()
+	err := errors.Join(
+		r.RegisterIngester(binance.Registration()),
+		r.RegisterIngester(bitfinex.Registration()),
+		r.RegisterIngester(bitget.Registration()),
+		r.RegisterIngester(bithumb.Registration()),
+		r.RegisterIngester(bitstamp.Registration()),
+		r.RegisterIngester(bybit.Registration()),
+		r.RegisterIngester(coinbase.Registration()),
+		r.RegisterIngester(crypto_com.Registration()),
+		r.RegisterIngester(gate.Registration()),
+		r.RegisterIngester(gecko.Registration()),
+		r.RegisterIngester(huobi.Registration()),
+		r.RegisterIngester(kraken.Registration()),
+		r.RegisterIngester(kucoin.Registration()),
+		r.RegisterIngester(meteora.Registration()),
+		r.RegisterIngester(mexc.Registration()),
+		r.RegisterIngester(okx.Registration()),
+		r.RegisterIngester(orca.Registration()),
+		r.RegisterIngester(raydium.Registration()),
+		r.RegisterIngester(uniswapv3.Registration()),
+		r.RegisterIngester(upbit.Registration()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
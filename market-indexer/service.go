@@ -4,26 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/lib/symbols"
+	"github.com/skip-mev/connect-mmu/market-indexer/coingecko"
 	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitfinex"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitstamp"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bybit"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/coinbase"
-	crypto_com "github.com/skip-mev/connect-mmu/market-indexer/ingesters/crypto.com"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gecko"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kucoin"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/mexc"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx"
-	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium"
 	"github.com/skip-mev/connect-mmu/market-indexer/utils"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
@@ -33,8 +26,15 @@ import (
 type Indexer struct {
 	logger *zap.Logger
 
-	igs        []ingesters.Ingester
+	igs []ingesters.Ingester
+	// igOptions are the options of each ingester, in the same order as igs.
+	igOptions []config.IngesterOptions
+	registry  *ingesters.Registry
+	// aliases resolve the symbols of ingested markets.
+	aliases    *symbols.AliasRegistry
 	cmcIndexer *coinmarketcap.Indexer
+	// cgIndexer is nil if CoinGecko is not enabled.
+	cgIndexer *coingecko.Indexer
 
 	providerStore provider.Store
 
@@ -43,92 +43,137 @@ type Indexer struct {
 	// knownAssets is a local cache of the known assets in the AssetsInfo table.
 	knownAssets utils.AssetMap
 
+	// coinGecko is set once CoinGecko assets have been indexed.
+	coinGecko *coinGeckoAggregator
+	// cmcUnavailable is set if CoinMarketCap assets could not be indexed.
+	cmcUnavailable bool
+
 	archiveIntermediateSteps bool
+	// archiveDir is the directory intermediate files are archived in.
+	archiveDir string
+	// fromArchive is the directory of the archive that is replayed, or empty if markets are fetched.
+	fromArchive string
 }
 
-const coinMarketCapKey = "CMC_API_KEY"
+const (
+	coinMarketCapKey = "CMC_API_KEY"
+	coinGeckoKey     = "COINGECKO_API_KEY"
+)
 
-// NewIndexer creates a new Indexer with the provided config.
-func NewIndexer(cfg config.MarketConfig, logger *zap.Logger, writer provider.Store, archiveIntermediateSteps bool) (*Indexer, error) {
+// NewIndexer creates a new Indexer with the provided config. The configured ingesters
+// are created from the given registry, and resolve symbols with the given aliases. httpOpts
+// are applied to every http client of the indexer and its ingesters, e.g. the transport of a cassette.
+func NewIndexer(
+	cfg config.MarketConfig,
+	logger *zap.Logger,
+	writer provider.Store,
+	registry *ingesters.Registry,
+	aliases *symbols.AliasRegistry,
+	archiveIntermediateSteps bool,
+	httpOpts ...http.ClientOption,
+) (*Indexer, error) {
 	envCMCKey := os.Getenv(coinMarketCapKey)
 	if envCMCKey != "" {
 		cfg.CoinMarketCapConfig.APIKey = envCMCKey
 	}
 
+	envCoinGeckoKey := os.Getenv(coinGeckoKey)
+	if envCoinGeckoKey != "" {
+		cfg.CoinGeckoConfig.APIKey = envCoinGeckoKey
+	}
+
 	svc := Indexer{
 		logger:                   logger.With(zap.String("mmu-service", "indexer")),
 		providerStore:            writer,
-		cmcIndexer:               coinmarketcap.New(logger, cfg.CoinMarketCapConfig.APIKey),
+		registry:                 registry,
+		aliases:                  aliases,
+		cmcIndexer:               coinmarketcap.New(logger, cfg.CoinMarketCapConfig, registry, httpOpts...),
 		config:                   cfg,
 		knownAssets:              make(utils.AssetMap),
 		archiveIntermediateSteps: archiveIntermediateSteps,
+		archiveDir:               DefaultArchiveDir,
+	}
+
+	if cfg.CoinGeckoConfig.Enabled {
+		svc.cgIndexer = coingecko.New(logger, cfg.CoinGeckoConfig, httpOpts...)
 	}
 
 	igs := make([]ingesters.Ingester, len(cfg.Ingesters))
+	igOptions := make([]config.IngesterOptions, len(cfg.Ingesters))
 	for i, ingestConfig := range cfg.Ingesters {
-		switch ingestConfig.Name {
-		case kucoin.Name:
-			igs[i] = kucoin.New(logger)
-		case crypto_com.Name:
-			igs[i] = crypto_com.New(logger)
-		case kraken.Name:
-			igs[i] = kraken.New(logger)
-		case bitfinex.Name:
-			igs[i] = bitfinex.New(logger)
-		case bybit.Name:
-			igs[i] = bybit.New(logger)
-		case binance.Name:
-			igs[i] = binance.New(logger)
-		case okx.Name:
-			igs[i] = okx.New(logger)
-		case coinbase.Name:
-			igs[i] = coinbase.New(logger)
-		case mexc.Name:
-			igs[i] = mexc.New(logger)
-		case gate.Name:
-			igs[i] = gate.New(logger)
-		case bitstamp.Name:
-			igs[i] = bitstamp.New(logger)
-		case huobi.Name:
-			igs[i] = huobi.New(logger)
-		case raydium.Name:
-			igs[i] = raydium.New(logger, cfg, cfg.CoinMarketCapConfig.APIKey)
-		case gecko.Name:
-			igs[i] = gecko.New(logger, cfg)
-		default:
-			return nil, fmt.Errorf("provider %s is unsupported", ingestConfig.Name)
+		ig, err := registry.CreateIngester(logger, ingestConfig.Name, cfg, aliases, httpOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s is unsupported: %w", ingestConfig.Name, err)
+		}
+		igs[i] = ig
+
+		igOptions[i], err = ingestConfig.DecodeOptions()
+		if err != nil {
+			return nil, fmt.Errorf("ingester %s options invalid: %w", ingestConfig.Name, err)
 		}
 	}
 	svc.igs = igs
+	svc.igOptions = igOptions
 	return &svc, nil
 }
 
 // Index collects market data for each ingester and returns the combined data.
-// TODO: parallelize and optimize.
+// Ingesters fetch their markets concurrently, but aggregator association and store writes
+// are performed in ingester config order so that the output is deterministic.
 func (idx *Indexer) Index(ctx context.Context) error {
+	startedAt := time.Now().UTC()
+	idx.aliases.ResetReport()
+
 	cmcMarketPairs, err := idx.SetupAssets(ctx)
 	if err != nil {
 		idx.logger.Error("error setting up known assets", zap.Error(err))
 		return err
 	}
 
-	count := 0
-	for _, ingester := range idx.igs {
-		idx.logger.Info("starting", zap.String("ingester", ingester.Name()))
+	allIngesterMarkets, failures, err := idx.fetchProviderMarkets(ctx)
+	if err != nil {
+		return err
+	}
 
-		ingesterMarkets, err := ingester.GetProviderMarkets(ctx)
-		if err != nil {
-			idx.logger.Error("error getting markets", zap.Bool("mmu_datadog", true), zap.String("ingester", ingester.Name()), zap.Error(err))
-			return err
+	firedAliases := idx.aliases.Report()
+	for _, alias := range firedAliases {
+		idx.logger.Info("applied symbol alias",
+			zap.String("venue", alias.Venue),
+			zap.String("alias", alias.Alias),
+			zap.String("canonical", alias.Canonical),
+			zap.Int("count", alias.Count),
+		)
+	}
+
+	associatedMarkets := make([][]provider.CreateProviderMarket, len(idx.igs))
+	for i, ingester := range idx.igs {
+		if failures[i] != nil {
+			idx.logger.Warn("skipping failed optional ingester", zap.String("ingester", ingester.Name()))
+			continue
 		}
 
-		idx.logger.Info("associating coin market cap for provider", zap.String("ingester", ingester.Name()))
-		transformed, err := idx.AssociateAggregator(ctx, ingesterMarkets, cmcMarketPairs)
+		idx.logger.Info("associating aggregators for provider", zap.String("ingester", ingester.Name()))
+		transformed, err := idx.AssociateAggregator(ctx, allIngesterMarkets[i], cmcMarketPairs)
 		if err != nil {
 			idx.logger.Error("error associating aggregators", zap.Bool("mmu_datadog", true), zap.String("ingester", ingester.Name()), zap.Error(err))
 			return err
 		}
-		idx.logger.Info("associated coin market cap for provider", zap.String("ingester", ingester.Name()), zap.Int("markets", len(transformed)))
+		idx.logger.Info("associated aggregators for provider", zap.String("ingester", ingester.Name()), zap.Int("markets", len(transformed)))
+		associatedMarkets[i] = transformed
+	}
+
+	// order books are a fallback for the markets that have no depth once aggregators are associated.
+	if err := idx.addOrderBookDepths(ctx, associatedMarkets); err != nil {
+		return err
+	}
+
+	count := 0
+	for i, ingester := range idx.igs {
+		if failures[i] != nil {
+			continue
+		}
+
+		transformed := associatedMarkets[i]
 
 		for _, pm := range transformed {
 			if _, err := idx.providerStore.AddProviderMarket(ctx, pm.Create); err != nil {
@@ -142,14 +187,257 @@ func (idx *Indexer) Index(ctx context.Context) error {
 
 	idx.logger.Info("committing provider markets tx to store...", zap.Int("total markets", count))
 
-	return nil
+	// asset infos are added while associating aggregators, so their changes are only known now.
+	assetInfoChanges := idx.providerStore.GetAssetInfoChanges(ctx)
+	idx.logger.Info("asset info changes", zap.Int("count", len(assetInfoChanges)))
+
+	// collect failures in ingester order so that the index report is deterministic.
+	failedIngesters := make([]provider.IngesterFailure, 0)
+	for _, failure := range failures {
+		if failure != nil {
+			failedIngesters = append(failedIngesters, *failure)
+		}
+	}
+
+	report := provider.IndexReport{
+		FailedIngesters:  failedIngesters,
+		FiredAliases:     firedAliases,
+		AssetInfoChanges: assetInfoChanges,
+		SkippedMarkets:   idx.skippedMarkets(failures),
+	}
+	if err := idx.providerStore.SetIndexReport(ctx, report); err != nil {
+		return err
+	}
+
+	return idx.setRunMetadata(ctx, startedAt)
+}
+
+// skippedMarkets returns the markets skipped by the ingesters that did not fail, in ingester order and then by reason.
+func (idx *Indexer) skippedMarkets(failures []*provider.IngesterFailure) []provider.SkippedMarkets {
+	var skipped []provider.SkippedMarkets
+	for i, ingester := range idx.igs {
+		reporter, ok := ingester.(ingesters.SkipReporter)
+		if !ok || failures[i] != nil {
+			continue
+		}
+
+		counts := reporter.SkippedMarkets()
+		reasons := maps.Keys(counts)
+		slices.Sort(reasons)
+		for _, reason := range reasons {
+			skipped = append(skipped, provider.SkippedMarkets{Ingester: ingester.Name(), Reason: reason, Count: counts[reason]})
+		}
+	}
+	return skipped
+}
+
+// setRunMetadata records the metadata of an index run that started at startedAt and has just finished.
+func (idx *Indexer) setRunMetadata(ctx context.Context, startedAt time.Time) error {
+	configHash, err := idx.config.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash index config: %w", err)
+	}
+
+	ingesterNames := make([]string, 0, len(idx.igs))
+	for _, ingester := range idx.igs {
+		ingesterNames = append(ingesterNames, ingester.Name())
+	}
+
+	return idx.providerStore.SetRunMetadata(ctx, provider.RunMetadata{
+		StartedAt:  startedAt,
+		FinishedAt: time.Now().UTC(),
+		ConfigHash: configHash,
+		Ingesters:  ingesterNames,
+	})
+}
+
+// fetchProviderMarkets runs GetProviderMarkets for every ingester, running at most
+// MaxConcurrentIngesters at a time. Each ingester is bounded by the configured IngesterTimeout.
+// The returned markets and failures are indexed in the same order as the Indexer's ingesters.
+//
+// If an optional ingester fails, its failure is set instead of returning an error, and its markets
+// are left nil. A failing required ingester fails the whole fetch.
+func (idx *Indexer) fetchProviderMarkets(ctx context.Context) ([][]provider.CreateProviderMarket, []*provider.IngesterFailure, error) {
+	limit := idx.config.MaxConcurrentIngesters
+	if limit == 0 {
+		limit = config.DefaultMaxConcurrentIngesters
+	}
+
+	results := make([][]provider.CreateProviderMarket, len(idx.igs))
+	failures := make([]*provider.IngesterFailure, len(idx.igs))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(limit)
+	for i, ingester := range idx.igs {
+		eg.Go(func() error {
+			idx.logger.Info("starting", zap.String("ingester", ingester.Name()))
+
+			ingesterCtx := ctx
+			if idx.config.IngesterTimeout > 0 {
+				var cancel context.CancelFunc
+				ingesterCtx, cancel = context.WithTimeout(ctx, idx.config.IngesterTimeout)
+				defer cancel()
+			}
+
+			ingesterMarkets, err := ingester.GetProviderMarkets(ingesterCtx)
+			if err != nil {
+				optional := idx.isOptionalIngester(ingester.Name())
+				idx.logger.Error("error getting markets", zap.Bool("mmu_datadog", true), zap.String("ingester", ingester.Name()), zap.Bool("optional", optional), zap.Error(err))
+				if !optional {
+					return fmt.Errorf("failed to get markets for ingester %s: %w", ingester.Name(), err)
+				}
+
+				failures[i] = &provider.IngesterFailure{
+					Ingester:      ingester.Name(),
+					ProviderNames: idx.providerNamesForIngester(ingester.Name()),
+					Error:         err.Error(),
+				}
+				return nil
+			}
+
+			idx.logger.Info("fetched markets", zap.String("ingester", ingester.Name()), zap.Int("num markets", len(ingesterMarkets)))
+
+			filteredMarkets := ingesters.FilterMarkets(idx.igOptions[i], ingesterMarkets)
+			if filtered := len(ingesterMarkets) - len(filteredMarkets); filtered > 0 {
+				idx.logger.Info("filtered markets by ingester options", zap.String("ingester", ingester.Name()), zap.Int("num markets", filtered))
+			}
+			results[i] = filteredMarkets
+
+			if err := idx.archiveIntermediateFile(filteredMarkets, ArchiveFileIngesterMarkets(ingester.Name())); err != nil {
+				return fmt.Errorf("failed to archive markets for ingester %s: %w", ingester.Name(), err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return results, failures, nil
+}
+
+// addOrderBookDepths sets the order book depth of the associated markets of every ingester with the
+// order_book_depth option, running at most MaxConcurrentIngesters ingesters at a time. The markets are
+// archived with their depth, since order books cannot be fetched when replaying an archive.
+func (idx *Indexer) addOrderBookDepths(ctx context.Context, markets [][]provider.CreateProviderMarket) error {
+	limit := idx.config.MaxConcurrentIngesters
+	if limit == 0 {
+		limit = config.DefaultMaxConcurrentIngesters
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(limit)
+	for i, ingester := range idx.igs {
+		opts := idx.igOptions[i]
+		if markets[i] == nil || !opts.OrderBookDepth {
+			continue
+		}
+
+		eg.Go(func() error {
+			withDepth, err := loadOrFetch(idx, ArchiveFileIngesterOrderBookMarkets(ingester.Name()), func() ([]provider.CreateProviderMarket, error) {
+				return idx.addOrderBookDepth(ctx, ingester, opts, markets[i])
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add order book depth for ingester %s: %w", ingester.Name(), err)
+			}
+
+			markets[i] = withDepth
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+// addOrderBookDepth sets the order book depth of the markets of ingesters that can fetch order books.
+func (idx *Indexer) addOrderBookDepth(
+	ctx context.Context,
+	ingester ingesters.Ingester,
+	opts config.IngesterOptions,
+	markets []provider.CreateProviderMarket,
+) ([]provider.CreateProviderMarket, error) {
+	orderBookIngester, ok := ingester.(ingesters.OrderBookIngester)
+	if !ok {
+		idx.logger.Warn("ingester does not support order book depth", zap.String("ingester", ingester.Name()))
+		return markets, nil
+	}
+
+	rateLimit := opts.OrderBookRateLimit
+	if rateLimit == 0 {
+		rateLimit = config.DefaultOrderBookRateLimit
+	}
+
+	logger := idx.logger.With(zap.String("ingester", ingester.Name()))
+	return ingesters.AddOrderBookDepth(ctx, logger, orderBookIngester, markets, rateLimit)
+}
+
+// isOptionalIngester returns true if the ingester with the given name is configured as optional.
+func (idx *Indexer) isOptionalIngester(name string) bool {
+	for _, ingesterConfig := range idx.config.Ingesters {
+		if ingesterConfig.Name == name {
+			return ingesterConfig.IsOptional()
+		}
+	}
+
+	return false
+}
+
+// providerNamesForIngester returns the provider names whose markets are produced by the ingester with the given name.
+func (idx *Indexer) providerNamesForIngester(name string) []string {
+	registration, found := idx.registry.Get(name)
+	if !found {
+		return []string{ingesters.ProviderNameUnknown}
+	}
+
+	venues := registration.GetVenues(idx.config)
+	providerNames := make([]string, 0, len(venues))
+	for _, venue := range venues {
+		providerNames = append(providerNames, venue.ProviderName)
+	}
+
+	return providerNames
 }
 
 // AssociateAggregator associates market aggregator data with each provider market to be written to the db.
+// Aggregators are tried in order of preference, CoinMarketCap first and then CoinGecko, and the first
+// aggregator that identifies both assets of a market is used. Markets that no aggregator identifies are dropped.
 func (idx *Indexer) AssociateAggregator(
 	ctx context.Context,
 	inputs []provider.CreateProviderMarket,
 	providerMarketPairs coinmarketcap.ProviderMarketPairs,
 ) ([]provider.CreateProviderMarket, error) {
-	return idx.AssociateCoinMarketCap(ctx, inputs, providerMarketPairs)
+	aggregators := idx.aggregators(providerMarketPairs)
+
+	associatedInputs := make([]provider.CreateProviderMarket, 0, len(inputs))
+	for _, input := range inputs {
+		for _, aggregator := range aggregators {
+			associated, ok, err := aggregator.Associate(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("failed to associate %s: %w", aggregator.Name(), err)
+			}
+
+			if ok {
+				associatedInputs = append(associatedInputs, associated)
+				break
+			}
+		}
+	}
+
+	return associatedInputs, nil
+}
+
+// aggregators returns the available aggregators in order of preference.
+func (idx *Indexer) aggregators(providerMarketPairs coinmarketcap.ProviderMarketPairs) []Aggregator {
+	aggregators := make([]Aggregator, 0, 2)
+	if !idx.cmcUnavailable {
+		aggregators = append(aggregators, &coinMarketCapAggregator{idx: idx, pairs: providerMarketPairs})
+	}
+
+	if idx.coinGecko != nil {
+		aggregators = append(aggregators, idx.coinGecko)
+	}
+
+	return aggregators
 }
@@ -0,0 +1,438 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/market-indexer/ingesters"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+var (
+	_ ingesters.Ingester          = &testIngester{}
+	_ ingesters.OrderBookIngester = &testOrderBookIngester{}
+	_ ingesters.SkipReporter      = &testSkipIngester{}
+)
+
+// testIngester is an ingester that returns a single market, or none if empty, after an optional delay.
+type testIngester struct {
+	name   string
+	delay  time.Duration
+	err    error
+	volume float64
+	empty  bool
+
+	running    *atomic.Int32
+	maxRunning *atomic.Int32
+}
+
+func (t *testIngester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
+	if t.running != nil {
+		n := t.running.Add(1)
+		defer t.running.Add(-1)
+		for {
+			prev := t.maxRunning.Load()
+			if n <= prev || t.maxRunning.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(t.delay):
+	}
+
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.empty {
+		return nil, nil
+	}
+
+	return []provider.CreateProviderMarket{
+		{
+			Create: provider.CreateProviderMarketParams{
+				TargetBase:     "BTC",
+				TargetQuote:    "USD",
+				OffChainTicker: "BTC-USD",
+				ProviderName:   t.name,
+				QuoteVolume:    t.volume,
+			},
+		},
+	}, nil
+}
+
+func (t *testIngester) Name() string {
+	return t.name
+}
+
+// testOrderBookIngester is a testIngester that returns the same order book for every market.
+type testOrderBookIngester struct {
+	testIngester
+	book ingesters.OrderBook
+}
+
+func (t *testOrderBookIngester) OrderBook(_ context.Context, _ provider.CreateProviderMarket) (ingesters.OrderBook, error) {
+	return t.book, nil
+}
+
+// testSkipIngester is a testIngester that reports skipped markets.
+type testSkipIngester struct {
+	testIngester
+	skipped map[string]int
+}
+
+func (t *testSkipIngester) SkippedMarkets() map[string]int {
+	return t.skipped
+}
+
+func newTestIndexer(t *testing.T, cfg config.MarketConfig, igs ...ingesters.Ingester) *Indexer {
+	t.Helper()
+
+	registry, err := NewDefaultIngesterRegistry()
+	require.NoError(t, err)
+
+	igOptions := make([]config.IngesterOptions, len(igs))
+	for i, ig := range igs {
+		igOptions[i], err = cfg.IngesterOptions(ig.Name())
+		require.NoError(t, err)
+	}
+
+	return &Indexer{
+		logger:    zap.NewNop(),
+		igs:       igs,
+		igOptions: igOptions,
+		registry:  registry,
+		config:    cfg,
+	}
+}
+
+func TestFetchProviderMarketsPreservesIngesterOrder(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{MaxConcurrentIngesters: 3},
+		&testIngester{name: "slow", delay: 50 * time.Millisecond},
+		&testIngester{name: "medium", delay: 25 * time.Millisecond},
+		&testIngester{name: "fast"},
+	)
+
+	results, _, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, "slow", results[0][0].Create.ProviderName)
+	require.Equal(t, "medium", results[1][0].Create.ProviderName)
+	require.Equal(t, "fast", results[2][0].Create.ProviderName)
+}
+
+func TestFetchProviderMarketsRespectsConcurrencyLimit(t *testing.T) {
+	running := &atomic.Int32{}
+	maxRunning := &atomic.Int32{}
+
+	igs := make([]ingesters.Ingester, 0, 6)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		igs = append(igs, &testIngester{
+			name:       name,
+			delay:      10 * time.Millisecond,
+			running:    running,
+			maxRunning: maxRunning,
+		})
+	}
+
+	idx := newTestIndexer(t, config.MarketConfig{MaxConcurrentIngesters: 2}, igs...)
+
+	_, _, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestFetchProviderMarketsIngesterTimeout(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{IngesterTimeout: 10 * time.Millisecond},
+		&testIngester{name: "fast"},
+		&testIngester{name: "stuck", delay: time.Minute},
+	)
+
+	_, _, err := idx.fetchProviderMarkets(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "stuck")
+}
+
+func TestFetchProviderMarketsReturnsIngesterError(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{},
+		&testIngester{name: "ok"},
+		&testIngester{name: "broken", err: errors.New("boom")},
+	)
+
+	_, _, err := idx.fetchProviderMarkets(context.Background())
+	require.ErrorContains(t, err, "boom")
+}
+
+func TestFetchProviderMarketsFiltersByIngesterOptions(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{
+			Ingesters: []config.IngesterConfig{
+				{Name: "usd", Options: map[string]any{"quote_allowlist": []any{"usd"}}},
+				{Name: "usdt", Options: map[string]any{"quote_allowlist": []any{"USDT"}}},
+				{Name: "volume", Options: map[string]any{"min_volume": 1000}},
+			},
+		},
+		&testIngester{name: "usd"},
+		&testIngester{name: "usdt"},
+		&testIngester{name: "volume"},
+	)
+
+	results, _, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Len(t, results[0], 1)
+	require.Empty(t, results[1])
+	require.Empty(t, results[2])
+}
+
+func TestAddOrderBookDepths(t *testing.T) {
+	book := ingesters.OrderBook{
+		Bids: []ingesters.OrderBookLevel{{Price: 99, Size: 10}},
+		Asks: []ingesters.OrderBookLevel{{Price: 101, Size: 5}},
+	}
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{
+			Ingesters: []config.IngesterConfig{
+				{Name: "orderbook", Options: map[string]any{"min_volume": 1000, "order_book_depth": true, "order_book_rate_limit": 100}},
+				{Name: "disabled", Options: map[string]any{"min_volume": 1000}},
+				{Name: "unsupported", Options: map[string]any{"min_volume": 1000, "order_book_depth": true}},
+				{Name: "failed", Options: map[string]any{"min_volume": 1000, "order_book_depth": true}},
+			},
+		},
+		&testOrderBookIngester{testIngester: testIngester{name: "orderbook", volume: 2000}, book: book},
+		&testOrderBookIngester{testIngester: testIngester{name: "disabled", volume: 2000}, book: book},
+		&testIngester{name: "unsupported", volume: 2000},
+		&testOrderBookIngester{testIngester: testIngester{name: "failed", volume: 2000}, book: book},
+	)
+
+	results, _, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	// the market has depth once it is associated, which the order book must not replace.
+	associated := results[0][0]
+	associated.Create.OffChainTicker = "ETH-USD"
+	associated.Create.NegativeDepthTwo = 1
+	associated.Create.PositiveDepthTwo = 2
+	results[0] = append(results[0], associated)
+	results[3] = nil
+
+	require.NoError(t, idx.addOrderBookDepths(context.Background(), results))
+
+	require.Len(t, results[0], 2)
+	require.Equal(t, 990.0, results[0][0].Create.NegativeDepthTwo)
+	require.Equal(t, 505.0, results[0][0].Create.PositiveDepthTwo)
+	require.Equal(t, 1.0, results[0][1].Create.NegativeDepthTwo)
+	require.Equal(t, 2.0, results[0][1].Create.PositiveDepthTwo)
+
+	for _, markets := range results[1:3] {
+		require.Len(t, markets, 1)
+		require.Zero(t, markets[0].Create.NegativeDepthTwo)
+		require.Zero(t, markets[0].Create.PositiveDepthTwo)
+	}
+	require.Nil(t, results[3])
+}
+
+func TestFetchProviderMarketsSkipsFailedOptionalIngester(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{
+			Ingesters: []config.IngesterConfig{
+				{Name: "ok"},
+				{Name: "okx", Policy: config.IngesterPolicyOptional},
+			},
+		},
+		&testIngester{name: "ok"},
+		&testIngester{name: "okx", err: errors.New("503 service unavailable")},
+	)
+
+	results, failures, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Len(t, results[0], 1)
+	require.Nil(t, results[1])
+
+	require.Equal(t, []*provider.IngesterFailure{
+		nil,
+		{
+			Ingester:      "okx",
+			ProviderNames: []string{"okx_ws"},
+			Error:         "503 service unavailable",
+		},
+	}, failures)
+}
+
+func TestFetchProviderMarketsEmptyIngesterIsNotFailed(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{
+			Ingesters: []config.IngesterConfig{
+				{Name: "okx", Policy: config.IngesterPolicyOptional},
+			},
+		},
+		&testIngester{name: "okx", empty: true},
+	)
+
+	results, failures, err := idx.fetchProviderMarkets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Empty(t, results[0])
+	require.Equal(t, []*provider.IngesterFailure{nil}, failures)
+}
+
+func TestSkippedMarkets(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{},
+		&testSkipIngester{testIngester: testIngester{name: "raydium"}, skipped: map[string]int{"invalid market": 2, "account not found": 1}},
+		&testIngester{name: "ok"},
+		&testSkipIngester{testIngester: testIngester{name: "failed"}, skipped: map[string]int{"invalid market": 1}},
+	)
+
+	failures := []*provider.IngesterFailure{nil, nil, {Ingester: "failed"}}
+	require.Equal(t, []provider.SkippedMarkets{
+		{Ingester: "raydium", Reason: "account not found", Count: 1},
+		{Ingester: "raydium", Reason: "invalid market", Count: 2},
+	}, idx.skippedMarkets(failures))
+}
+
+func TestFetchProviderMarketsFailsOnRequiredIngester(t *testing.T) {
+	idx := newTestIndexer(
+		t,
+		config.MarketConfig{
+			Ingesters: []config.IngesterConfig{
+				{Name: "okx", Policy: config.IngesterPolicyRequired},
+			},
+		},
+		&testIngester{name: "okx", err: errors.New("503 service unavailable")},
+	)
+
+	_, _, err := idx.fetchProviderMarkets(context.Background())
+	require.ErrorContains(t, err, "503 service unavailable")
+}
+
+// observedAt is the observation time of the test indexes, so that the documents of different runs are equal.
+var observedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// indexFromCassettes runs a coinbase-only index using the HTTP responses recorded in testdata/cassettes, which
+// has no cassettes for the other ingesters.
+func indexFromCassettes(t *testing.T) provider.Document {
+	t.Helper()
+
+	transport, err := http.NewCassetteTransport(http.CassetteModeReplay, "testdata/cassettes", nil)
+	require.NoError(t, err)
+
+	registry, err := NewDefaultIngesterRegistry()
+	require.NoError(t, err)
+
+	cfg := config.DefaultMarketConfig()
+	cfg.Ingesters = []config.IngesterConfig{{Name: "coinbase"}}
+
+	store := provider.NewMemoryStoreObservedAt(observedAt)
+	idx, err := NewIndexer(cfg, zap.NewNop(), store, registry, nil, false, http.WithTransport(transport))
+	require.NoError(t, err)
+	require.NoError(t, idx.Index(context.Background()))
+
+	return store.CreateOutputDocument()
+}
+
+func TestIndexReplaysCassettes(t *testing.T) {
+	doc := indexFromCassettes(t)
+
+	tickers := make([]string, 0, len(doc.ProviderMarkets))
+	for _, market := range doc.ProviderMarkets {
+		require.Equal(t, "coinbase_ws", market.ProviderName)
+		tickers = append(tickers, market.OffChainTicker)
+	}
+	require.ElementsMatch(t, []string{"BTC-USD", "ETH-USD", "BTC-USDT"}, tickers)
+
+	require.NotNil(t, doc.Run)
+	require.Equal(t, []string{"coinbase"}, doc.Run.Ingesters)
+	require.NotEmpty(t, doc.Run.ConfigHash)
+	require.False(t, doc.Run.FinishedAt.Before(doc.Run.StartedAt))
+
+	// the documents of both runs only differ by the times of their runs.
+	again := indexFromCassettes(t)
+	require.Equal(t, doc.Run.ConfigHash, again.Run.ConfigHash)
+	doc.Run, again.Run = nil, nil
+	require.Equal(t, doc, again)
+}
+
+func TestIndexFromArchive(t *testing.T) {
+	registry, err := NewDefaultIngesterRegistry()
+	require.NoError(t, err)
+
+	cfg := config.DefaultMarketConfig()
+	cfg.Ingesters = []config.IngesterConfig{
+		{Name: "coinbase", Options: map[string]any{"min_volume": 1, "order_book_depth": true, "order_book_rate_limit": 100}},
+	}
+
+	transport, err := http.NewCassetteTransport(http.CassetteModeReplay, "testdata/cassettes", nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	store := provider.NewMemoryStoreObservedAt(observedAt)
+	idx, err := NewIndexer(cfg, zap.NewNop(), store, registry, nil, true, http.WithTransport(transport))
+	require.NoError(t, err)
+	idx.archiveDir = dir
+	require.NoError(t, idx.ArchiveRun(ArchivedRun{ObservedAt: observedAt}))
+	require.NoError(t, idx.Index(context.Background()))
+
+	for _, filename := range []string{
+		ArchiveFileCMCCryptoData,
+		ArchiveFileCMCFiatData,
+		ArchiveFileCMCMarketPairs,
+		ArchiveFileCMCQuotes,
+		ArchiveFileRun,
+		ArchiveFileIngesterMarkets("coinbase"),
+		ArchiveFileIngesterOrderBookMarkets("coinbase"),
+	} {
+		require.FileExists(t, filepath.Join(dir, filename))
+	}
+
+	// replay from an empty cassette directory, so that any request fails.
+	transport, err = http.NewCassetteTransport(http.CassetteModeReplay, t.TempDir(), nil)
+	require.NoError(t, err)
+
+	run, err := ReadArchivedRun(dir)
+	require.NoError(t, err)
+	require.Equal(t, observedAt, run.ObservedAt)
+
+	replayedStore := provider.NewMemoryStoreObservedAt(run.ObservedAt)
+	replayed, err := NewIndexerFromArchive(cfg, zap.NewNop(), replayedStore, registry, nil, dir, http.WithTransport(transport))
+	require.NoError(t, err)
+	require.NoError(t, replayed.Index(context.Background()))
+
+	doc := store.CreateOutputDocument()
+	replayedDoc := replayedStore.CreateOutputDocument()
+	require.NotEmpty(t, replayedDoc.ProviderMarkets)
+	doc.Run, replayedDoc.Run = nil, nil
+	require.Equal(t, doc, replayedDoc)
+
+	// an archive without the markets of an ingester cannot be replayed.
+	require.NoError(t, os.Remove(filepath.Join(dir, ArchiveFileIngesterMarkets("coinbase"))))
+	replayed, err = NewIndexerFromArchive(cfg, zap.NewNop(), provider.NewMemoryStore(), registry, nil, dir)
+	require.NoError(t, err)
+	require.ErrorContains(t, replayed.Index(context.Background()), ArchiveFileIngesterMarkets("coinbase"))
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/client/dydx"
+	"github.com/skip-mev/connect-mmu/generator/types"
 )
 
 type Options struct {
@@ -162,13 +163,25 @@ func newMarketHasDifferentCMCID(logger *zap.Logger, ticker string, actualMarket,
 			return false, nil
 		}
 
-		if generatedMetadata.AggregateIDs[0].ID != actualMetadata.AggregateIDs[0].ID {
-			logger.Warn("not adding market because the generated market has a different CMC ID than the actual market",
-				zap.String("ticker", ticker),
-				zap.String("generated_cmc_id", generatedMetadata.AggregateIDs[0].ID),
-				zap.String("actual_cmc_id", actualMetadata.AggregateIDs[0].ID),
-			)
-			return true, nil
+		// compare CMC IDs, or CoinGecko IDs for markets that are only known to CoinGecko.
+		for _, venue := range []string{types.VenueCoinMarketcap, types.VenueCoinGecko} {
+			generatedID, generatedFound := types.AggregateID(generatedMetadata, venue)
+			actualID, actualFound := types.AggregateID(actualMetadata, venue)
+			if !generatedFound || !actualFound {
+				continue
+			}
+
+			if generatedID != actualID {
+				logger.Warn("not adding market because the generated market has a different aggregator ID than the actual market",
+					zap.String("ticker", ticker),
+					zap.String("venue", venue),
+					zap.String("generated_id", generatedID),
+					zap.String("actual_id", actualID),
+				)
+				return true, nil
+			}
+
+			break
 		}
 	}
 	return false, nil
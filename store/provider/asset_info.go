@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// The fields of an AssetInfo that are recorded in AssetInfoChanges.
+const (
+	AssetInfoFieldSymbol         = "symbol"
+	AssetInfoFieldRank           = "rank"
+	AssetInfoFieldMultiAddresses = "multi_addresses"
+	AssetInfoFieldCMCTags        = "cmc_tags"
+	AssetInfoFieldCoinGeckoID    = "coingecko_id"
+	AssetInfoFieldCoinGeckoRank  = "coingecko_rank"
+)
+
+// newAssetInfo creates the AssetInfo with id of an asset that is not in a store yet.
+func newAssetInfo(id int32, params CreateAssetInfoParams) AssetInfo {
+	return AssetInfo{
+		ID:             id,
+		Symbol:         params.Symbol,
+		IsCrypto:       true, // it doesn't look like we actually use this
+		CMCID:          params.CmcID,
+		CMCIDValid:     params.CmcID != 0,
+		Rank:           params.Rank,
+		RankValid:      params.Rank != 0,
+		MultiAddresses: params.MultiAddresses,
+		CMCTags:        params.CMCTags,
+		CoinGeckoID:    params.CoinGeckoID,
+		CoinGeckoRank:  params.CoinGeckoRank,
+		ObservedAt:     params.ObservedAt,
+	}
+}
+
+// mergeAssetInfo updates assetInfo with another observation of the same asset and returns the changes it made:
+//
+//   - contract addresses are the union of both observations, whichever is newer.
+//   - a newer observation renames the asset, and replaces its ranks and CMC tags unless it has no tags. An
+//     asset that is no longer ranked loses its rank, so that rank filters don't keep delisted assets.
+//   - a CoinGecko ID is taken from a newer observation, or from an older one if the asset has none.
+//
+// An older observation whose symbol, ranks or CMC tags differ is recorded as a change from its values to the
+// asset's, observed when the asset was, so that merging the runs of a document in any order records its renames.
+func mergeAssetInfo(assetInfo *AssetInfo, params CreateAssetInfoParams) []AssetInfoChange {
+	var changes []AssetInfoChange
+	record := func(field string, oldValue, newValue any, observedAt time.Time) {
+		changes = append(changes, AssetInfoChange{
+			AssetInfoID: assetInfo.ID,
+			CMCID:       assetInfo.CMCID,
+			Field:       field,
+			OldValue:    marshalAssetInfoValue(oldValue),
+			NewValue:    marshalAssetInfoValue(newValue),
+			ObservedAt:  observedAt,
+		})
+	}
+	set := func(field string, oldValue, newValue any) {
+		record(field, oldValue, newValue, params.ObservedAt)
+	}
+
+	multiAddresses := unionMultiAddresses(assetInfo.MultiAddresses, params.MultiAddresses)
+	if len(multiAddresses) != len(assetInfo.MultiAddresses) {
+		set(AssetInfoFieldMultiAddresses, assetInfo.MultiAddresses, multiAddresses)
+		assetInfo.MultiAddresses = multiAddresses
+	}
+
+	newer := !params.ObservedAt.Before(assetInfo.ObservedAt)
+	if params.CoinGeckoID != "" && params.CoinGeckoID != assetInfo.CoinGeckoID && (newer || assetInfo.CoinGeckoID == "") {
+		set(AssetInfoFieldCoinGeckoID, assetInfo.CoinGeckoID, params.CoinGeckoID)
+		assetInfo.CoinGeckoID = params.CoinGeckoID
+	}
+
+	if !newer {
+		if params.Symbol != "" && params.Symbol != assetInfo.Symbol {
+			record(AssetInfoFieldSymbol, params.Symbol, assetInfo.Symbol, assetInfo.ObservedAt)
+		}
+		if params.Rank != assetInfo.Rank {
+			record(AssetInfoFieldRank, params.Rank, assetInfo.Rank, assetInfo.ObservedAt)
+		}
+		if params.CoinGeckoRank != assetInfo.CoinGeckoRank {
+			record(AssetInfoFieldCoinGeckoRank, params.CoinGeckoRank, assetInfo.CoinGeckoRank, assetInfo.ObservedAt)
+		}
+		if params.CMCTags != nil && !slices.Equal(params.CMCTags, assetInfo.CMCTags) {
+			record(AssetInfoFieldCMCTags, params.CMCTags, assetInfo.CMCTags, assetInfo.ObservedAt)
+		}
+		return changes
+	}
+
+	if params.Symbol != "" && params.Symbol != assetInfo.Symbol {
+		set(AssetInfoFieldSymbol, assetInfo.Symbol, params.Symbol)
+		assetInfo.Symbol = params.Symbol
+	}
+	if params.Rank != assetInfo.Rank {
+		set(AssetInfoFieldRank, assetInfo.Rank, params.Rank)
+		assetInfo.Rank = params.Rank
+	}
+	if params.CoinGeckoRank != assetInfo.CoinGeckoRank {
+		set(AssetInfoFieldCoinGeckoRank, assetInfo.CoinGeckoRank, params.CoinGeckoRank)
+		assetInfo.CoinGeckoRank = params.CoinGeckoRank
+	}
+	if params.CMCTags != nil && !slices.Equal(params.CMCTags, assetInfo.CMCTags) {
+		set(AssetInfoFieldCMCTags, assetInfo.CMCTags, params.CMCTags)
+		assetInfo.CMCTags = params.CMCTags
+	}
+	assetInfo.RankValid = assetInfo.Rank != 0
+	assetInfo.CMCIDValid = assetInfo.CMCID != 0
+	assetInfo.ObservedAt = params.ObservedAt
+
+	return changes
+}
+
+// unionMultiAddresses returns the addresses of a followed by the addresses of b that are not in a.
+func unionMultiAddresses(a, b [][]string) [][]string {
+	union := slices.Clone(a)
+	for _, address := range b {
+		if !slices.ContainsFunc(union, func(other []string) bool { return slices.Equal(address, other) }) {
+			union = append(union, address)
+		}
+	}
+	return union
+}
+
+func marshalAssetInfoValue(value any) json.RawMessage {
+	// asset info fields are strings, integers and slices of strings, which always marshal.
+	bz, _ := json.Marshal(value)
+	return bz
+}
@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect-mmu/lib/file"
+)
+
+// SchemaVersion is the schema version of the documents written by the stores.
+const SchemaVersion = 2
+
+// documentMigrations migrate a Document from the schema version of their index to the next version. There is a
+// migration for every version before SchemaVersion.
+var documentMigrations = []func(*Document) error{
+	// 0 -> 1: documents without a schema version predate run metadata and may predate observation times,
+	// which are left empty, and their records are not ordered.
+	func(document *Document) error {
+		sortDocument(document)
+		return nil
+	},
+	// 1 -> 2: the CoinGecko ranks of assets that are only listed on CoinGecko were stored as their rank.
+	func(document *Document) error {
+		for i, assetInfo := range document.AssetInfos {
+			if assetInfo.CMCID == 0 && assetInfo.CoinGeckoID != "" {
+				document.AssetInfos[i].CoinGeckoRank = assetInfo.Rank
+				document.AssetInfos[i].Rank = 0
+				document.AssetInfos[i].RankValid = false
+			}
+		}
+		return nil
+	},
+}
+
+// DocumentHeader is the part of a Document other than its records.
+type DocumentHeader struct {
+	SchemaVersion int          `json:"schema_version"`
+	Run           *RunMetadata `json:"run,omitempty"`
+	IndexReport   *IndexReport `json:"index_report,omitempty"`
+}
+
+// Header returns the header of the document.
+func (d Document) Header() DocumentHeader {
+	return DocumentHeader{
+		SchemaVersion: d.SchemaVersion,
+		Run:           d.Run,
+		IndexReport:   d.IndexReport,
+	}
+}
+
+// MissingObservedAt returns the number of records of the document without an observation time, e.g. those of
+// documents written before observation times were recorded.
+func (d Document) MissingObservedAt() int {
+	count := 0
+	for _, assetInfo := range d.AssetInfos {
+		if assetInfo.ObservedAt.IsZero() {
+			count++
+		}
+	}
+	for _, providerMarket := range d.ProviderMarkets {
+		if providerMarket.ObservedAt.IsZero() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SetMissingObservedAt sets the observation time of the records of the document that have none.
+func (d *Document) SetMissingObservedAt(observedAt time.Time) {
+	for i := range d.AssetInfos {
+		if d.AssetInfos[i].ObservedAt.IsZero() {
+			d.AssetInfos[i].ObservedAt = observedAt.UTC()
+		}
+	}
+	for i := range d.ProviderMarkets {
+		if d.ProviderMarkets[i].ObservedAt.IsZero() {
+			d.ProviderMarkets[i].ObservedAt = observedAt.UTC()
+		}
+	}
+}
+
+// DocumentHandler handles the parts of a Document as it is read by StreamDocument. The header is handled first,
+// then the records in the order of the document. Nil functions are skipped.
+type DocumentHandler struct {
+	Header         func(DocumentHeader) error
+	AssetInfo      func(AssetInfo) error
+	ProviderMarket func(ProviderMarket) error
+}
+
+// ndjsonLine is a line of an NDJSON document. Exactly one of its fields is set, and the first line of a document
+// is its header.
+type ndjsonLine struct {
+	Header         *DocumentHeader `json:"header,omitempty"`
+	AssetInfo      *AssetInfo      `json:"asset_info,omitempty"`
+	ProviderMarket *ProviderMarket `json:"provider_market,omitempty"`
+}
+
+// isNDJSONPath returns true if documents at path are NDJSON, i.e. path ends in .ndjson or .jsonl, optionally
+// followed by .gz. Documents at other paths are JSON.
+func isNDJSONPath(path string) bool {
+	ext := filepath.Ext(strings.TrimSuffix(path, ".gz"))
+	return ext == ".ndjson" || ext == ".jsonl"
+}
+
+// isGzipPath returns true if documents at path are gzip compressed, i.e. path ends in .gz.
+func isGzipPath(path string) bool {
+	return filepath.Ext(path) == ".gz"
+}
+
+// ReadDocument reads the Document at path, migrating it to the current SchemaVersion. The format of the document
+// is selected by the extension of path, see StreamDocument.
+func ReadDocument(path string) (Document, error) {
+	var document Document
+	err := StreamDocument(path, DocumentHandler{
+		Header: func(header DocumentHeader) error {
+			document.SchemaVersion = header.SchemaVersion
+			document.Run = header.Run
+			document.IndexReport = header.IndexReport
+			return nil
+		},
+		AssetInfo: func(assetInfo AssetInfo) error {
+			document.AssetInfos = append(document.AssetInfos, assetInfo)
+			return nil
+		},
+		ProviderMarket: func(providerMarket ProviderMarket) error {
+			document.ProviderMarkets = append(document.ProviderMarkets, providerMarket)
+			return nil
+		},
+	})
+	if err != nil {
+		return Document{}, err
+	}
+
+	return document, nil
+}
+
+// StreamDocument reads the Document at path, passing its parts to handler. Paths ending in .ndjson or .jsonl are
+// read as NDJSON one record at a time, so that the document is never held in memory; other paths are read as
+// JSON. Paths ending in .gz, e.g. provider-data.ndjson.gz, are decompressed. Documents of older schema versions are
+// read into memory and migrated before they are handled.
+func StreamDocument(path string, handler DocumentHandler) (err error) {
+	f, err := file.OpenFile(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = bufio.NewReader(f)
+	if isGzipPath(path) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if isNDJSONPath(path) {
+		err = streamNDJSONDocument(r, handler)
+	} else {
+		var document Document
+		if err = json.NewDecoder(r).Decode(&document); err == nil {
+			err = handleDocument(document, handler)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func streamNDJSONDocument(r io.Reader, handler DocumentHandler) error {
+	decoder := json.NewDecoder(r)
+
+	var first ndjsonLine
+	if err := decoder.Decode(&first); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if first.Header == nil || first.AssetInfo != nil || first.ProviderMarket != nil {
+		return errors.New("the first line must be the header")
+	}
+
+	// documents of older schema versions are migrated as a whole.
+	if first.Header.SchemaVersion != SchemaVersion {
+		document := Document{
+			SchemaVersion: first.Header.SchemaVersion,
+			Run:           first.Header.Run,
+			IndexReport:   first.Header.IndexReport,
+		}
+		err := decodeNDJSONRecords(decoder, DocumentHandler{
+			AssetInfo: func(assetInfo AssetInfo) error {
+				document.AssetInfos = append(document.AssetInfos, assetInfo)
+				return nil
+			},
+			ProviderMarket: func(providerMarket ProviderMarket) error {
+				document.ProviderMarkets = append(document.ProviderMarkets, providerMarket)
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return handleDocument(document, handler)
+	}
+
+	if handler.Header != nil {
+		if err := handler.Header(*first.Header); err != nil {
+			return err
+		}
+	}
+
+	return decodeNDJSONRecords(decoder, handler)
+}
+
+// decodeNDJSONRecords decodes the record lines that follow the header of an NDJSON document.
+func decodeNDJSONRecords(decoder *json.Decoder, handler DocumentHandler) error {
+	for lineNumber := 2; ; lineNumber++ {
+		var line ndjsonLine
+		if err := decoder.Decode(&line); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid line %d: %w", lineNumber, err)
+		}
+
+		switch {
+		case line.Header == nil && line.AssetInfo != nil && line.ProviderMarket == nil:
+			if handler.AssetInfo != nil {
+				if err := handler.AssetInfo(*line.AssetInfo); err != nil {
+					return err
+				}
+			}
+		case line.Header == nil && line.AssetInfo == nil && line.ProviderMarket != nil:
+			if handler.ProviderMarket != nil {
+				if err := handler.ProviderMarket(*line.ProviderMarket); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("invalid line %d: must be either an asset info or a provider market", lineNumber)
+		}
+	}
+}
+
+// handleDocument migrates a document that was read as a whole and passes its parts to handler.
+func handleDocument(document Document, handler DocumentHandler) error {
+	if err := MigrateDocument(&document); err != nil {
+		return err
+	}
+
+	if handler.Header != nil {
+		if err := handler.Header(document.Header()); err != nil {
+			return err
+		}
+	}
+	if handler.AssetInfo != nil {
+		for _, assetInfo := range document.AssetInfos {
+			if err := handler.AssetInfo(assetInfo); err != nil {
+				return err
+			}
+		}
+	}
+	if handler.ProviderMarket != nil {
+		for _, providerMarket := range document.ProviderMarkets {
+			if err := handler.ProviderMarket(providerMarket); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteDocument writes the document to path, in the format selected by the extension of path, see StreamDocument.
+// NDJSON documents are encoded one record at a time.
+func WriteDocument(path string, document Document) (err error) {
+	f, err := file.CreateFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	var w io.Writer = f
+	if isGzipPath(path) {
+		gz := gzip.NewWriter(f)
+		defer func() { err = errors.Join(err, gz.Close()) }()
+		w = gz
+	}
+
+	buffered := bufio.NewWriter(w)
+	defer func() { err = errors.Join(err, buffered.Flush()) }()
+
+	encoder := json.NewEncoder(buffered)
+	if !isNDJSONPath(path) {
+		return encoder.Encode(document)
+	}
+
+	header := document.Header()
+	if err := encoder.Encode(ndjsonLine{Header: &header}); err != nil {
+		return err
+	}
+	for _, assetInfo := range document.AssetInfos {
+		if err := encoder.Encode(ndjsonLine{AssetInfo: &assetInfo}); err != nil {
+			return err
+		}
+	}
+	for _, providerMarket := range document.ProviderMarkets {
+		if err := encoder.Encode(ndjsonLine{ProviderMarket: &providerMarket}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateDocument migrates a document of an older schema version to the current SchemaVersion. Documents of a
+// newer schema version are rejected, since they may hold data this version does not know how to read.
+func MigrateDocument(document *Document) error {
+	if document.SchemaVersion < 0 || document.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("unsupported schema version %d: the latest supported version is %d", document.SchemaVersion, SchemaVersion)
+	}
+
+	for version := document.SchemaVersion; version < SchemaVersion; version++ {
+		if err := documentMigrations[version](document); err != nil {
+			return fmt.Errorf("failed to migrate from schema version %d: %w", version, err)
+		}
+		document.SchemaVersion = version + 1
+	}
+
+	return nil
+}
+
+// sortDocument orders the records of the document by ID, so that the same records are always written in the
+// same order.
+func sortDocument(document *Document) {
+	sort.Slice(document.AssetInfos, func(i, j int) bool {
+		return document.AssetInfos[i].ID < document.AssetInfos[j].ID
+	})
+	sort.Slice(document.ProviderMarkets, func(i, j int) bool {
+		return document.ProviderMarkets[i].ID < document.ProviderMarkets[j].ID
+	})
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentMigrations(t *testing.T) {
+	require.Len(t, documentMigrations, SchemaVersion)
+}
+
+func TestReadDocumentMigratesLegacyDocuments(t *testing.T) {
+	// a document written before schema versions, with its records in map order.
+	legacy := `{
+		"asset_infos": [
+			{"id": 1, "symbol": "USD", "is_crypto": true, "rank": 3, "cmc_id": 2781},
+			{"id": 0, "symbol": "BTC", "is_crypto": true, "rank": 1, "cmc_id": 1},
+			{"id": 2, "symbol": "PEPE", "is_crypto": true, "rank": 30, "coingecko_id": "pepe"}
+		],
+		"provider_markets": [
+			{"id": 1, "target_base": "BTC", "target_quote": "USD", "off_chain_ticker": "BTCUSD", "provider_name": "kraken_api",
+				"base_asset_info_id": 0, "quote_asset_info_id": 1},
+			{"id": 0, "target_base": "BTC", "target_quote": "USD", "off_chain_ticker": "BTC-USD", "provider_name": "coinbase_ws",
+				"base_asset_info_id": 0, "quote_asset_info_id": 1}
+		]
+	}`
+	path := filepath.Join(t.TempDir(), "legacy.json")
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0o600))
+
+	document, err := ReadDocument(path)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, document.SchemaVersion)
+	require.Nil(t, document.Run)
+	require.Equal(t, int32(0), document.AssetInfos[0].ID)
+	require.Equal(t, int32(1), document.AssetInfos[1].ID)
+	require.Equal(t, int64(3), document.AssetInfos[1].Rank)
+	// the CoinGecko rank of an asset that is only listed on CoinGecko is not a CMC rank.
+	require.Zero(t, document.AssetInfos[2].Rank)
+	require.Equal(t, int64(30), document.AssetInfos[2].CoinGeckoRank)
+	require.Equal(t, "BTC-USD", document.ProviderMarkets[0].OffChainTicker)
+	require.True(t, document.ProviderMarkets[0].ObservedAt.IsZero())
+
+	store, err := NewMemoryStoreFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, document, store.CreateOutputDocument())
+}
+
+func TestReadDocumentRejectsNewerSchemaVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "future.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema_version": 1000, "asset_infos": [], "provider_markets": []}`), 0o600))
+
+	_, err := ReadDocument(path)
+	require.ErrorContains(t, err, "unsupported schema version 1000")
+
+	_, err = NewMemoryStoreFromFile(path)
+	require.Error(t, err)
+}
+
+func TestMemoryStoreOutputIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	observedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := NewMemoryStoreObservedAt(observedAt)
+	addTestRun(t, store, observedAt, 1000)
+	run := RunMetadata{
+		StartedAt:  observedAt,
+		FinishedAt: observedAt.Add(time.Minute),
+		ConfigHash: "abc",
+		Ingesters:  []string{"coinbase", "okx"},
+	}
+	require.NoError(t, store.SetRunMetadata(ctx, run))
+
+	document := store.CreateOutputDocument()
+	require.Equal(t, SchemaVersion, document.SchemaVersion)
+	require.Equal(t, &run, document.Run)
+	for i, assetInfo := range document.AssetInfos {
+		require.Equal(t, int32(i), assetInfo.ID)
+	}
+	for i, providerMarket := range document.ProviderMarkets {
+		require.Equal(t, int32(i), providerMarket.ID)
+	}
+
+	// writing the same store, or a store read from its file, gives the same bytes.
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	require.NoError(t, store.WriteToPath(ctx, first))
+
+	fromFile, err := NewMemoryStoreFromFile(first)
+	require.NoError(t, err)
+	second := filepath.Join(dir, "second.json")
+	require.NoError(t, fromFile.WriteToPath(ctx, second))
+
+	firstBz, err := os.ReadFile(first)
+	require.NoError(t, err)
+	secondBz, err := os.ReadFile(second)
+	require.NoError(t, err)
+	require.Equal(t, string(firstBz), string(secondBz))
+}
+
+func TestWriteDocumentFormats(t *testing.T) {
+	observedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStoreObservedAt(observedAt)
+	addTestRun(t, store, observedAt, 1000)
+	require.NoError(t, store.SetRunMetadata(context.Background(), RunMetadata{StartedAt: observedAt, ConfigHash: "abc"}))
+	document := store.CreateOutputDocument()
+
+	for _, name := range []string{"data.json", "data.ndjson", "data.jsonl", "data.json.gz", "data.ndjson.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, WriteDocument(path, document))
+
+			read, err := ReadDocument(path)
+			require.NoError(t, err)
+			require.Equal(t, document, read)
+
+			fromFile, err := NewMemoryStoreFromFile(path)
+			require.NoError(t, err)
+			require.Equal(t, document, fromFile.CreateOutputDocument())
+		})
+	}
+}
+
+func TestReadNDJSONDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			lines: `{"header": {"schema_version": 2}}
+{"asset_info": {"id": 0, "symbol": "BTC", "is_crypto": true, "rank": 1, "cmc_id": 1}}
+{"asset_info": {"id": 1, "symbol": "USD", "is_crypto": true, "rank": 3, "cmc_id": 2781}}
+{"provider_market": {"id": 0, "target_base": "BTC", "target_quote": "USD", "off_chain_ticker": "BTC-USD", "provider_name": "coinbase_ws", "base_asset_info_id": 0, "quote_asset_info_id": 1}}
+`,
+		},
+		{
+			name: "legacy document is migrated",
+			lines: `{"header": {}}
+{"asset_info": {"id": 1, "symbol": "USD", "is_crypto": true, "rank": 3, "cmc_id": 2781}}
+{"asset_info": {"id": 0, "symbol": "BTC", "is_crypto": true, "rank": 1, "cmc_id": 1}}
+{"provider_market": {"id": 0, "target_base": "BTC", "target_quote": "USD", "off_chain_ticker": "BTC-USD", "provider_name": "coinbase_ws", "base_asset_info_id": 0, "quote_asset_info_id": 1}}
+`,
+		},
+		{
+			name:    "empty document",
+			lines:   "",
+			wantErr: "invalid header",
+		},
+		{
+			name: "record before header",
+			lines: `{"asset_info": {"id": 0, "symbol": "BTC"}}
+{"header": {"schema_version": 1}}
+`,
+			wantErr: "the first line must be the header",
+		},
+		{
+			name: "second header",
+			lines: `{"header": {"schema_version": 1}}
+{"header": {"schema_version": 1}}
+`,
+			wantErr: "invalid line 2",
+		},
+		{
+			name: "newer schema version",
+			lines: `{"header": {"schema_version": 1000}}
+`,
+			wantErr: "unsupported schema version 1000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.ndjson")
+			require.NoError(t, os.WriteFile(path, []byte(tc.lines), 0o600))
+
+			document, err := ReadDocument(path)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, SchemaVersion, document.SchemaVersion)
+			require.Len(t, document.AssetInfos, 2)
+			require.Equal(t, int32(0), document.AssetInfos[0].ID)
+			require.Equal(t, int32(1), document.AssetInfos[1].ID)
+			require.Len(t, document.ProviderMarkets, 1)
+		})
+	}
+}
@@ -2,11 +2,11 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
-
-	"github.com/skip-mev/connect-mmu/lib/file"
+	"time"
 )
 
 type MemoryStore struct {
@@ -20,9 +20,26 @@ type MemoryStore struct {
 
 	providerMarketOffChainTickerProviderNameUniqueIndex map[string]map[string]int32
 	assetInfoCMCIDUniqueIndex                           map[int64]int32
+	assetInfoCoinGeckoIDUniqueIndex                     map[string]int32
+
+	indexReport IndexReport
+	// assetInfoChanges are the changes that were made to existing asset infos.
+	assetInfoChanges []AssetInfoChange
+	// runMetadata is nil if it was not set.
+	runMetadata *RunMetadata
+
+	// observedAt is the observation time of records added without one. Records added to the same store
+	// belong to the same index run, so they share an observation time.
+	observedAt time.Time
 }
 
 func NewMemoryStore() *MemoryStore {
+	return NewMemoryStoreObservedAt(time.Now())
+}
+
+// NewMemoryStoreObservedAt creates a MemoryStore that stamps records added without an observation time
+// with observedAt.
+func NewMemoryStoreObservedAt(observedAt time.Time) *MemoryStore {
 	return &MemoryStore{
 		providerMarketNextID: 0,
 		assetInfoNextID:      0,
@@ -32,61 +49,73 @@ func NewMemoryStore() *MemoryStore {
 
 		providerMarketOffChainTickerProviderNameUniqueIndex: make(map[string]map[string]int32),
 		assetInfoCMCIDUniqueIndex:                           make(map[int64]int32),
+		assetInfoCoinGeckoIDUniqueIndex:                     make(map[string]int32),
+
+		observedAt: observedAt.UTC(),
 	}
 }
 
+// NewMemoryStoreFromFile creates a MemoryStore from the Document at path, migrating documents of older schema
+// versions. NDJSON documents are streamed into the store one record at a time.
 func NewMemoryStoreFromFile(path string) (*MemoryStore, error) {
-	jsonBz, err := file.ReadBytesFromFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var document Document
-	if err := json.Unmarshal(jsonBz, &document); err != nil {
-		return nil, err
-	}
-
 	store := NewMemoryStore()
 
-	maxAssetID := int32(-1)
-	for _, assetInfo := range document.AssetInfos {
-		if assetInfo.ID > maxAssetID {
-			maxAssetID = assetInfo.ID
-		}
-		store.assetInfos[assetInfo.ID] = &AssetInfo{
-			ID:             assetInfo.ID,
-			Symbol:         assetInfo.Symbol,
-			IsCrypto:       assetInfo.IsCrypto,
-			CMCID:          assetInfo.CMCID,
-			Rank:           assetInfo.Rank,
-			MultiAddresses: assetInfo.MultiAddresses,
-			CMCTags:        assetInfo.CMCTags,
-		}
-	}
-	store.assetInfoNextID = maxAssetID + 1
-
-	maxProviderMarketID := int32(-1)
-	for _, providerMarket := range document.ProviderMarkets {
-		if providerMarket.ID > maxProviderMarketID {
-			maxProviderMarketID = providerMarket.ID
-		}
-		store.providerMarkets[providerMarket.ID] = &ProviderMarket{
-			ID:               providerMarket.ID,
-			TargetBase:       providerMarket.TargetBase,
-			TargetQuote:      providerMarket.TargetQuote,
-			OffChainTicker:   providerMarket.OffChainTicker,
-			ProviderName:     providerMarket.ProviderName,
-			QuoteVolume:      providerMarket.QuoteVolume,
-			UsdVolume:        providerMarket.UsdVolume,
-			BaseAssetInfoID:  providerMarket.BaseAssetInfoID,
-			QuoteAssetInfoID: providerMarket.QuoteAssetInfoID,
-			MetadataJSON:     providerMarket.MetadataJSON,
-			ReferencePrice:   providerMarket.ReferencePrice,
-			NegativeDepthTwo: providerMarket.NegativeDepthTwo,
-			PositiveDepthTwo: providerMarket.PositiveDepthTwo,
-		}
+	err := StreamDocument(path, DocumentHandler{
+		Header: func(header DocumentHeader) error {
+			if header.IndexReport != nil {
+				store.indexReport = *header.IndexReport
+			}
+			store.runMetadata = header.Run
+			return nil
+		},
+		AssetInfo: func(assetInfo AssetInfo) error {
+			if assetInfo.ID >= store.assetInfoNextID {
+				store.assetInfoNextID = assetInfo.ID + 1
+			}
+			store.assetInfos[assetInfo.ID] = &AssetInfo{
+				ID:             assetInfo.ID,
+				Symbol:         assetInfo.Symbol,
+				IsCrypto:       assetInfo.IsCrypto,
+				CMCID:          assetInfo.CMCID,
+				CMCIDValid:     assetInfo.CMCIDValid,
+				Rank:           assetInfo.Rank,
+				RankValid:      assetInfo.RankValid,
+				MultiAddresses: assetInfo.MultiAddresses,
+				CMCTags:        assetInfo.CMCTags,
+				CoinGeckoID:    assetInfo.CoinGeckoID,
+				CoinGeckoRank:  assetInfo.CoinGeckoRank,
+				ObservedAt:     assetInfo.ObservedAt,
+			}
+			store.indexAssetInfo(store.assetInfos[assetInfo.ID])
+			return nil
+		},
+		ProviderMarket: func(providerMarket ProviderMarket) error {
+			if providerMarket.ID >= store.providerMarketNextID {
+				store.providerMarketNextID = providerMarket.ID + 1
+			}
+			store.providerMarkets[providerMarket.ID] = &ProviderMarket{
+				ID:               providerMarket.ID,
+				TargetBase:       providerMarket.TargetBase,
+				TargetQuote:      providerMarket.TargetQuote,
+				OffChainTicker:   providerMarket.OffChainTicker,
+				ProviderName:     providerMarket.ProviderName,
+				QuoteVolume:      providerMarket.QuoteVolume,
+				UsdVolume:        providerMarket.UsdVolume,
+				BaseAssetInfoID:  providerMarket.BaseAssetInfoID,
+				QuoteAssetInfoID: providerMarket.QuoteAssetInfoID,
+				MetadataJSON:     providerMarket.MetadataJSON,
+				ReferencePrice:   providerMarket.ReferencePrice,
+				NegativeDepthTwo: providerMarket.NegativeDepthTwo,
+				PositiveDepthTwo: providerMarket.PositiveDepthTwo,
+				ObservedAt:       providerMarket.ObservedAt,
+			}
+			store.indexProviderMarket(store.providerMarkets[providerMarket.ID])
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
-	store.providerMarketNextID = maxProviderMarketID + 1
 
 	return store, nil
 }
@@ -95,6 +124,14 @@ func (w *MemoryStore) AddProviderMarket(_ context.Context, params CreateProvider
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if params.ObservedAt.IsZero() {
+		params.ObservedAt = w.observedAt
+	}
+
+	return w.addProviderMarket(params)
+}
+
+func (w *MemoryStore) addProviderMarket(params CreateProviderMarketParams) (ProviderMarket, error) {
 	if _, ok := w.providerMarketOffChainTickerProviderNameUniqueIndex[params.OffChainTicker]; ok {
 		if id, ok := w.providerMarketOffChainTickerProviderNameUniqueIndex[params.OffChainTicker][params.ProviderName]; ok {
 			return w.updateProviderMarket(params, id)
@@ -115,26 +152,36 @@ func (w *MemoryStore) AddProviderMarket(_ context.Context, params CreateProvider
 		ReferencePrice:   params.ReferencePrice,
 		NegativeDepthTwo: params.NegativeDepthTwo,
 		PositiveDepthTwo: params.PositiveDepthTwo,
+		ObservedAt:       params.ObservedAt,
 	}
 
 	w.providerMarketNextID++
 	w.providerMarkets[providerMarket.ID] = &providerMarket
-	if _, ok := w.providerMarketOffChainTickerProviderNameUniqueIndex[params.OffChainTicker]; !ok {
-		w.providerMarketOffChainTickerProviderNameUniqueIndex[params.OffChainTicker] = make(map[string]int32)
-	}
-	w.providerMarketOffChainTickerProviderNameUniqueIndex[params.OffChainTicker][params.ProviderName] = providerMarket.ID
+	w.indexProviderMarket(&providerMarket)
 
 	return providerMarket, nil
 }
 
+// indexProviderMarket adds a provider market to the unique index of markets by off-chain ticker and provider.
+func (w *MemoryStore) indexProviderMarket(providerMarket *ProviderMarket) {
+	if _, ok := w.providerMarketOffChainTickerProviderNameUniqueIndex[providerMarket.OffChainTicker]; !ok {
+		w.providerMarketOffChainTickerProviderNameUniqueIndex[providerMarket.OffChainTicker] = make(map[string]int32)
+	}
+	w.providerMarketOffChainTickerProviderNameUniqueIndex[providerMarket.OffChainTicker][providerMarket.ProviderName] = providerMarket.ID
+}
+
 func (w *MemoryStore) updateProviderMarket(params CreateProviderMarketParams, id int32) (ProviderMarket, error) {
 	providerMarket, ok := w.providerMarkets[id]
 	if !ok {
 		return ProviderMarket{}, errors.New("attempted to update provider market at invalid id")
 	}
-	// Don't overwrite if the quote volume is lower.
+	// Don't overwrite with an older observation, e.g. when merging a previous index run.
+	if params.ObservedAt.Before(providerMarket.ObservedAt) {
+		return *providerMarket, nil
+	}
+	// Don't overwrite if the quote volume of the same observation is lower.
 	// e.g. we can have multiple provider markets for the same uniswap ticker because there can be multiple fee pools
-	if providerMarket.QuoteVolume > params.QuoteVolume {
+	if params.ObservedAt.Equal(providerMarket.ObservedAt) && providerMarket.QuoteVolume > params.QuoteVolume {
 		return *providerMarket, nil
 	}
 
@@ -146,6 +193,7 @@ func (w *MemoryStore) updateProviderMarket(params CreateProviderMarketParams, id
 	providerMarket.NegativeDepthTwo = params.NegativeDepthTwo
 	providerMarket.PositiveDepthTwo = params.PositiveDepthTwo
 	providerMarket.MetadataJSON = string(params.MetadataJSON)
+	providerMarket.ObservedAt = params.ObservedAt
 
 	return *providerMarket, nil
 }
@@ -154,43 +202,67 @@ func (w *MemoryStore) AddAssetInfo(_ context.Context, params CreateAssetInfoPara
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if id, ok := w.assetInfoCMCIDUniqueIndex[params.CmcID]; ok {
-		return w.updateAssetInfo(params, id)
+	if params.ObservedAt.IsZero() {
+		params.ObservedAt = w.observedAt
 	}
 
-	assetInfo := AssetInfo{
-		ID: w.assetInfoNextID,
+	return w.addAssetInfo(params)
+}
 
-		Symbol:         params.Symbol,
-		IsCrypto:       true, // it doesn't look like we actually use this
-		CMCID:          params.CmcID,
-		Rank:           params.Rank,
-		MultiAddresses: params.MultiAddresses,
-		CMCTags:        params.CMCTags,
+func (w *MemoryStore) addAssetInfo(params CreateAssetInfoParams) (AssetInfo, error) {
+	// assets are unique by CMC ID, or by CoinGecko ID for assets that are not listed on CMC.
+	if params.CmcID == 0 && params.CoinGeckoID != "" {
+		if id, ok := w.assetInfoCoinGeckoIDUniqueIndex[params.CoinGeckoID]; ok {
+			return w.updateAssetInfo(params, id)
+		}
+	} else if id, ok := w.assetInfoCMCIDUniqueIndex[params.CmcID]; ok {
+		return w.updateAssetInfo(params, id)
 	}
 
+	assetInfo := newAssetInfo(w.assetInfoNextID, params)
+
 	w.assetInfoNextID++
 	w.assetInfos[assetInfo.ID] = &assetInfo
-	w.assetInfoCMCIDUniqueIndex[params.CmcID] = assetInfo.ID
+	w.indexAssetInfo(&assetInfo)
 
 	return assetInfo, nil
 }
 
+// indexAssetInfo adds an asset info to the unique index of its CMC ID, or of its CoinGecko ID if it has no CMC ID.
+func (w *MemoryStore) indexAssetInfo(assetInfo *AssetInfo) {
+	if assetInfo.CMCID == 0 && assetInfo.CoinGeckoID != "" {
+		w.assetInfoCoinGeckoIDUniqueIndex[assetInfo.CoinGeckoID] = assetInfo.ID
+	} else {
+		w.assetInfoCMCIDUniqueIndex[assetInfo.CMCID] = assetInfo.ID
+	}
+}
+
 func (w *MemoryStore) updateAssetInfo(params CreateAssetInfoParams, id int32) (AssetInfo, error) {
 	assetInfo, ok := w.assetInfos[id]
 	if !ok {
 		return AssetInfo{}, errors.New("attempted to update asset info at invalid id")
 	}
 
-	assetInfo.Rank = params.Rank
-	assetInfo.MultiAddresses = params.MultiAddresses
+	w.assetInfoChanges = append(w.assetInfoChanges, mergeAssetInfo(assetInfo, params)...)
 
 	return *assetInfo, nil
 }
 
+// GetAssetInfoChanges returns the changes that adding asset infos to the store made to existing asset infos.
+func (w *MemoryStore) GetAssetInfoChanges(_ context.Context) []AssetInfoChange {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.assetInfoChanges)
+}
+
 func (w *MemoryStore) GetCMCIDToAssetInfo(_ context.Context) map[int64]AssetInfo {
 	result := make(map[int64]AssetInfo)
 	for _, assetInfo := range w.assetInfos {
+		// assets that are only listed on CoinGecko have no CMC ID.
+		if assetInfo.CMCID == 0 && assetInfo.CoinGeckoID != "" {
+			continue
+		}
 		result[assetInfo.CMCID] = *assetInfo
 	}
 	return result
@@ -202,12 +274,23 @@ func (w *MemoryStore) GetProviderMarkets(_ context.Context, params GetFilteredPr
 		targetProviderNames[providerName] = struct{}{}
 	}
 
+	var observedAfter time.Time
+	if params.MaxMarketAge > 0 {
+		observedAfter = w.latestObservation().Add(-params.MaxMarketAge)
+	}
+
 	rows := make([]GetFilteredProviderMarketsRow, 0)
 	for _, providerMarket := range w.providerMarkets {
 		if _, ok := targetProviderNames[providerMarket.ProviderName]; !ok {
 			continue
 		}
 
+		// markets of documents written before observation times were recorded have an unknown age, so they
+		// are kept.
+		if params.MaxMarketAge > 0 && !providerMarket.ObservedAt.IsZero() && providerMarket.ObservedAt.Before(observedAfter) {
+			continue
+		}
+
 		baseAssetInfo, ok := w.assetInfos[providerMarket.BaseAssetInfoID]
 		if !ok {
 			continue
@@ -218,29 +301,136 @@ func (w *MemoryStore) GetProviderMarkets(_ context.Context, params GetFilteredPr
 			continue
 		}
 
+		if !params.matches(*providerMarket, *baseAssetInfo) {
+			continue
+		}
+
 		row := GetFilteredProviderMarketsRow{
+			TargetBase:         providerMarket.TargetBase,
+			TargetQuote:        providerMarket.TargetQuote,
+			OffChainTicker:     providerMarket.OffChainTicker,
+			ProviderName:       providerMarket.ProviderName,
+			QuoteVolume:        providerMarket.QuoteVolume,
+			UsdVolume:          providerMarket.UsdVolume,
+			MetadataJSON:       []byte(providerMarket.MetadataJSON),
+			ReferencePrice:     providerMarket.ReferencePrice,
+			NegativeDepthTwo:   providerMarket.NegativeDepthTwo,
+			PositiveDepthTwo:   providerMarket.PositiveDepthTwo,
+			BaseCmcID:          baseAssetInfo.CMCID,
+			QuoteCmcID:         quoteAssetInfo.CMCID,
+			BaseRank:           baseAssetInfo.Rank,
+			QuoteRank:          quoteAssetInfo.Rank,
+			BaseCoinGeckoID:    baseAssetInfo.CoinGeckoID,
+			QuoteCoinGeckoID:   quoteAssetInfo.CoinGeckoID,
+			BaseCoinGeckoRank:  baseAssetInfo.CoinGeckoRank,
+			QuoteCoinGeckoRank: quoteAssetInfo.CoinGeckoRank,
+			ObservedAt:         providerMarket.ObservedAt,
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// latestObservation returns the most recent observation time of the provider markets in the store.
+func (w *MemoryStore) latestObservation() time.Time {
+	var latest time.Time
+	for _, providerMarket := range w.providerMarkets {
+		if providerMarket.ObservedAt.After(latest) {
+			latest = providerMarket.ObservedAt
+		}
+	}
+	return latest
+}
+
+// Merge adds the asset infos and provider markets of a document from another index run. Asset infos are
+// matched by CMC ID or CoinGecko ID and provider markets by off-chain ticker and provider name, and records
+// that are in both keep their most recently observed values. Records without an observation time are
+// older than any other. The index report and run metadata of the store are kept.
+func (w *MemoryStore) Merge(_ context.Context, document Document) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// the IDs of the document's asset infos in the store.
+	assetInfoIDs := make(map[int32]int32, len(document.AssetInfos))
+	for _, assetInfo := range document.AssetInfos {
+		merged, err := w.addAssetInfo(CreateAssetInfoParams{
+			Symbol:         assetInfo.Symbol,
+			CmcID:          assetInfo.CMCID,
+			Rank:           assetInfo.Rank,
+			MultiAddresses: assetInfo.MultiAddresses,
+			CMCTags:        assetInfo.CMCTags,
+			CoinGeckoID:    assetInfo.CoinGeckoID,
+			CoinGeckoRank:  assetInfo.CoinGeckoRank,
+			ObservedAt:     assetInfo.ObservedAt,
+		})
+		if err != nil {
+			return err
+		}
+		assetInfoIDs[assetInfo.ID] = merged.ID
+	}
+
+	for _, providerMarket := range document.ProviderMarkets {
+		baseAssetInfoID, ok := assetInfoIDs[providerMarket.BaseAssetInfoID]
+		if !ok {
+			return fmt.Errorf("provider market %s of %s has unknown base asset info %d",
+				providerMarket.OffChainTicker, providerMarket.ProviderName, providerMarket.BaseAssetInfoID)
+		}
+		quoteAssetInfoID, ok := assetInfoIDs[providerMarket.QuoteAssetInfoID]
+		if !ok {
+			return fmt.Errorf("provider market %s of %s has unknown quote asset info %d",
+				providerMarket.OffChainTicker, providerMarket.ProviderName, providerMarket.QuoteAssetInfoID)
+		}
+
+		_, err := w.addProviderMarket(CreateProviderMarketParams{
 			TargetBase:       providerMarket.TargetBase,
 			TargetQuote:      providerMarket.TargetQuote,
 			OffChainTicker:   providerMarket.OffChainTicker,
 			ProviderName:     providerMarket.ProviderName,
 			QuoteVolume:      providerMarket.QuoteVolume,
 			UsdVolume:        providerMarket.UsdVolume,
+			BaseAssetInfoID:  baseAssetInfoID,
+			QuoteAssetInfoID: quoteAssetInfoID,
 			MetadataJSON:     []byte(providerMarket.MetadataJSON),
 			ReferencePrice:   providerMarket.ReferencePrice,
 			NegativeDepthTwo: providerMarket.NegativeDepthTwo,
 			PositiveDepthTwo: providerMarket.PositiveDepthTwo,
-			BaseCmcID:        baseAssetInfo.CMCID,
-			QuoteCmcID:       quoteAssetInfo.CMCID,
-			BaseRank:         baseAssetInfo.Rank,
-			QuoteRank:        quoteAssetInfo.Rank,
+			ObservedAt:       providerMarket.ObservedAt,
+		})
+		if err != nil {
+			return err
 		}
-
-		rows = append(rows, row)
 	}
 
-	return rows, nil
+	return nil
+}
+
+func (w *MemoryStore) SetIndexReport(_ context.Context, report IndexReport) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.indexReport = report
+	return nil
 }
 
+func (w *MemoryStore) GetIndexReport(_ context.Context) IndexReport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.indexReport
+}
+
+// SetRunMetadata sets the metadata of the store's index run.
+func (w *MemoryStore) SetRunMetadata(_ context.Context, metadata RunMetadata) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.runMetadata = &metadata
+	return nil
+}
+
+// CreateOutputDocument returns the document of the store, with its records ordered by ID.
 func (w *MemoryStore) CreateOutputDocument() Document {
 	providerMarkets := make([]ProviderMarket, 0, len(w.providerMarkets))
 	for _, providerMarket := range w.providerMarkets {
@@ -252,19 +442,25 @@ func (w *MemoryStore) CreateOutputDocument() Document {
 		assetInfos = append(assetInfos, *assetInfo)
 	}
 
-	return Document{
+	var indexReport *IndexReport
+	if len(w.indexReport.FailedIngesters) > 0 || len(w.indexReport.FiredAliases) > 0 ||
+		len(w.indexReport.AssetInfoChanges) > 0 {
+		indexReport = &w.indexReport
+	}
+
+	document := Document{
+		SchemaVersion:   SchemaVersion,
+		Run:             w.runMetadata,
 		ProviderMarkets: providerMarkets,
 		AssetInfos:      assetInfos,
+		IndexReport:     indexReport,
 	}
+	sortDocument(&document)
+
+	return document
 }
 
+// WriteToPath writes the document of the store to path, in the format selected by the extension of path.
 func (w *MemoryStore) WriteToPath(_ context.Context, path string) error {
-	document := w.CreateOutputDocument()
-
-	bz, err := json.Marshal(document)
-	if err != nil {
-		return err
-	}
-
-	return file.WriteBytesToFile(path, bz)
+	return WriteDocument(path, w.CreateOutputDocument())
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -79,3 +80,401 @@ func TestMemoryStoreWriteToPath(t *testing.T) {
 	require.Equal(t, original.NegativeDepthTwo, row.NegativeDepthTwo, "NegativeDepthTwo should be preserved")
 	require.Equal(t, original.PositiveDepthTwo, row.PositiveDepthTwo, "PositiveDepthTwo should be preserved")
 }
+
+func TestMemoryStoreIndexReportRoundTrip(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	report := IndexReport{
+		FailedIngesters: []IngesterFailure{
+			{
+				Ingester:      "okx",
+				ProviderNames: []string{"okx_ws"},
+				Error:         "503 service unavailable",
+			},
+		},
+	}
+	require.NoError(t, store.SetIndexReport(ctx, report))
+
+	tmpfile, err := os.CreateTemp("", "test-store-*.json")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	require.NoError(t, store.WriteToPath(ctx, tmpfile.Name()))
+
+	newStore, err := NewMemoryStoreFromFile(tmpfile.Name())
+	require.NoError(t, err)
+	require.Equal(t, report, newStore.GetIndexReport(ctx))
+	require.Contains(t, newStore.GetIndexReport(ctx).FailedProviders(), "okx_ws")
+}
+
+func TestMemoryStoreCoinGeckoAssetInfo(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	btc, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1})
+	require.NoError(t, err)
+
+	// a CoinGecko ID cross-checked against a CMC asset is set on the existing asset.
+	crossChecked, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1, CoinGeckoID: "bitcoin"})
+	require.NoError(t, err)
+	require.Equal(t, btc.ID, crossChecked.ID)
+	require.Equal(t, "bitcoin", crossChecked.CoinGeckoID)
+
+	// assets that are only listed on CoinGecko are unique by their CoinGecko ID.
+	foo, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "FOO", CoinGeckoID: "foo"})
+	require.NoError(t, err)
+	bar, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BAR", CoinGeckoID: "bar"})
+	require.NoError(t, err)
+	require.NotEqual(t, foo.ID, bar.ID)
+
+	fooAgain, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "FOO", CoinGeckoID: "foo", Rank: 500})
+	require.NoError(t, err)
+	require.Equal(t, foo.ID, fooAgain.ID)
+	require.Equal(t, int64(500), fooAgain.Rank)
+
+	_, err = store.AddProviderMarket(ctx, CreateProviderMarketParams{
+		TargetBase:       "FOO",
+		TargetQuote:      "BTC",
+		OffChainTicker:   "FOOBTC",
+		ProviderName:     "test_provider",
+		BaseAssetInfoID:  foo.ID,
+		QuoteAssetInfoID: btc.ID,
+	})
+	require.NoError(t, err)
+
+	rows, err := store.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{ProviderNames: []string{"test_provider"}})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(0), rows[0].BaseCmcID)
+	require.Equal(t, "foo", rows[0].BaseCoinGeckoID)
+	require.Equal(t, int64(1), rows[0].QuoteCmcID)
+	require.Equal(t, "bitcoin", rows[0].QuoteCoinGeckoID)
+
+	cmcIDToAssetInfo := store.GetCMCIDToAssetInfo(ctx)
+	require.Len(t, cmcIDToAssetInfo, 1)
+	require.Equal(t, "BTC", cmcIDToAssetInfo[1].Symbol)
+}
+
+func TestAddAssetInfoUpserts(t *testing.T) {
+	firstRun := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondRun := firstRun.Add(time.Hour)
+
+	first := CreateAssetInfoParams{
+		Symbol:         "MATIC",
+		CmcID:          3890,
+		Rank:           20,
+		MultiAddresses: [][]string{{"ethereum", "0xabc"}},
+		CMCTags:        []string{"layer-2"},
+		ObservedAt:     firstRun,
+	}
+
+	// the fields of the changes, observed at the time of the update unless they are in wantChangedBefore.
+	tests := []struct {
+		name              string
+		update            CreateAssetInfoParams
+		want              AssetInfo
+		wantChanged       []string
+		wantChangedBefore []string
+	}{
+		{
+			name: "same values",
+			update: CreateAssetInfoParams{
+				Symbol: "MATIC", CmcID: 3890, Rank: 20, MultiAddresses: [][]string{{"ethereum", "0xabc"}},
+				CMCTags: []string{"layer-2"}, ObservedAt: secondRun,
+			},
+			want: AssetInfo{
+				Symbol: "MATIC", Rank: 20, MultiAddresses: [][]string{{"ethereum", "0xabc"}},
+				CMCTags: []string{"layer-2"}, ObservedAt: secondRun,
+			},
+		},
+		{
+			name: "newer observation renames, reranks, retags and unions addresses",
+			update: CreateAssetInfoParams{
+				Symbol: "POL", CmcID: 3890, Rank: 25, MultiAddresses: [][]string{{"polygon", "0xdef"}},
+				CMCTags: []string{"layer-2", "polygon-ecosystem"}, CoinGeckoID: "polygon", ObservedAt: secondRun,
+			},
+			want: AssetInfo{
+				Symbol: "POL", Rank: 25, MultiAddresses: [][]string{{"ethereum", "0xabc"}, {"polygon", "0xdef"}},
+				CMCTags: []string{"layer-2", "polygon-ecosystem"}, CoinGeckoID: "polygon", ObservedAt: secondRun,
+			},
+			wantChanged: []string{
+				AssetInfoFieldMultiAddresses, AssetInfoFieldCoinGeckoID, AssetInfoFieldSymbol, AssetInfoFieldRank,
+				AssetInfoFieldCMCTags,
+			},
+		},
+		{
+			name:   "newer observation without rank clears it and keeps tags",
+			update: CreateAssetInfoParams{Symbol: "MATIC", CmcID: 3890, ObservedAt: secondRun},
+			want: AssetInfo{
+				Symbol: "MATIC", MultiAddresses: [][]string{{"ethereum", "0xabc"}},
+				CMCTags: []string{"layer-2"}, ObservedAt: secondRun,
+			},
+			wantChanged: []string{AssetInfoFieldRank},
+		},
+		{
+			name: "older observation only adds addresses and a missing CoinGecko ID, and records its differences",
+			update: CreateAssetInfoParams{
+				Symbol: "OLD", CmcID: 3890, Rank: 10, MultiAddresses: [][]string{{"polygon", "0xdef"}},
+				CMCTags: []string{}, CoinGeckoID: "matic-network", ObservedAt: firstRun.Add(-time.Hour),
+			},
+			want: AssetInfo{
+				Symbol: "MATIC", Rank: 20, MultiAddresses: [][]string{{"ethereum", "0xabc"}, {"polygon", "0xdef"}},
+				CMCTags: []string{"layer-2"}, CoinGeckoID: "matic-network", ObservedAt: firstRun,
+			},
+			wantChanged:       []string{AssetInfoFieldMultiAddresses, AssetInfoFieldCoinGeckoID},
+			wantChangedBefore: []string{AssetInfoFieldSymbol, AssetInfoFieldRank, AssetInfoFieldCMCTags},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, store := range []Store{NewMemoryStore(), newTestSQLiteStore(t)} {
+				added, err := store.AddAssetInfo(ctx, first)
+				require.NoError(t, err)
+				require.True(t, added.RankValid)
+				require.True(t, added.CMCIDValid)
+
+				updated, err := store.AddAssetInfo(ctx, tc.update)
+				require.NoError(t, err)
+
+				want := tc.want
+				want.ID = added.ID
+				want.IsCrypto = true
+				want.CMCID = 3890
+				want.CMCIDValid = true
+				want.RankValid = want.Rank != 0
+				require.Equal(t, want, updated)
+				require.Equal(t, want, store.GetCMCIDToAssetInfo(ctx)[3890])
+
+				changes := store.GetAssetInfoChanges(ctx)
+				var changed, changedBefore []string
+				for _, change := range changes {
+					require.Equal(t, added.ID, change.AssetInfoID)
+					if change.ObservedAt.Equal(tc.update.ObservedAt) {
+						changed = append(changed, change.Field)
+					} else {
+						require.Equal(t, firstRun, change.ObservedAt)
+						changedBefore = append(changedBefore, change.Field)
+					}
+				}
+				require.ElementsMatch(t, tc.wantChanged, changed)
+				require.ElementsMatch(t, tc.wantChangedBefore, changedBefore)
+			}
+		})
+	}
+}
+
+func TestAssetInfoChangeValues(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "MATIC", CmcID: 3890, Rank: 20})
+	require.NoError(t, err)
+	_, err = store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "POL", CmcID: 3890, Rank: 20})
+	require.NoError(t, err)
+
+	changes := store.GetAssetInfoChanges(ctx)
+	require.Len(t, changes, 1)
+	require.Equal(t, AssetInfoFieldSymbol, changes[0].Field)
+	require.Equal(t, int64(3890), changes[0].CMCID)
+	require.JSONEq(t, `"MATIC"`, string(changes[0].OldValue))
+	require.JSONEq(t, `"POL"`, string(changes[0].NewValue))
+
+	// the change log is written with the index report.
+	require.NoError(t, store.SetIndexReport(ctx, IndexReport{AssetInfoChanges: changes}))
+	require.Equal(t, changes, store.CreateOutputDocument().IndexReport.AssetInfoChanges)
+
+	// merging a previous run records the rename since it, observed in the latest run.
+	latestRun := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	latest := NewMemoryStoreObservedAt(latestRun)
+	_, err = latest.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "POL", CmcID: 3890, Rank: 20})
+	require.NoError(t, err)
+	previous := NewMemoryStoreObservedAt(latestRun.Add(-24 * time.Hour))
+	_, err = previous.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "MATIC", CmcID: 3890, Rank: 20})
+	require.NoError(t, err)
+	require.NoError(t, latest.Merge(ctx, previous.CreateOutputDocument()))
+
+	changes = latest.GetAssetInfoChanges(ctx)
+	require.Len(t, changes, 1)
+	require.Equal(t, AssetInfoFieldSymbol, changes[0].Field)
+	require.JSONEq(t, `"MATIC"`, string(changes[0].OldValue))
+	require.JSONEq(t, `"POL"`, string(changes[0].NewValue))
+	require.Equal(t, latestRun, changes[0].ObservedAt)
+}
+
+func TestMemoryStoreMerge(t *testing.T) {
+	ctx := context.Background()
+	previousRun := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	latestRun := previousRun.Add(24 * time.Hour)
+
+	// the previous index run has BTC/USD and ETH/USD, which has since been delisted.
+	previous := NewMemoryStoreObservedAt(previousRun)
+	btc, err := previous.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1, CoinGeckoID: "bitcoin"})
+	require.NoError(t, err)
+	eth, err := previous.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "ETH", CmcID: 1027, Rank: 2})
+	require.NoError(t, err)
+	usd, err := previous.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "USD", CmcID: 2781, Rank: 3})
+	require.NoError(t, err)
+	for _, base := range []AssetInfo{btc, eth} {
+		_, err = previous.AddProviderMarket(ctx, CreateProviderMarketParams{
+			TargetBase:       base.Symbol,
+			TargetQuote:      "USD",
+			OffChainTicker:   base.Symbol + "-USD",
+			ProviderName:     "test_provider",
+			BaseAssetInfoID:  base.ID,
+			QuoteAssetInfoID: usd.ID,
+			QuoteVolume:      1000,
+		})
+		require.NoError(t, err)
+	}
+
+	// the latest index run only has BTC/USD, with a different volume and asset IDs.
+	latest := NewMemoryStoreObservedAt(latestRun)
+	latestUSD, err := latest.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "USD", CmcID: 2781, Rank: 3})
+	require.NoError(t, err)
+	latestBTC, err := latest.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1})
+	require.NoError(t, err)
+	_, err = latest.AddProviderMarket(ctx, CreateProviderMarketParams{
+		TargetBase:       "BTC",
+		TargetQuote:      "USD",
+		OffChainTicker:   "BTC-USD",
+		ProviderName:     "test_provider",
+		BaseAssetInfoID:  latestBTC.ID,
+		QuoteAssetInfoID: latestUSD.ID,
+		QuoteVolume:      500,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, latest.Merge(ctx, previous.CreateOutputDocument()))
+
+	rows, err := latest.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{ProviderNames: []string{"test_provider"}})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	observed := make(map[string]GetFilteredProviderMarketsRow, len(rows))
+	for _, row := range rows {
+		observed[row.OffChainTicker] = row
+	}
+
+	// BTC/USD keeps the values of the latest run, and the older observation fills in the CoinGecko ID.
+	require.Equal(t, 500.0, observed["BTC-USD"].QuoteVolume)
+	require.Equal(t, latestRun, observed["BTC-USD"].ObservedAt)
+	require.Equal(t, "bitcoin", observed["BTC-USD"].BaseCoinGeckoID)
+
+	// ETH/USD is kept from the previous run, with its assets remapped to the IDs of the store.
+	require.Equal(t, previousRun, observed["ETH-USD"].ObservedAt)
+	require.Equal(t, int64(1027), observed["ETH-USD"].BaseCmcID)
+	require.Equal(t, int64(2781), observed["ETH-USD"].QuoteCmcID)
+
+	// merging in the other order gives the same markets.
+	require.NoError(t, previous.Merge(ctx, NewMemoryStoreObservedAt(latestRun).CreateOutputDocument()))
+	require.NoError(t, previous.Merge(ctx, latest.CreateOutputDocument()))
+	reversed, err := previous.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{ProviderNames: []string{"test_provider"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, rows, reversed)
+
+	// markets older than the max market age are excluded.
+	rows, err = latest.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{
+		ProviderNames: []string{"test_provider"},
+		MaxMarketAge:  time.Hour,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "BTC-USD", rows[0].OffChainTicker)
+
+	rows, err = latest.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{
+		ProviderNames: []string{"test_provider"},
+		MaxMarketAge:  48 * time.Hour,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+}
+
+func TestMemoryStoreMergeUnknownAssetInfo(t *testing.T) {
+	store := NewMemoryStore()
+
+	err := store.Merge(context.Background(), Document{
+		ProviderMarkets: []ProviderMarket{
+			{OffChainTicker: "BTC-USD", ProviderName: "test_provider", BaseAssetInfoID: 0, QuoteAssetInfoID: 1},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestMemoryStoreObservedAtRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	observedAt := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	store := NewMemoryStoreObservedAt(observedAt)
+
+	btc, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1})
+	require.NoError(t, err)
+	_, err = store.AddProviderMarket(ctx, CreateProviderMarketParams{
+		TargetBase:       "BTC",
+		TargetQuote:      "BTC",
+		OffChainTicker:   "BTC-BTC",
+		ProviderName:     "test_provider",
+		BaseAssetInfoID:  btc.ID,
+		QuoteAssetInfoID: btc.ID,
+	})
+	require.NoError(t, err)
+
+	tmpfile, err := os.CreateTemp("", "test-store-*.json")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	require.NoError(t, store.WriteToPath(ctx, tmpfile.Name()))
+
+	newStore, err := NewMemoryStoreFromFile(tmpfile.Name())
+	require.NoError(t, err)
+
+	document := newStore.CreateOutputDocument()
+	require.Len(t, document.AssetInfos, 1)
+	require.Equal(t, observedAt, document.AssetInfos[0].ObservedAt)
+	require.Len(t, document.ProviderMarkets, 1)
+	require.Equal(t, observedAt, document.ProviderMarkets[0].ObservedAt)
+}
+
+func TestMemoryStoreFromFileUpserts(t *testing.T) {
+	ctx := context.Background()
+	observedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStoreObservedAt(observedAt)
+
+	btc, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1})
+	require.NoError(t, err)
+	bar, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BAR", CoinGeckoID: "bar"})
+	require.NoError(t, err)
+	_, err = store.AddProviderMarket(ctx, CreateProviderMarketParams{
+		TargetBase:       "BAR",
+		TargetQuote:      "BTC",
+		OffChainTicker:   "BAR-BTC",
+		ProviderName:     "test_provider",
+		BaseAssetInfoID:  bar.ID,
+		QuoteAssetInfoID: btc.ID,
+		QuoteVolume:      1000,
+	})
+	require.NoError(t, err)
+
+	tmpfile, err := os.CreateTemp("", "test-store-*.json")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	require.NoError(t, store.WriteToPath(ctx, tmpfile.Name()))
+
+	newStore, err := NewMemoryStoreFromFile(tmpfile.Name())
+	require.NoError(t, err)
+
+	// merging a later run of the same document updates its records instead of duplicating them.
+	later := store.CreateOutputDocument()
+	for i := range later.ProviderMarkets {
+		later.ProviderMarkets[i].QuoteVolume = 2000
+		later.ProviderMarkets[i].ObservedAt = observedAt.Add(time.Hour)
+	}
+	require.NoError(t, newStore.Merge(ctx, later))
+
+	document := newStore.CreateOutputDocument()
+	require.Len(t, document.AssetInfos, 2)
+	require.Len(t, document.ProviderMarkets, 1)
+	require.Equal(t, 2000.0, document.ProviderMarkets[0].QuoteVolume)
+}
@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	provider "github.com/skip-mev/connect-mmu/store/provider"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -137,6 +136,54 @@ func (_c *Store_AddProviderMarket_Call) RunAndReturn(run func(context.Context, p
 	return _c
 }
 
+// GetAssetInfoChanges provides a mock function with given fields: ctx
+func (_m *Store) GetAssetInfoChanges(ctx context.Context) []provider.AssetInfoChange {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetInfoChanges")
+	}
+
+	var r0 []provider.AssetInfoChange
+	if rf, ok := ret.Get(0).(func(context.Context) []provider.AssetInfoChange); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]provider.AssetInfoChange)
+		}
+	}
+
+	return r0
+}
+
+// Store_GetAssetInfoChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetInfoChanges'
+type Store_GetAssetInfoChanges_Call struct {
+	*mock.Call
+}
+
+// GetAssetInfoChanges is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Store_Expecter) GetAssetInfoChanges(ctx interface{}) *Store_GetAssetInfoChanges_Call {
+	return &Store_GetAssetInfoChanges_Call{Call: _e.mock.On("GetAssetInfoChanges", ctx)}
+}
+
+func (_c *Store_GetAssetInfoChanges_Call) Run(run func(ctx context.Context)) *Store_GetAssetInfoChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Store_GetAssetInfoChanges_Call) Return(_a0 []provider.AssetInfoChange) *Store_GetAssetInfoChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_GetAssetInfoChanges_Call) RunAndReturn(run func(context.Context) []provider.AssetInfoChange) *Store_GetAssetInfoChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCMCIDToAssetInfo provides a mock function with given fields: ctx
 func (_m *Store) GetCMCIDToAssetInfo(ctx context.Context) map[int64]provider.AssetInfo {
 	ret := _m.Called(ctx)
@@ -185,6 +232,52 @@ func (_c *Store_GetCMCIDToAssetInfo_Call) RunAndReturn(run func(context.Context)
 	return _c
 }
 
+// GetIndexReport provides a mock function with given fields: ctx
+func (_m *Store) GetIndexReport(ctx context.Context) provider.IndexReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIndexReport")
+	}
+
+	var r0 provider.IndexReport
+	if rf, ok := ret.Get(0).(func(context.Context) provider.IndexReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(provider.IndexReport)
+	}
+
+	return r0
+}
+
+// Store_GetIndexReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIndexReport'
+type Store_GetIndexReport_Call struct {
+	*mock.Call
+}
+
+// GetIndexReport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Store_Expecter) GetIndexReport(ctx interface{}) *Store_GetIndexReport_Call {
+	return &Store_GetIndexReport_Call{Call: _e.mock.On("GetIndexReport", ctx)}
+}
+
+func (_c *Store_GetIndexReport_Call) Run(run func(ctx context.Context)) *Store_GetIndexReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Store_GetIndexReport_Call) Return(_a0 provider.IndexReport) *Store_GetIndexReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_GetIndexReport_Call) RunAndReturn(run func(context.Context) provider.IndexReport) *Store_GetIndexReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProviderMarkets provides a mock function with given fields: ctx, params
 func (_m *Store) GetProviderMarkets(ctx context.Context, params provider.GetFilteredProviderMarketsParams) ([]provider.GetFilteredProviderMarketsRow, error) {
 	ret := _m.Called(ctx, params)
@@ -244,6 +337,147 @@ func (_c *Store_GetProviderMarkets_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// Merge provides a mock function with given fields: ctx, document
+func (_m *Store) Merge(ctx context.Context, document provider.Document) error {
+	ret := _m.Called(ctx, document)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, provider.Document) error); ok {
+		r0 = rf(ctx, document)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type Store_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - document provider.Document
+func (_e *Store_Expecter) Merge(ctx interface{}, document interface{}) *Store_Merge_Call {
+	return &Store_Merge_Call{Call: _e.mock.On("Merge", ctx, document)}
+}
+
+func (_c *Store_Merge_Call) Run(run func(ctx context.Context, document provider.Document)) *Store_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(provider.Document))
+	})
+	return _c
+}
+
+func (_c *Store_Merge_Call) Return(_a0 error) *Store_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_Merge_Call) RunAndReturn(run func(context.Context, provider.Document) error) *Store_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIndexReport provides a mock function with given fields: ctx, report
+func (_m *Store) SetIndexReport(ctx context.Context, report provider.IndexReport) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIndexReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, provider.IndexReport) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_SetIndexReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIndexReport'
+type Store_SetIndexReport_Call struct {
+	*mock.Call
+}
+
+// SetIndexReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report provider.IndexReport
+func (_e *Store_Expecter) SetIndexReport(ctx interface{}, report interface{}) *Store_SetIndexReport_Call {
+	return &Store_SetIndexReport_Call{Call: _e.mock.On("SetIndexReport", ctx, report)}
+}
+
+func (_c *Store_SetIndexReport_Call) Run(run func(ctx context.Context, report provider.IndexReport)) *Store_SetIndexReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(provider.IndexReport))
+	})
+	return _c
+}
+
+func (_c *Store_SetIndexReport_Call) Return(_a0 error) *Store_SetIndexReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_SetIndexReport_Call) RunAndReturn(run func(context.Context, provider.IndexReport) error) *Store_SetIndexReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRunMetadata provides a mock function with given fields: ctx, metadata
+func (_m *Store) SetRunMetadata(ctx context.Context, metadata provider.RunMetadata) error {
+	ret := _m.Called(ctx, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRunMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, provider.RunMetadata) error); ok {
+		r0 = rf(ctx, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_SetRunMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRunMetadata'
+type Store_SetRunMetadata_Call struct {
+	*mock.Call
+}
+
+// SetRunMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - metadata provider.RunMetadata
+func (_e *Store_Expecter) SetRunMetadata(ctx interface{}, metadata interface{}) *Store_SetRunMetadata_Call {
+	return &Store_SetRunMetadata_Call{Call: _e.mock.On("SetRunMetadata", ctx, metadata)}
+}
+
+func (_c *Store_SetRunMetadata_Call) Run(run func(ctx context.Context, metadata provider.RunMetadata)) *Store_SetRunMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(provider.RunMetadata))
+	})
+	return _c
+}
+
+func (_c *Store_SetRunMetadata_Call) Return(_a0 error) *Store_SetRunMetadata_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_SetRunMetadata_Call) RunAndReturn(run func(context.Context, provider.RunMetadata) error) *Store_SetRunMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WriteToPath provides a mock function with given fields: ctx, path
 func (_m *Store) WriteToPath(ctx context.Context, path string) error {
 	ret := _m.Called(ctx, path)
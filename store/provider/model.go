@@ -1,25 +1,111 @@
 package provider
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/skip-mev/connect-mmu/lib/symbols"
 )
 
 type Document struct {
+	// SchemaVersion is the version of the document's format. Documents without one have version 0.
+	SchemaVersion   int              `json:"schema_version"`
+	Run             *RunMetadata     `json:"run,omitempty"`
 	AssetInfos      []AssetInfo      `json:"asset_infos"`
 	ProviderMarkets []ProviderMarket `json:"provider_markets"`
+	IndexReport     *IndexReport     `json:"index_report,omitempty"`
+}
+
+// RunMetadata describes the index run that produced a Document.
+type RunMetadata struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// ConfigHash identifies the index config of the run, see config.MarketConfig.Hash.
+	ConfigHash string `json:"config_hash"`
+	// Ingesters are the names of the ingesters of the run, including ones that failed.
+	Ingesters []string `json:"ingesters"`
+}
+
+// IndexReport summarizes the outcome of the index run that produced a Document.
+type IndexReport struct {
+	// FailedIngesters are the optional ingesters that failed during the index run. Markets for the
+	// providers of these ingesters are missing from the Document because of the failure, not because
+	// the markets were delisted.
+	FailedIngesters []IngesterFailure `json:"failed_ingesters"`
+	// FiredAliases are the symbol aliases that were applied to the ingested markets.
+	FiredAliases []symbols.AliasUsage `json:"fired_aliases,omitempty"`
+	// AssetInfoChanges are the changes to the metadata of assets that were indexed more than once.
+	AssetInfoChanges []AssetInfoChange `json:"asset_info_changes,omitempty"`
+	// SkippedMarkets are the counts of the markets that ingesters fetched but could not index, by reason.
+	SkippedMarkets []SkippedMarkets `json:"skipped_markets,omitempty"`
+}
+
+// SkippedMarkets is the number of markets an ingester skipped for a reason during an index run.
+type SkippedMarkets struct {
+	Ingester string `json:"ingester"`
+	Reason   string `json:"reason"`
+	Count    int    `json:"count"`
+}
+
+// AssetInfoChange is a change to a field of an AssetInfo by a later observation of the asset.
+type AssetInfoChange struct {
+	AssetInfoID int32 `json:"asset_info_id"`
+	CMCID       int64 `json:"cmc_id"`
+	// Field is the JSON name of the field, e.g. symbol for a renamed asset.
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+	// ObservedAt is the observation time of the new value.
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// Equal returns true if both changes change the same field of the same asset info between the same values at the
+// same time.
+func (c AssetInfoChange) Equal(other AssetInfoChange) bool {
+	return c.AssetInfoID == other.AssetInfoID && c.CMCID == other.CMCID && c.Field == other.Field &&
+		bytes.Equal(c.OldValue, other.OldValue) && bytes.Equal(c.NewValue, other.NewValue) &&
+		c.ObservedAt.Equal(other.ObservedAt)
+}
+
+// IngesterFailure describes an ingester that failed during an index run.
+type IngesterFailure struct {
+	Ingester      string   `json:"ingester"`
+	ProviderNames []string `json:"provider_names"`
+	Error         string   `json:"error"`
+}
+
+// FailedProviders returns the set of provider names whose ingesters failed during the index run.
+func (r IndexReport) FailedProviders() map[string]IngesterFailure {
+	failed := make(map[string]IngesterFailure)
+	for _, failure := range r.FailedIngesters {
+		for _, providerName := range failure.ProviderNames {
+			failed[providerName] = failure
+		}
+	}
+	return failed
 }
 
 type AssetInfo struct {
-	ID             int32      `json:"id"`
-	Symbol         string     `json:"symbol"`
-	IsCrypto       bool       `json:"is_crypto"`
+	ID       int32  `json:"id"`
+	Symbol   string `json:"symbol"`
+	IsCrypto bool   `json:"is_crypto"`
+	// Rank is the CMC rank of the asset.
 	Rank           int64      `json:"rank"`
 	RankValid      bool       `json:"rank_valid"`
 	CMCID          int64      `json:"cmc_id"`
 	CMCIDValid     bool       `json:"cmc_id_valid"`
 	MultiAddresses [][]string `json:"multi_addresses"`
 	CMCTags        []string   `json:"cmc_tags"`
+	CoinGeckoID    string     `json:"coingecko_id,omitempty"`
+	// CoinGeckoRank is the CoinGecko market cap rank of an asset that is only listed on CoinGecko. It is on a
+	// different scale than CMC ranks, so it is kept apart from Rank.
+	CoinGeckoRank int64 `json:"coingecko_rank,omitempty"`
+	// ObservedAt is when the asset info was last indexed.
+	ObservedAt time.Time `json:"observed_at"`
 }
 
 //nolint:revive
@@ -37,6 +123,8 @@ type ProviderMarket struct {
 	ReferencePrice   float64 `json:"reference_price"`
 	NegativeDepthTwo float64 `json:"negative_depth_two"`
 	PositiveDepthTwo float64 `json:"positive_depth_two"`
+	// ObservedAt is when the market was last indexed.
+	ObservedAt time.Time `json:"observed_at"`
 }
 
 // CreateProviderMarket wraps generated CreateProviderMarketParams with extra info.
@@ -96,6 +184,12 @@ type CreateAssetInfoParams struct {
 	Rank           int64
 	MultiAddresses [][]string
 	CMCTags        []string
+	// CoinGeckoID is the ID of the asset on CoinGecko. Assets without a CmcID are identified by it.
+	CoinGeckoID string
+	// CoinGeckoRank is the CoinGecko market cap rank of an asset that is only listed on CoinGecko.
+	CoinGeckoRank int64
+	// ObservedAt is when the asset was indexed. If zero, the store sets it to its observation time.
+	ObservedAt time.Time
 }
 
 type CreateProviderMarketParams struct {
@@ -111,25 +205,87 @@ type CreateProviderMarketParams struct {
 	ReferencePrice   float64
 	NegativeDepthTwo float64
 	PositiveDepthTwo float64
+	// ObservedAt is when the market was indexed. If zero, the store sets it to its observation time.
+	ObservedAt time.Time
 }
 
 type GetFilteredProviderMarketsParams struct {
 	ProviderNames []string
+	// MaxMarketAge excludes markets that were observed longer than MaxMarketAge before the most recently
+	// observed market in the store. Markets without an observation time, from documents written before
+	// observation times were recorded, have an unknown age and are kept. If 0, markets are not filtered by age.
+	MaxMarketAge time.Duration
+
+	// The following filters are ignored if empty or 0.
+
+	// TargetQuotes are the quotes of the markets.
+	TargetQuotes []string
+	// MinUsdVolume is the minimum 24 hour volume of the markets in USD.
+	MinUsdVolume float64
+	// MinLiquidity is the minimum ±2% depth in USD of both sides of the markets' order books.
+	MinLiquidity float64
+	// MinRank and MaxRank bound the CMC rank of the markets' base assets. If either is set, markets with an
+	// unranked base asset are excluded.
+	MinRank int64
+	MaxRank int64
+	// CMCTags selects markets whose base asset has at least one of the CMC tags.
+	CMCTags []string
+	// BaseCmcIDs are the CMC IDs of the markets' base assets.
+	BaseCmcIDs []int64
+}
+
+// matches returns true if the market with the given base asset passes the filters of the params other than
+// ProviderNames and MaxMarketAge.
+func (p GetFilteredProviderMarketsParams) matches(providerMarket ProviderMarket, baseAssetInfo AssetInfo) bool {
+	if len(p.TargetQuotes) > 0 && !slices.Contains(p.TargetQuotes, providerMarket.TargetQuote) {
+		return false
+	}
+
+	if p.MinUsdVolume > 0 && providerMarket.UsdVolume < p.MinUsdVolume {
+		return false
+	}
+
+	if p.MinLiquidity > 0 && (providerMarket.NegativeDepthTwo < p.MinLiquidity || providerMarket.PositiveDepthTwo < p.MinLiquidity) {
+		return false
+	}
+
+	if p.MinRank > 0 || p.MaxRank > 0 {
+		if baseAssetInfo.Rank <= 0 || baseAssetInfo.Rank < p.MinRank || (p.MaxRank > 0 && baseAssetInfo.Rank > p.MaxRank) {
+			return false
+		}
+	}
+
+	if len(p.CMCTags) > 0 && !slices.ContainsFunc(baseAssetInfo.CMCTags, func(tag string) bool {
+		return slices.Contains(p.CMCTags, tag)
+	}) {
+		return false
+	}
+
+	if len(p.BaseCmcIDs) > 0 && !slices.Contains(p.BaseCmcIDs, baseAssetInfo.CMCID) {
+		return false
+	}
+
+	return true
 }
 
 type GetFilteredProviderMarketsRow struct {
-	TargetBase       string
-	TargetQuote      string
-	OffChainTicker   string
-	ProviderName     string
-	QuoteVolume      float64
-	UsdVolume        float64
-	MetadataJSON     []byte
-	ReferencePrice   float64
-	NegativeDepthTwo float64
-	PositiveDepthTwo float64
-	BaseCmcID        int64
-	QuoteCmcID       int64
-	BaseRank         int64
-	QuoteRank        int64
+	TargetBase         string
+	TargetQuote        string
+	OffChainTicker     string
+	ProviderName       string
+	QuoteVolume        float64
+	UsdVolume          float64
+	MetadataJSON       []byte
+	ReferencePrice     float64
+	NegativeDepthTwo   float64
+	PositiveDepthTwo   float64
+	BaseCmcID          int64
+	QuoteCmcID         int64
+	BaseRank           int64
+	QuoteRank          int64
+	BaseCoinGeckoID    string
+	QuoteCoinGeckoID   string
+	BaseCoinGeckoRank  int64
+	QuoteCoinGeckoRank int64
+	ObservedAt         time.Time
 }
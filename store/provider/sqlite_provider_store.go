@@ -0,0 +1,925 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	// registers the pure Go "sqlite" database/sql driver.
+	_ "modernc.org/sqlite"
+)
+
+// sqliteTimeFormat is the format observation times are stored in. It has a fixed width, so times sort
+// lexically, and SQLite's date and time functions can read it.
+const sqliteTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// sqliteSchema creates the tables of a SQLiteStore. asset_infos and provider_markets hold the most recently
+// observed values of each record, with the same unique indexes as the MemoryStore, and
+// provider_market_observations keeps the values of every index run.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS asset_infos (
+	id              INTEGER PRIMARY KEY,
+	symbol          TEXT    NOT NULL,
+	is_crypto       BOOLEAN NOT NULL,
+	rank            INTEGER NOT NULL,
+	cmc_id          INTEGER NOT NULL,
+	multi_addresses TEXT    NOT NULL,
+	cmc_tags        TEXT    NOT NULL,
+	coingecko_id    TEXT    NOT NULL,
+	observed_at     TEXT    NOT NULL
+);
+
+-- assets are unique by CMC ID, or by CoinGecko ID for assets that are not listed on CMC.
+CREATE UNIQUE INDEX IF NOT EXISTS asset_infos_cmc_id
+	ON asset_infos (cmc_id) WHERE cmc_id != 0 OR coingecko_id = '';
+CREATE UNIQUE INDEX IF NOT EXISTS asset_infos_coingecko_id
+	ON asset_infos (coingecko_id) WHERE cmc_id = 0 AND coingecko_id != '';
+
+CREATE TABLE IF NOT EXISTS provider_markets (
+	id                  INTEGER PRIMARY KEY,
+	target_base         TEXT    NOT NULL,
+	target_quote        TEXT    NOT NULL,
+	off_chain_ticker    TEXT    NOT NULL,
+	provider_name       TEXT    NOT NULL,
+	quote_volume        REAL    NOT NULL,
+	usd_volume          REAL    NOT NULL,
+	base_asset_info_id  INTEGER NOT NULL REFERENCES asset_infos (id),
+	quote_asset_info_id INTEGER NOT NULL REFERENCES asset_infos (id),
+	metadata_json       TEXT    NOT NULL,
+	reference_price     REAL    NOT NULL,
+	negative_depth_two  REAL    NOT NULL,
+	positive_depth_two  REAL    NOT NULL,
+	observed_at         TEXT    NOT NULL,
+	UNIQUE (off_chain_ticker, provider_name)
+);
+
+CREATE TABLE IF NOT EXISTS provider_market_observations (
+	provider_market_id INTEGER NOT NULL REFERENCES provider_markets (id),
+	observed_at        TEXT    NOT NULL,
+	quote_volume       REAL    NOT NULL,
+	usd_volume         REAL    NOT NULL,
+	reference_price    REAL    NOT NULL,
+	negative_depth_two REAL    NOT NULL,
+	positive_depth_two REAL    NOT NULL,
+	PRIMARY KEY (provider_market_id, observed_at)
+);
+
+CREATE TABLE IF NOT EXISTS index_runs (
+	observed_at  TEXT PRIMARY KEY,
+	index_report TEXT NOT NULL
+);
+`
+
+// sqliteMigrations migrate the database from the schema version of their index, stored as its user_version, to
+// the next version. The first migration is idempotent, since databases created before schema versions were
+// recorded have version 0 but already have its tables.
+var sqliteMigrations = []string{
+	sqliteSchema,
+	// run_metadata is NULL for runs without metadata.
+	`ALTER TABLE index_runs ADD COLUMN run_metadata TEXT`,
+	// the CoinGecko ranks of assets that are only listed on CoinGecko were stored as their rank.
+	`ALTER TABLE asset_infos ADD COLUMN coingecko_rank INTEGER NOT NULL DEFAULT 0;
+UPDATE asset_infos SET coingecko_rank = rank, rank = 0 WHERE cmc_id = 0 AND coingecko_id != ''`,
+}
+
+var _ Store = &SQLiteStore{}
+
+// SQLiteStore is a Store backed by a SQLite database. Unlike the MemoryStore, the database keeps the history
+// of every index run written to it, and can be queried with SQL directly.
+type SQLiteStore struct {
+	db *sql.DB
+
+	// observedAt is the observation time of records added without one, and the index run of the index report.
+	observedAt time.Time
+
+	mu sync.Mutex
+	// assetInfoChanges are the changes that adding asset infos through this store made to existing asset infos.
+	assetInfoChanges []AssetInfoChange
+}
+
+// NewSQLiteStore opens the SQLite database at path, creating it if it does not exist.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	return NewSQLiteStoreObservedAt(path, time.Now())
+}
+
+// NewSQLiteStoreObservedAt opens the SQLite database at path, creating it if it does not exist. Records added
+// without an observation time are stamped with observedAt.
+func NewSQLiteStoreObservedAt(path string, observedAt time.Time) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+	// SQLite allows a single writer, so requests are serialized on a single connection.
+	db.SetMaxOpenConns(1)
+
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteStore{
+		db:         db,
+		observedAt: observedAt.UTC(),
+	}, nil
+}
+
+// migrateSQLite applies the migrations the database has not applied yet.
+func migrateSQLite(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read sqlite schema version: %w", err)
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("unsupported sqlite schema version %d: the latest supported version is %d", version, len(sqliteMigrations))
+	}
+
+	for ; version < len(sqliteMigrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		// PRAGMA statements can't take parameters.
+		_, err = tx.Exec(sqliteMigrations[version] + fmt.Sprintf(`; PRAGMA user_version = %d`, version+1))
+		if err == nil {
+			err = tx.Commit()
+		}
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to migrate sqlite schema from version %d: %w", version, err), tx.Rollback())
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// DB returns the database of the store, e.g. for ad-hoc queries.
+func (s *SQLiteStore) DB() *sql.DB {
+	return s.db
+}
+
+func (s *SQLiteStore) AddProviderMarket(ctx context.Context, params CreateProviderMarketParams) (ProviderMarket, error) {
+	if params.ObservedAt.IsZero() {
+		params.ObservedAt = s.observedAt
+	}
+
+	var providerMarket ProviderMarket
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		providerMarket, err = addSQLiteProviderMarket(ctx, tx, params, -1)
+		return err
+	})
+
+	return providerMarket, err
+}
+
+func (s *SQLiteStore) AddAssetInfo(ctx context.Context, params CreateAssetInfoParams) (AssetInfo, error) {
+	if params.ObservedAt.IsZero() {
+		params.ObservedAt = s.observedAt
+	}
+
+	var (
+		assetInfo AssetInfo
+		changes   []AssetInfoChange
+	)
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		assetInfo, changes, err = addSQLiteAssetInfo(ctx, tx, params, -1)
+		return err
+	})
+	if err != nil {
+		return AssetInfo{}, err
+	}
+	s.recordAssetInfoChanges(changes)
+
+	return assetInfo, nil
+}
+
+// GetAssetInfoChanges returns the changes that adding asset infos through this store made to existing asset
+// infos. Changes made by other connections to the database are not included.
+func (s *SQLiteStore) GetAssetInfoChanges(_ context.Context) []AssetInfoChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.assetInfoChanges)
+}
+
+// recordAssetInfoChanges records the changes of a committed transaction.
+func (s *SQLiteStore) recordAssetInfoChanges(changes []AssetInfoChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assetInfoChanges = append(s.assetInfoChanges, changes...)
+}
+
+// GetProviderMarkets returns the most recently observed values of the provider markets.
+func (s *SQLiteStore) GetProviderMarkets(ctx context.Context, params GetFilteredProviderMarketsParams) ([]GetFilteredProviderMarketsRow, error) {
+	rows := make([]GetFilteredProviderMarketsRow, 0)
+	if len(params.ProviderNames) == 0 {
+		return rows, nil
+	}
+
+	query := `
+SELECT pm.target_base, pm.target_quote, pm.off_chain_ticker, pm.provider_name, pm.quote_volume, pm.usd_volume,
+	pm.metadata_json, pm.reference_price, pm.negative_depth_two, pm.positive_depth_two,
+	base.cmc_id, quote.cmc_id, base.rank, quote.rank, base.coingecko_id, quote.coingecko_id,
+	base.coingecko_rank, quote.coingecko_rank, pm.observed_at
+FROM provider_markets pm
+JOIN asset_infos base ON base.id = pm.base_asset_info_id
+JOIN asset_infos quote ON quote.id = pm.quote_asset_info_id
+WHERE pm.provider_name IN (?` + strings.Repeat(", ?", len(params.ProviderNames)-1) + `)`
+
+	args := make([]any, 0, len(params.ProviderNames))
+	for _, providerName := range params.ProviderNames {
+		args = append(args, providerName)
+	}
+
+	if params.MaxMarketAge > 0 {
+		latest, err := s.latestObservation(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// markets without an observation time have an unknown age, so they are kept.
+		query += ` AND (pm.observed_at = ? OR pm.observed_at >= ?)`
+		args = append(args, formatSQLiteTime(time.Time{}), formatSQLiteTime(latest.Add(-params.MaxMarketAge)))
+	}
+
+	if len(params.TargetQuotes) > 0 {
+		query += ` AND pm.target_quote IN (?` + strings.Repeat(", ?", len(params.TargetQuotes)-1) + `)`
+		for _, quote := range params.TargetQuotes {
+			args = append(args, quote)
+		}
+	}
+	if params.MinUsdVolume > 0 {
+		query += ` AND pm.usd_volume >= ?`
+		args = append(args, params.MinUsdVolume)
+	}
+	if params.MinLiquidity > 0 {
+		query += ` AND pm.negative_depth_two >= ? AND pm.positive_depth_two >= ?`
+		args = append(args, params.MinLiquidity, params.MinLiquidity)
+	}
+	if params.MinRank > 0 || params.MaxRank > 0 {
+		query += ` AND base.rank > 0 AND base.rank >= ?`
+		args = append(args, params.MinRank)
+		if params.MaxRank > 0 {
+			query += ` AND base.rank <= ?`
+			args = append(args, params.MaxRank)
+		}
+	}
+	if len(params.CMCTags) > 0 {
+		query += ` AND EXISTS (SELECT 1 FROM json_each(base.cmc_tags) WHERE json_each.value IN (?` +
+			strings.Repeat(", ?", len(params.CMCTags)-1) + `))`
+		for _, tag := range params.CMCTags {
+			args = append(args, tag)
+		}
+	}
+	if len(params.BaseCmcIDs) > 0 {
+		query += ` AND base.cmc_id IN (?` + strings.Repeat(", ?", len(params.BaseCmcIDs)-1) + `)`
+		for _, cmcID := range params.BaseCmcIDs {
+			args = append(args, cmcID)
+		}
+	}
+	query += ` ORDER BY pm.id`
+
+	result, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider markets: %w", err)
+	}
+	defer result.Close()
+
+	for result.Next() {
+		var (
+			row          GetFilteredProviderMarketsRow
+			metadataJSON string
+			observedAt   string
+		)
+		if err := result.Scan(
+			&row.TargetBase, &row.TargetQuote, &row.OffChainTicker, &row.ProviderName, &row.QuoteVolume, &row.UsdVolume,
+			&metadataJSON, &row.ReferencePrice, &row.NegativeDepthTwo, &row.PositiveDepthTwo,
+			&row.BaseCmcID, &row.QuoteCmcID, &row.BaseRank, &row.QuoteRank, &row.BaseCoinGeckoID, &row.QuoteCoinGeckoID,
+			&row.BaseCoinGeckoRank, &row.QuoteCoinGeckoRank, &observedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan provider market: %w", err)
+		}
+
+		row.MetadataJSON = []byte(metadataJSON)
+		if row.ObservedAt, err = parseSQLiteTime(observedAt); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, result.Err()
+}
+
+// latestObservation returns the most recent observation time of the provider markets in the store.
+func (s *SQLiteStore) latestObservation(ctx context.Context) (time.Time, error) {
+	var latest sql.NullString
+	if err := s.db.QueryRowContext(ctx, `SELECT MAX(observed_at) FROM provider_markets`).Scan(&latest); err != nil {
+		return time.Time{}, fmt.Errorf("failed to query latest observation: %w", err)
+	}
+	if !latest.Valid {
+		return time.Time{}, nil
+	}
+
+	return parseSQLiteTime(latest.String)
+}
+
+// GetCMCIDToAssetInfo returns the asset infos by CMC ID. It returns an empty map if the database cannot be read.
+func (s *SQLiteStore) GetCMCIDToAssetInfo(ctx context.Context) map[int64]AssetInfo {
+	result := make(map[int64]AssetInfo)
+
+	assetInfos, err := s.assetInfos(ctx)
+	if err != nil {
+		return result
+	}
+
+	for _, assetInfo := range assetInfos {
+		// assets that are only listed on CoinGecko have no CMC ID.
+		if assetInfo.CMCID == 0 && assetInfo.CoinGeckoID != "" {
+			continue
+		}
+		result[assetInfo.CMCID] = assetInfo
+	}
+
+	return result
+}
+
+// SetIndexReport sets the index report of the store's index run.
+func (s *SQLiteStore) SetIndexReport(ctx context.Context, report IndexReport) error {
+	bz, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO index_runs (observed_at, index_report) VALUES (?, ?)
+ON CONFLICT (observed_at) DO UPDATE SET index_report = excluded.index_report`,
+		formatSQLiteTime(s.observedAt), string(bz))
+	if err != nil {
+		return fmt.Errorf("failed to set index report: %w", err)
+	}
+
+	return nil
+}
+
+// SetRunMetadata sets the metadata of the store's index run.
+func (s *SQLiteStore) SetRunMetadata(ctx context.Context, metadata RunMetadata) error {
+	bz, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO index_runs (observed_at, index_report, run_metadata) VALUES (?, '{}', ?)
+ON CONFLICT (observed_at) DO UPDATE SET run_metadata = excluded.run_metadata`,
+		formatSQLiteTime(s.observedAt), string(bz))
+	if err != nil {
+		return fmt.Errorf("failed to set run metadata: %w", err)
+	}
+
+	return nil
+}
+
+// runMetadata returns the metadata of the latest index run, or nil if it has none.
+func (s *SQLiteStore) runMetadata(ctx context.Context) (*RunMetadata, error) {
+	var bz sql.NullString
+	err := s.db.QueryRowContext(ctx, `SELECT run_metadata FROM index_runs ORDER BY observed_at DESC LIMIT 1`).Scan(&bz)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !bz.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query run metadata: %w", err)
+	}
+
+	var metadata RunMetadata
+	if err := json.Unmarshal([]byte(bz.String), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid run metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// GetIndexReport returns the index report of the latest index run. It returns an empty report if the database
+// cannot be read.
+func (s *SQLiteStore) GetIndexReport(ctx context.Context) IndexReport {
+	var bz string
+	if err := s.db.QueryRowContext(ctx, `SELECT index_report FROM index_runs ORDER BY observed_at DESC LIMIT 1`).Scan(&bz); err != nil {
+		return IndexReport{}
+	}
+
+	var report IndexReport
+	if err := json.Unmarshal([]byte(bz), &report); err != nil {
+		return IndexReport{}
+	}
+
+	return report
+}
+
+// Merge adds the asset infos and provider markets of a document, matched by the same keys as the MemoryStore,
+// and records in both keep their most recently observed values. The IDs of the document are kept where they
+// are not used by other records, so a document merged into an empty store keeps all of its IDs. The index
+// report and run metadata of the document, if any, are recorded for the index run of its latest observation
+// unless that run is already recorded. The asset info changes of the merge are added to the index reports of
+// the runs they were observed in.
+func (s *SQLiteStore) Merge(ctx context.Context, document Document) error {
+	assetInfos := append([]AssetInfo(nil), document.AssetInfos...)
+	sort.Slice(assetInfos, func(i, j int) bool { return assetInfos[i].ID < assetInfos[j].ID })
+
+	providerMarkets := append([]ProviderMarket(nil), document.ProviderMarkets...)
+	sort.Slice(providerMarkets, func(i, j int) bool { return providerMarkets[i].ID < providerMarkets[j].ID })
+
+	var changes []AssetInfoChange
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		// the IDs of the document's asset infos in the store.
+		assetInfoIDs := make(map[int32]int32, len(assetInfos))
+		for _, assetInfo := range assetInfos {
+			merged, mergeChanges, err := addSQLiteAssetInfo(ctx, tx, CreateAssetInfoParams{
+				Symbol:         assetInfo.Symbol,
+				CmcID:          assetInfo.CMCID,
+				Rank:           assetInfo.Rank,
+				MultiAddresses: assetInfo.MultiAddresses,
+				CMCTags:        assetInfo.CMCTags,
+				CoinGeckoID:    assetInfo.CoinGeckoID,
+				CoinGeckoRank:  assetInfo.CoinGeckoRank,
+				ObservedAt:     assetInfo.ObservedAt,
+			}, assetInfo.ID)
+			if err != nil {
+				return err
+			}
+			assetInfoIDs[assetInfo.ID] = merged.ID
+			changes = append(changes, mergeChanges...)
+		}
+
+		var latest time.Time
+		for _, providerMarket := range providerMarkets {
+			baseAssetInfoID, ok := assetInfoIDs[providerMarket.BaseAssetInfoID]
+			if !ok {
+				return fmt.Errorf("provider market %s of %s has unknown base asset info %d",
+					providerMarket.OffChainTicker, providerMarket.ProviderName, providerMarket.BaseAssetInfoID)
+			}
+			quoteAssetInfoID, ok := assetInfoIDs[providerMarket.QuoteAssetInfoID]
+			if !ok {
+				return fmt.Errorf("provider market %s of %s has unknown quote asset info %d",
+					providerMarket.OffChainTicker, providerMarket.ProviderName, providerMarket.QuoteAssetInfoID)
+			}
+
+			_, err := addSQLiteProviderMarket(ctx, tx, CreateProviderMarketParams{
+				TargetBase:       providerMarket.TargetBase,
+				TargetQuote:      providerMarket.TargetQuote,
+				OffChainTicker:   providerMarket.OffChainTicker,
+				ProviderName:     providerMarket.ProviderName,
+				QuoteVolume:      providerMarket.QuoteVolume,
+				UsdVolume:        providerMarket.UsdVolume,
+				BaseAssetInfoID:  baseAssetInfoID,
+				QuoteAssetInfoID: quoteAssetInfoID,
+				MetadataJSON:     []byte(providerMarket.MetadataJSON),
+				ReferencePrice:   providerMarket.ReferencePrice,
+				NegativeDepthTwo: providerMarket.NegativeDepthTwo,
+				PositiveDepthTwo: providerMarket.PositiveDepthTwo,
+				ObservedAt:       providerMarket.ObservedAt,
+			}, providerMarket.ID)
+			if err != nil {
+				return err
+			}
+
+			if providerMarket.ObservedAt.After(latest) {
+				latest = providerMarket.ObservedAt
+			}
+		}
+
+		if document.IndexReport == nil && document.Run == nil {
+			return addSQLiteIndexRunChanges(ctx, tx, changes)
+		}
+
+		report := IndexReport{}
+		if document.IndexReport != nil {
+			report = *document.IndexReport
+		}
+		reportBz, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+
+		var runBz sql.NullString
+		if document.Run != nil {
+			bz, err := json.Marshal(document.Run)
+			if err != nil {
+				return err
+			}
+			runBz = sql.NullString{String: string(bz), Valid: true}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+INSERT INTO index_runs (observed_at, index_report, run_metadata) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+			formatSQLiteTime(latest), string(reportBz), runBz)
+		if err != nil {
+			return err
+		}
+
+		return addSQLiteIndexRunChanges(ctx, tx, changes)
+	})
+	if err != nil {
+		return err
+	}
+	s.recordAssetInfoChanges(changes)
+
+	return nil
+}
+
+// addSQLiteIndexRunChanges adds asset info changes to the index reports of the index runs they were observed in,
+// so that the changes found by merging the runs of a document are kept with the runs. Changes that a report
+// already has are not added again.
+func addSQLiteIndexRunChanges(ctx context.Context, tx *sql.Tx, changes []AssetInfoChange) error {
+	byRun := make(map[string][]AssetInfoChange)
+	for _, change := range changes {
+		run := formatSQLiteTime(change.ObservedAt)
+		byRun[run] = append(byRun[run], change)
+	}
+
+	runs := make([]string, 0, len(byRun))
+	for run := range byRun {
+		runs = append(runs, run)
+	}
+	sort.Strings(runs)
+
+	for _, run := range runs {
+		var report IndexReport
+		var reportBz string
+		err := tx.QueryRowContext(ctx, `SELECT index_report FROM index_runs WHERE observed_at = ?`, run).Scan(&reportBz)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return fmt.Errorf("failed to query index report of run %s: %w", run, err)
+		default:
+			if err := json.Unmarshal([]byte(reportBz), &report); err != nil {
+				return fmt.Errorf("invalid index report of run %s: %w", run, err)
+			}
+		}
+
+		for _, change := range byRun[run] {
+			if !slices.ContainsFunc(report.AssetInfoChanges, change.Equal) {
+				report.AssetInfoChanges = append(report.AssetInfoChanges, change)
+			}
+		}
+
+		bz, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+INSERT INTO index_runs (observed_at, index_report) VALUES (?, ?)
+ON CONFLICT (observed_at) DO UPDATE SET index_report = excluded.index_report`,
+			run, string(bz))
+		if err != nil {
+			return fmt.Errorf("failed to record asset info changes of run %s: %w", run, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateOutputDocument returns the most recently observed values of the store's records, ordered by ID, and the
+// index report and run metadata of the latest index run.
+func (s *SQLiteStore) CreateOutputDocument(ctx context.Context) (Document, error) {
+	assetInfos, err := s.assetInfos(ctx)
+	if err != nil {
+		return Document{}, err
+	}
+
+	providerMarkets, err := s.providerMarkets(ctx)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var indexReport *IndexReport
+	if report := s.GetIndexReport(ctx); len(report.FailedIngesters) > 0 || len(report.FiredAliases) > 0 ||
+		len(report.AssetInfoChanges) > 0 {
+		indexReport = &report
+	}
+
+	runMetadata, err := s.runMetadata(ctx)
+	if err != nil {
+		return Document{}, err
+	}
+
+	return Document{
+		SchemaVersion:   SchemaVersion,
+		Run:             runMetadata,
+		AssetInfos:      assetInfos,
+		ProviderMarkets: providerMarkets,
+		IndexReport:     indexReport,
+	}, nil
+}
+
+// WriteToPath writes the document of the store to path, in the format selected by the extension of path.
+func (s *SQLiteStore) WriteToPath(ctx context.Context, path string) error {
+	document, err := s.CreateOutputDocument(ctx)
+	if err != nil {
+		return err
+	}
+
+	return WriteDocument(path, document)
+}
+
+func (s *SQLiteStore) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) assetInfos(ctx context.Context) ([]AssetInfo, error) {
+	result, err := s.db.QueryContext(ctx, `SELECT `+assetInfoColumns+` FROM asset_infos ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset infos: %w", err)
+	}
+	defer result.Close()
+
+	assetInfos := make([]AssetInfo, 0)
+	for result.Next() {
+		assetInfo, err := scanSQLiteAssetInfo(result)
+		if err != nil {
+			return nil, err
+		}
+		assetInfos = append(assetInfos, assetInfo)
+	}
+
+	return assetInfos, result.Err()
+}
+
+func (s *SQLiteStore) providerMarkets(ctx context.Context) ([]ProviderMarket, error) {
+	result, err := s.db.QueryContext(ctx, `SELECT `+providerMarketColumns+` FROM provider_markets ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider markets: %w", err)
+	}
+	defer result.Close()
+
+	providerMarkets := make([]ProviderMarket, 0)
+	for result.Next() {
+		providerMarket, err := scanSQLiteProviderMarket(result)
+		if err != nil {
+			return nil, err
+		}
+		providerMarkets = append(providerMarkets, providerMarket)
+	}
+
+	return providerMarkets, result.Err()
+}
+
+const assetInfoColumns = `id, symbol, is_crypto, rank, cmc_id, multi_addresses, cmc_tags, coingecko_id, coingecko_rank,
+	observed_at`
+
+const providerMarketColumns = `id, target_base, target_quote, off_chain_ticker, provider_name, quote_volume, usd_volume,
+	base_asset_info_id, quote_asset_info_id, metadata_json, reference_price, negative_depth_two, positive_depth_two, observed_at`
+
+// addSQLiteAssetInfo adds or updates an asset info like the MemoryStore does. New asset infos get preferredID
+// if it is non-negative and unused.
+func addSQLiteAssetInfo(ctx context.Context, tx *sql.Tx, params CreateAssetInfoParams, preferredID int32) (AssetInfo, []AssetInfoChange, error) {
+	var row *sql.Row
+	if params.CmcID == 0 && params.CoinGeckoID != "" {
+		row = tx.QueryRowContext(ctx, `SELECT `+assetInfoColumns+` FROM asset_infos WHERE cmc_id = 0 AND coingecko_id = ?`,
+			params.CoinGeckoID)
+	} else {
+		row = tx.QueryRowContext(ctx, `SELECT `+assetInfoColumns+` FROM asset_infos WHERE cmc_id = ? AND (cmc_id != 0 OR coingecko_id = '')`,
+			params.CmcID)
+	}
+
+	var changes []AssetInfoChange
+	assetInfo, err := scanSQLiteAssetInfo(row)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		id, err := nextSQLiteID(ctx, tx, "asset_infos", preferredID)
+		if err != nil {
+			return AssetInfo{}, nil, err
+		}
+		assetInfo = newAssetInfo(id, params)
+	case err != nil:
+		return AssetInfo{}, nil, err
+	default:
+		changes = mergeAssetInfo(&assetInfo, params)
+	}
+
+	multiAddresses, err := json.Marshal(assetInfo.MultiAddresses)
+	if err != nil {
+		return AssetInfo{}, nil, err
+	}
+	cmcTags, err := json.Marshal(assetInfo.CMCTags)
+	if err != nil {
+		return AssetInfo{}, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO asset_infos (`+assetInfoColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	symbol = excluded.symbol,
+	rank = excluded.rank,
+	multi_addresses = excluded.multi_addresses,
+	cmc_tags = excluded.cmc_tags,
+	coingecko_id = excluded.coingecko_id,
+	coingecko_rank = excluded.coingecko_rank,
+	observed_at = excluded.observed_at`,
+		assetInfo.ID, assetInfo.Symbol, assetInfo.IsCrypto, assetInfo.Rank, assetInfo.CMCID, string(multiAddresses),
+		string(cmcTags), assetInfo.CoinGeckoID, assetInfo.CoinGeckoRank, formatSQLiteTime(assetInfo.ObservedAt),
+	)
+	if err != nil {
+		return AssetInfo{}, nil, fmt.Errorf("failed to write asset info %s: %w", assetInfo.Symbol, err)
+	}
+
+	return assetInfo, changes, nil
+}
+
+// addSQLiteProviderMarket adds or updates a provider market like the MemoryStore does, and records its observation.
+// New provider markets get preferredID if it is non-negative and unused.
+func addSQLiteProviderMarket(
+	ctx context.Context,
+	tx *sql.Tx,
+	params CreateProviderMarketParams,
+	preferredID int32,
+) (ProviderMarket, error) {
+	row := tx.QueryRowContext(ctx, `SELECT `+providerMarketColumns+` FROM provider_markets WHERE off_chain_ticker = ? AND provider_name = ?`,
+		params.OffChainTicker, params.ProviderName)
+
+	providerMarket, err := scanSQLiteProviderMarket(row)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		id, err := nextSQLiteID(ctx, tx, "provider_markets", preferredID)
+		if err != nil {
+			return ProviderMarket{}, err
+		}
+		providerMarket = ProviderMarket{
+			ID:             id,
+			TargetBase:     params.TargetBase,
+			TargetQuote:    params.TargetQuote,
+			OffChainTicker: params.OffChainTicker,
+			ProviderName:   params.ProviderName,
+		}
+		setProviderMarketValues(&providerMarket, params)
+	case err != nil:
+		return ProviderMarket{}, err
+	// Don't overwrite with an older observation, e.g. when merging a previous index run.
+	case params.ObservedAt.Before(providerMarket.ObservedAt):
+	// Don't overwrite if the quote volume of the same observation is lower.
+	// e.g. we can have multiple provider markets for the same uniswap ticker because there can be multiple fee pools
+	case params.ObservedAt.Equal(providerMarket.ObservedAt) && providerMarket.QuoteVolume > params.QuoteVolume:
+	default:
+		setProviderMarketValues(&providerMarket, params)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO provider_markets (`+providerMarketColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	quote_volume = excluded.quote_volume,
+	usd_volume = excluded.usd_volume,
+	base_asset_info_id = excluded.base_asset_info_id,
+	quote_asset_info_id = excluded.quote_asset_info_id,
+	metadata_json = excluded.metadata_json,
+	reference_price = excluded.reference_price,
+	negative_depth_two = excluded.negative_depth_two,
+	positive_depth_two = excluded.positive_depth_two,
+	observed_at = excluded.observed_at`,
+		providerMarket.ID, providerMarket.TargetBase, providerMarket.TargetQuote, providerMarket.OffChainTicker,
+		providerMarket.ProviderName, providerMarket.QuoteVolume, providerMarket.UsdVolume, providerMarket.BaseAssetInfoID,
+		providerMarket.QuoteAssetInfoID, providerMarket.MetadataJSON, providerMarket.ReferencePrice,
+		providerMarket.NegativeDepthTwo, providerMarket.PositiveDepthTwo, formatSQLiteTime(providerMarket.ObservedAt),
+	)
+	if err != nil {
+		return ProviderMarket{}, fmt.Errorf("failed to write provider market %s of %s: %w", params.OffChainTicker, params.ProviderName, err)
+	}
+
+	// every index run's observation is kept, with the same rule for markets observed more than once in a run.
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO provider_market_observations (provider_market_id, observed_at, quote_volume, usd_volume, reference_price,
+	negative_depth_two, positive_depth_two) VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (provider_market_id, observed_at) DO UPDATE SET
+	quote_volume = excluded.quote_volume,
+	usd_volume = excluded.usd_volume,
+	reference_price = excluded.reference_price,
+	negative_depth_two = excluded.negative_depth_two,
+	positive_depth_two = excluded.positive_depth_two
+WHERE excluded.quote_volume >= provider_market_observations.quote_volume`,
+		providerMarket.ID, formatSQLiteTime(params.ObservedAt), params.QuoteVolume, params.UsdVolume, params.ReferencePrice,
+		params.NegativeDepthTwo, params.PositiveDepthTwo,
+	)
+	if err != nil {
+		return ProviderMarket{}, fmt.Errorf("failed to record observation of provider market %s of %s: %w",
+			params.OffChainTicker, params.ProviderName, err)
+	}
+
+	return providerMarket, nil
+}
+
+func setProviderMarketValues(providerMarket *ProviderMarket, params CreateProviderMarketParams) {
+	providerMarket.QuoteVolume = params.QuoteVolume
+	providerMarket.UsdVolume = params.UsdVolume
+	providerMarket.BaseAssetInfoID = params.BaseAssetInfoID
+	providerMarket.QuoteAssetInfoID = params.QuoteAssetInfoID
+	providerMarket.ReferencePrice = params.ReferencePrice
+	providerMarket.NegativeDepthTwo = params.NegativeDepthTwo
+	providerMarket.PositiveDepthTwo = params.PositiveDepthTwo
+	providerMarket.MetadataJSON = string(params.MetadataJSON)
+	providerMarket.ObservedAt = params.ObservedAt
+}
+
+// nextSQLiteID returns preferredID if it is non-negative and unused in table, or else the next unused ID.
+func nextSQLiteID(ctx context.Context, tx *sql.Tx, table string, preferredID int32) (int32, error) {
+	if preferredID >= 0 {
+		var used bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = ?)`, preferredID).Scan(&used); err != nil {
+			return 0, fmt.Errorf("failed to query %s: %w", table, err)
+		}
+		if !used {
+			return preferredID, nil
+		}
+	}
+
+	var id int32
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(id) + 1, 0) FROM `+table).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+
+	return id, nil
+}
+
+// sqliteScanner is a *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteAssetInfo(row sqliteScanner) (AssetInfo, error) {
+	var (
+		assetInfo      AssetInfo
+		multiAddresses string
+		cmcTags        string
+		observedAt     string
+	)
+	if err := row.Scan(&assetInfo.ID, &assetInfo.Symbol, &assetInfo.IsCrypto, &assetInfo.Rank, &assetInfo.CMCID,
+		&multiAddresses, &cmcTags, &assetInfo.CoinGeckoID, &assetInfo.CoinGeckoRank, &observedAt); err != nil {
+		return AssetInfo{}, err
+	}
+
+	if err := json.Unmarshal([]byte(multiAddresses), &assetInfo.MultiAddresses); err != nil {
+		return AssetInfo{}, fmt.Errorf("invalid multi addresses of asset info %d: %w", assetInfo.ID, err)
+	}
+	if err := json.Unmarshal([]byte(cmcTags), &assetInfo.CMCTags); err != nil {
+		return AssetInfo{}, fmt.Errorf("invalid cmc tags of asset info %d: %w", assetInfo.ID, err)
+	}
+
+	assetInfo.CMCIDValid = assetInfo.CMCID != 0
+	assetInfo.RankValid = assetInfo.Rank != 0
+
+	var err error
+	assetInfo.ObservedAt, err = parseSQLiteTime(observedAt)
+
+	return assetInfo, err
+}
+
+func scanSQLiteProviderMarket(row sqliteScanner) (ProviderMarket, error) {
+	var (
+		providerMarket ProviderMarket
+		observedAt     string
+	)
+	if err := row.Scan(&providerMarket.ID, &providerMarket.TargetBase, &providerMarket.TargetQuote,
+		&providerMarket.OffChainTicker, &providerMarket.ProviderName, &providerMarket.QuoteVolume, &providerMarket.UsdVolume,
+		&providerMarket.BaseAssetInfoID, &providerMarket.QuoteAssetInfoID, &providerMarket.MetadataJSON,
+		&providerMarket.ReferencePrice, &providerMarket.NegativeDepthTwo, &providerMarket.PositiveDepthTwo, &observedAt); err != nil {
+		return ProviderMarket{}, err
+	}
+
+	var err error
+	providerMarket.ObservedAt, err = parseSQLiteTime(observedAt)
+
+	return providerMarket, err
+}
+
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+func parseSQLiteTime(s string) (time.Time, error) {
+	t, err := time.Parse(sqliteTimeFormat, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid observation time %q: %w", s, err)
+	}
+
+	return t.UTC(), nil
+}
@@ -0,0 +1,411 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// addTestRun adds an index run of BTC/USD and ETH/USD markets, with a CoinGecko-only FOO/USD market and a
+// lower volume duplicate of BTC/USD, to the store.
+func addTestRun(t *testing.T, store Store, observedAt time.Time, volume float64) {
+	t.Helper()
+	ctx := context.Background()
+
+	btc, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1, ObservedAt: observedAt})
+	require.NoError(t, err)
+	eth, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{
+		Symbol:         "ETH",
+		CmcID:          1027,
+		Rank:           2,
+		MultiAddresses: [][]string{{"ethereum", "native"}},
+		CMCTags:        []string{"layer-1"},
+		ObservedAt:     observedAt,
+	})
+	require.NoError(t, err)
+	foo, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "FOO", CoinGeckoID: "foo", ObservedAt: observedAt})
+	require.NoError(t, err)
+	usd, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "USD", CmcID: 2781, Rank: 3, ObservedAt: observedAt})
+	require.NoError(t, err)
+
+	for _, market := range []struct {
+		base   AssetInfo
+		volume float64
+	}{
+		{base: btc, volume: volume},
+		{base: btc, volume: volume / 2},
+		{base: eth, volume: volume},
+		{base: foo, volume: volume},
+	} {
+		_, err = store.AddProviderMarket(ctx, CreateProviderMarketParams{
+			TargetBase:       market.base.Symbol,
+			TargetQuote:      "USD",
+			OffChainTicker:   market.base.Symbol + "-USD",
+			ProviderName:     "test_provider",
+			BaseAssetInfoID:  market.base.ID,
+			QuoteAssetInfoID: usd.ID,
+			MetadataJSON:     []byte(`{"pool":"` + market.base.Symbol + `"}`),
+			QuoteVolume:      market.volume,
+			UsdVolume:        market.volume,
+			ReferencePrice:   100,
+			ObservedAt:       observedAt,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+
+	store, err := NewSQLiteStoreObservedAt(filepath.Join(t.TempDir(), "provider.db"), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+
+	return store
+}
+
+func TestSQLiteStoreMatchesMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	firstRun := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondRun := firstRun.Add(time.Hour)
+
+	memoryStore := NewMemoryStore()
+	sqliteStore := newTestSQLiteStore(t)
+	for _, store := range []Store{memoryStore, sqliteStore} {
+		addTestRun(t, store, secondRun, 2000)
+		// an older run doesn't overwrite the newer values.
+		addTestRun(t, store, firstRun, 1000)
+	}
+
+	params := GetFilteredProviderMarketsParams{ProviderNames: []string{"test_provider"}}
+	want, err := memoryStore.GetProviderMarkets(ctx, params)
+	require.NoError(t, err)
+	got, err := sqliteStore.GetProviderMarkets(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.ElementsMatch(t, want, got)
+
+	require.Equal(t, memoryStore.GetCMCIDToAssetInfo(ctx), sqliteStore.GetCMCIDToAssetInfo(ctx))
+
+	got, err = sqliteStore.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{ProviderNames: []string{"other_provider"}})
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestSQLiteStoreHistory(t *testing.T) {
+	ctx := context.Background()
+	firstRun := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondRun := firstRun.Add(24 * time.Hour)
+
+	store := newTestSQLiteStore(t)
+	addTestRun(t, store, firstRun, 1000)
+	addTestRun(t, store, secondRun, 2000)
+
+	// every run's observation of a market is kept, with the higher volume of duplicates.
+	rows, err := store.DB().QueryContext(ctx, `
+SELECT o.observed_at, o.quote_volume FROM provider_market_observations o
+JOIN provider_markets pm ON pm.id = o.provider_market_id
+WHERE pm.off_chain_ticker = 'BTC-USD' ORDER BY o.observed_at`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var volumes []float64
+	for rows.Next() {
+		var (
+			observedAt string
+			volume     float64
+		)
+		require.NoError(t, rows.Scan(&observedAt, &volume))
+		volumes = append(volumes, volume)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []float64{1000, 2000}, volumes)
+
+	markets, err := store.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{
+		ProviderNames: []string{"test_provider"},
+		MaxMarketAge:  time.Hour,
+	})
+	require.NoError(t, err)
+	require.Len(t, markets, 3)
+	for _, market := range markets {
+		require.Equal(t, secondRun, market.ObservedAt)
+		require.Equal(t, 2000.0, market.QuoteVolume)
+	}
+}
+
+func TestSQLiteStoreDocumentRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	observedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	memoryStore := NewMemoryStoreObservedAt(observedAt)
+	addTestRun(t, memoryStore, observedAt, 1000)
+	report := IndexReport{FailedIngesters: []IngesterFailure{{Ingester: "okx", ProviderNames: []string{"okx_ws"}, Error: "503"}}}
+	require.NoError(t, memoryStore.SetIndexReport(ctx, report))
+	require.NoError(t, memoryStore.SetRunMetadata(ctx, RunMetadata{
+		StartedAt:  observedAt,
+		FinishedAt: observedAt.Add(time.Minute),
+		ConfigHash: "abc",
+		Ingesters:  []string{"coinbase"},
+	}))
+
+	document := memoryStore.CreateOutputDocument()
+
+	path := filepath.Join(t.TempDir(), "provider.db")
+	store, err := NewSQLiteStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Merge(ctx, document))
+	require.NoError(t, store.Close())
+
+	// the database is persisted.
+	store, err = NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	got, err := store.CreateOutputDocument(ctx)
+	require.NoError(t, err)
+	require.Equal(t, document, got)
+
+	jsonPath := filepath.Join(t.TempDir(), "provider-data.json")
+	require.NoError(t, store.WriteToPath(ctx, jsonPath))
+	fromFile, err := NewMemoryStoreFromFile(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, report, fromFile.GetIndexReport(ctx))
+	require.ElementsMatch(t, document.ProviderMarkets, fromFile.CreateOutputDocument().ProviderMarkets)
+}
+
+func TestSQLiteStoreMergeRecordsAssetInfoChanges(t *testing.T) {
+	ctx := context.Background()
+	previousRun := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	latestRun := previousRun.Add(24 * time.Hour)
+
+	runDocument := func(observedAt time.Time, symbol string, rank int64) Document {
+		store := NewMemoryStoreObservedAt(observedAt)
+		_, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: symbol, CmcID: 3890, Rank: rank})
+		require.NoError(t, err)
+		return store.CreateOutputDocument()
+	}
+	previous := runDocument(previousRun, "MATIC", 20)
+	latest := runDocument(latestRun, "POL", 25)
+
+	// the rename is recorded in the latest run, whichever run is merged first.
+	for _, documents := range [][]Document{{previous, latest}, {latest, previous}} {
+		store := newTestSQLiteStore(t)
+		for _, document := range documents {
+			require.NoError(t, store.Merge(ctx, document))
+		}
+
+		var observedAt string
+		require.NoError(t, store.DB().QueryRowContext(ctx, `SELECT observed_at FROM index_runs`).Scan(&observedAt))
+		require.Equal(t, formatSQLiteTime(latestRun), observedAt)
+
+		changes := store.GetIndexReport(ctx).AssetInfoChanges
+		require.Len(t, changes, 2)
+		for _, change := range changes {
+			require.Equal(t, latestRun, change.ObservedAt)
+			switch change.Field {
+			case AssetInfoFieldSymbol:
+				require.JSONEq(t, `"MATIC"`, string(change.OldValue))
+				require.JSONEq(t, `"POL"`, string(change.NewValue))
+			case AssetInfoFieldRank:
+				require.JSONEq(t, `20`, string(change.OldValue))
+				require.JSONEq(t, `25`, string(change.NewValue))
+			default:
+				require.Fail(t, "unexpected change", change.Field)
+			}
+		}
+
+		// merging a run again records no new changes.
+		require.NoError(t, store.Merge(ctx, previous))
+		require.Len(t, store.GetIndexReport(ctx).AssetInfoChanges, 2)
+	}
+}
+
+func TestGetProviderMarketsKeepsMarketsOfUnknownAge(t *testing.T) {
+	ctx := context.Background()
+	observedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// a document written before observation times were recorded.
+	legacy := Document{
+		AssetInfos: []AssetInfo{
+			{ID: 0, Symbol: "DOGE", CMCID: 74, CMCIDValid: true},
+			{ID: 1, Symbol: "USD", CMCID: 2781, CMCIDValid: true},
+		},
+		ProviderMarkets: []ProviderMarket{
+			{
+				ID: 0, TargetBase: "DOGE", TargetQuote: "USD", OffChainTicker: "DOGE-USD", ProviderName: "test_provider",
+				BaseAssetInfoID: 0, QuoteAssetInfoID: 1,
+			},
+		},
+	}
+
+	for _, store := range []Store{NewMemoryStoreObservedAt(observedAt), newTestSQLiteStore(t)} {
+		addTestRun(t, store, observedAt, 1000)
+		addTestRun(t, store, observedAt.Add(48*time.Hour), 1000)
+		require.NoError(t, store.Merge(ctx, legacy))
+
+		rows, err := store.GetProviderMarkets(ctx, GetFilteredProviderMarketsParams{
+			ProviderNames: []string{"test_provider"},
+			MaxMarketAge:  time.Hour,
+		})
+		require.NoError(t, err)
+
+		tickers := make([]string, 0, len(rows))
+		for _, row := range rows {
+			tickers = append(tickers, row.OffChainTicker)
+		}
+		require.ElementsMatch(t, []string{"BTC-USD", "ETH-USD", "FOO-USD", "DOGE-USD"}, tickers)
+	}
+}
+
+func TestGetProviderMarketsFilters(t *testing.T) {
+	ctx := context.Background()
+
+	memoryStore := NewMemoryStore()
+	sqliteStore := newTestSQLiteStore(t)
+	for _, store := range []Store{memoryStore, sqliteStore} {
+		btc, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "BTC", CmcID: 1, Rank: 1, CMCTags: []string{"pow"}})
+		require.NoError(t, err)
+		eth, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "ETH", CmcID: 1027, Rank: 2, CMCTags: []string{"layer-1", "pos"}})
+		require.NoError(t, err)
+		foo, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "FOO", CoinGeckoID: "foo"})
+		require.NoError(t, err)
+		usd, err := store.AddAssetInfo(ctx, CreateAssetInfoParams{Symbol: "USD", CmcID: 2781, Rank: 3})
+		require.NoError(t, err)
+
+		for _, market := range []struct {
+			base, quote AssetInfo
+			volume      float64
+			depth       float64
+		}{
+			{base: btc, quote: usd, volume: 1_000_000, depth: 100_000},
+			{base: eth, quote: usd, volume: 100_000, depth: 10_000},
+			{base: eth, quote: btc, volume: 10_000, depth: 1_000},
+			{base: foo, quote: usd, volume: 1_000, depth: 100},
+		} {
+			_, err = store.AddProviderMarket(ctx, CreateProviderMarketParams{
+				TargetBase:       market.base.Symbol,
+				TargetQuote:      market.quote.Symbol,
+				OffChainTicker:   market.base.Symbol + "-" + market.quote.Symbol,
+				ProviderName:     "test_provider",
+				BaseAssetInfoID:  market.base.ID,
+				QuoteAssetInfoID: market.quote.ID,
+				UsdVolume:        market.volume,
+				NegativeDepthTwo: market.depth,
+				PositiveDepthTwo: market.depth * 2,
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	tcs := []struct {
+		name   string
+		params GetFilteredProviderMarketsParams
+		want   []string
+	}{
+		{
+			name:   "no filters",
+			params: GetFilteredProviderMarketsParams{},
+			want:   []string{"BTC-USD", "ETH-USD", "ETH-BTC", "FOO-USD"},
+		},
+		{
+			name:   "target quotes",
+			params: GetFilteredProviderMarketsParams{TargetQuotes: []string{"BTC"}},
+			want:   []string{"ETH-BTC"},
+		},
+		{
+			name:   "min usd volume",
+			params: GetFilteredProviderMarketsParams{MinUsdVolume: 100_000},
+			want:   []string{"BTC-USD", "ETH-USD"},
+		},
+		{
+			name:   "min liquidity applies to both sides",
+			params: GetFilteredProviderMarketsParams{MinLiquidity: 2_000},
+			want:   []string{"BTC-USD", "ETH-USD"},
+		},
+		{
+			name:   "max rank excludes unranked assets",
+			params: GetFilteredProviderMarketsParams{MaxRank: 1},
+			want:   []string{"BTC-USD"},
+		},
+		{
+			name:   "rank range",
+			params: GetFilteredProviderMarketsParams{MinRank: 2, MaxRank: 10},
+			want:   []string{"ETH-USD", "ETH-BTC"},
+		},
+		{
+			name:   "cmc tags",
+			params: GetFilteredProviderMarketsParams{CMCTags: []string{"pow", "pos"}},
+			want:   []string{"BTC-USD", "ETH-USD", "ETH-BTC"},
+		},
+		{
+			name:   "base cmc ids",
+			params: GetFilteredProviderMarketsParams{BaseCmcIDs: []int64{1027}},
+			want:   []string{"ETH-USD", "ETH-BTC"},
+		},
+		{
+			name:   "combined",
+			params: GetFilteredProviderMarketsParams{TargetQuotes: []string{"USD"}, CMCTags: []string{"pos"}, MinUsdVolume: 1},
+			want:   []string{"ETH-USD"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.ProviderNames = []string{"test_provider"}
+
+			for name, store := range map[string]Store{"memory": memoryStore, "sqlite": sqliteStore} {
+				rows, err := store.GetProviderMarkets(ctx, tc.params)
+				require.NoError(t, err)
+
+				tickers := make([]string, 0, len(rows))
+				for _, row := range rows {
+					tickers = append(tickers, row.OffChainTicker)
+				}
+				require.ElementsMatch(t, tc.want, tickers, name)
+			}
+		})
+	}
+}
+
+func TestSQLiteStoreMigratesDatabases(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "provider.db")
+
+	// a database created before schema versions were recorded.
+	db, err := sql.Open("sqlite", "file:"+path)
+	require.NoError(t, err)
+	_, err = db.Exec(sqliteSchema)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO asset_infos VALUES (0, 'PEPE', true, 30, 0, '[]', '[]', 'pepe', ?)`,
+		formatSQLiteTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	store, err := NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	var version int
+	require.NoError(t, store.DB().QueryRow(`PRAGMA user_version`).Scan(&version))
+	require.Equal(t, len(sqliteMigrations), version)
+
+	run := RunMetadata{ConfigHash: "abc", Ingesters: []string{"coinbase"}}
+	require.NoError(t, store.SetRunMetadata(ctx, run))
+	document, err := store.CreateOutputDocument(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &run, document.Run)
+
+	// the CoinGecko rank of an asset that is only listed on CoinGecko is not a CMC rank.
+	require.Len(t, document.AssetInfos, 1)
+	require.Zero(t, document.AssetInfos[0].Rank)
+	require.Equal(t, int64(30), document.AssetInfos[0].CoinGeckoRank)
+
+	// migrations are only applied once.
+	reopened, err := NewSQLiteStore(path)
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+}
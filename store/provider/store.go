@@ -9,10 +9,22 @@ type Store interface {
 	AddProviderMarket(ctx context.Context, params CreateProviderMarketParams) (ProviderMarket, error)
 	AddAssetInfo(ctx context.Context, params CreateAssetInfoParams) (AssetInfo, error)
 
-	// TODO(zrbecker): The params object here includes a MaxMarketAge field that is ignored by all implemented
-	// proivder stores. This should be removed eventually. It should be considered an error to pass the field to this function.
 	GetProviderMarkets(ctx context.Context, params GetFilteredProviderMarketsParams) ([]GetFilteredProviderMarketsRow, error)
 	GetCMCIDToAssetInfo(ctx context.Context) map[int64]AssetInfo
 
+	SetIndexReport(ctx context.Context, report IndexReport) error
+	GetIndexReport(ctx context.Context) IndexReport
+
+	// GetAssetInfoChanges returns the changes that adding asset infos to the store made to existing asset infos,
+	// in the order they were made.
+	GetAssetInfoChanges(ctx context.Context) []AssetInfoChange
+
+	// SetRunMetadata sets the metadata of the store's index run, which is written to its Document.
+	SetRunMetadata(ctx context.Context, metadata RunMetadata) error
+
+	// Merge adds the asset infos and provider markets of a document from another index run. Records that
+	// are in both keep the most recently observed values.
+	Merge(ctx context.Context, document Document) error
+
 	WriteToPath(ctx context.Context, path string) error
 }
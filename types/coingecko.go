@@ -0,0 +1,30 @@
+package types
+
+type CoinGeckoInfo struct {
+	// BaseID is the ID of a base asset on coingecko
+	BaseID string `json:"base_id"`
+	// QuoteID is the ID of a quote asset on coingecko
+	QuoteID string `json:"quote_id"`
+
+	// BaseRank and QuoteRank are market cap ranks on coingecko, which are not comparable with coinmarketcap ranks.
+	BaseRank  int64 `json:"base_rank,omitempty"`
+	QuoteRank int64 `json:"quote_rank,omitempty"`
+}
+
+func NewCoinGeckoInfo(baseID, quoteID string, baseRank, quoteRank int64) CoinGeckoInfo {
+	return CoinGeckoInfo{
+		BaseID:    baseID,
+		QuoteID:   quoteID,
+		BaseRank:  baseRank,
+		QuoteRank: quoteRank,
+	}
+}
+
+func (c *CoinGeckoInfo) Invert() {
+	c.BaseID, c.QuoteID = c.QuoteID, c.BaseID
+	c.BaseRank, c.QuoteRank = c.QuoteRank, c.BaseRank
+}
+
+func (c *CoinGeckoInfo) HasRank() bool {
+	return c.BaseRank > 0
+}
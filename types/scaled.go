@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+)
+
+// scaledBaseRegex splits base symbols that represent a fixed multiple of an underlying asset into their
+// multiplier and underlying symbol, e.g. 1000SATS (1,000 SATS) or 1MBABYDOGE (1,000,000 BABYDOGE).
+var scaledBaseRegex = regexp.MustCompile(`^(10{3,}|1M)([A-Z][A-Z0-9]*)$`)
+
+// ScaledBases are the base symbols that venues list as a fixed multiple of an underlying asset. Venues
+// don't report the multiplier of their spot markets, and real tokens may have symbols that look scaled,
+// so only these symbols are treated as scaled.
+var ScaledBases = map[string]struct{}{
+	"1000SATS":   {},
+	"1000CAT":    {},
+	"1000CHEEMS": {},
+	"1MBABYDOGE": {},
+	"1000000MOG": {},
+	"10000LADYS": {},
+}
+
+// ScaledMarketMetadata is the provider metadata JSON of a market whose base is a fixed multiple of
+// an underlying asset. The market's base is the underlying asset and its reference price is the
+// price of one unit of the underlying asset, while the provider quotes the price of Multiplier units.
+type ScaledMarketMetadata struct {
+	// Multiplier is the number of units of the underlying asset in one unit of the scaled base.
+	Multiplier uint64 `json:"multiplier"`
+	// ScaledBase is the base symbol as listed by the provider, e.g. 1000SATS.
+	ScaledBase string `json:"scaled_base"`
+}
+
+// ParseScaledBase splits a scaled base symbol such as 1000SATS or 1MBABYDOGE into its multiplier and
+// the symbol of the underlying asset. It returns false if the symbol is not in ScaledBases.
+func ParseScaledBase(symbol string) (uint64, string, bool) {
+	if _, ok := ScaledBases[symbol]; !ok {
+		return 0, "", false
+	}
+
+	matches := scaledBaseRegex.FindStringSubmatch(symbol)
+	if matches == nil {
+		return 0, "", false
+	}
+
+	if matches[1] == "1M" {
+		return 1_000_000, matches[2], true
+	}
+
+	multiplier, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return multiplier, matches[2], true
+}
+
+// ScaledMarketMetadataFromJSON returns the ScaledMarketMetadata in the given provider metadata JSON.
+// It returns false if the metadata does not describe a scaled market.
+func ScaledMarketMetadataFromJSON(metadataJSON string) (ScaledMarketMetadata, bool) {
+	if metadataJSON == "" {
+		return ScaledMarketMetadata{}, false
+	}
+
+	var metadata ScaledMarketMetadata
+	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		return ScaledMarketMetadata{}, false
+	}
+
+	return metadata, metadata.Multiplier > 1
+}
@@ -0,0 +1,50 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/types"
+)
+
+func TestParseScaledBase(t *testing.T) {
+	tests := []struct {
+		symbol     string
+		multiplier uint64
+		base       string
+		scaled     bool
+	}{
+		{symbol: "1000SATS", multiplier: 1000, base: "SATS", scaled: true},
+		{symbol: "1MBABYDOGE", multiplier: 1_000_000, base: "BABYDOGE", scaled: true},
+		{symbol: "1000000MOG", multiplier: 1_000_000, base: "MOG", scaled: true},
+		{symbol: "10000LADYS", multiplier: 10_000, base: "LADYS", scaled: true},
+		{symbol: "1INCH", scaled: false},
+		{symbol: "BTC", scaled: false},
+		{symbol: "1000", scaled: false},
+		{symbol: "100X", scaled: false},
+		// tokens whose symbols look scaled are not, unless they are listed in ScaledBases.
+		{symbol: "1000X", scaled: false},
+		{symbol: "1MDOGE", scaled: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			multiplier, base, scaled := types.ParseScaledBase(tt.symbol)
+			require.Equal(t, tt.scaled, scaled)
+			require.Equal(t, tt.multiplier, multiplier)
+			require.Equal(t, tt.base, base)
+		})
+	}
+}
+
+func TestScaledMarketMetadataFromJSON(t *testing.T) {
+	metadata, ok := types.ScaledMarketMetadataFromJSON(`{"multiplier":1000,"scaled_base":"1000SATS"}`)
+	require.True(t, ok)
+	require.Equal(t, types.ScaledMarketMetadata{Multiplier: 1000, ScaledBase: "1000SATS"}, metadata)
+
+	_, ok = types.ScaledMarketMetadataFromJSON("")
+	require.False(t, ok)
+
+	_, ok = types.ScaledMarketMetadataFromJSON(`{"base_token_address":"0x1"}`)
+	require.False(t, ok)
+}